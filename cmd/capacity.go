@@ -3,8 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/marcgeld/cobrak/pkg/capacity"
 	"github.com/marcgeld/cobrak/pkg/config"
@@ -13,23 +16,32 @@ import (
 )
 
 func newCapacityCmd(kubeconfigFlag *string) *cobra.Command {
-	return &cobra.Command{
+	c := &cobra.Command{
 		Use:   "capacity",
 		Short: "Show CPU and memory capacity for each node",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			kubeconfig, _ := cmd.Root().PersistentFlags().GetString("kubeconfig")
 			kubeCtx, _ := cmd.Root().PersistentFlags().GetString("context")
 			nocolor, _ := cmd.Root().PersistentFlags().GetBool("nocolor")
+			poolLabel, _ := cmd.Flags().GetString("group-by-pool")
+			byZone, _ := cmd.Flags().GetBool("by-zone")
+			outputFlag, _ := cmd.Flags().GetString("output")
 
-			// Load settings and merge with flags
-			configFlag, _ := cmd.Root().PersistentFlags().GetString("config")
-			configPath, err := config.ResolveConfigPath(configFlag)
+			format, err := output.ParseOutputFormat(outputFlag)
 			if err != nil {
-				return fmt.Errorf("resolving config path: %w", err)
+				return err
+			}
+			if format != output.FormatText && format != output.FormatCSV {
+				return fmt.Errorf("unsupported --output %q for capacity (supported: text, csv)", outputFlag)
+			}
+			if format == output.FormatCSV && (byZone || poolLabel != "") {
+				return fmt.Errorf("--output csv cannot be combined with --by-zone or --group-by-pool")
 			}
-			settings, err := config.LoadSettingsAt(configPath)
+
+			// Load settings and merge with flags
+			settings, err := loadSettings(cmd)
 			if err != nil {
-				return fmt.Errorf("loading config: %w", err)
+				return err
 			}
 
 			// Set global color state
@@ -48,21 +60,237 @@ func newCapacityCmd(kubeconfigFlag *string) *cobra.Command {
 				return fmt.Errorf("creating k8s client: %w", err)
 			}
 
-			nodes, err := capacity.Analyze(context.Background(), client)
-			if err != nil {
-				return fmt.Errorf("analysing capacity: %w", err)
+			if byZone {
+				return printNodePools(cmd, client, settings, capacity.ZoneLabel, true)
+			}
+			if poolLabel != "" {
+				return printNodePools(cmd, client, settings, poolLabel, false)
 			}
 
-			for _, n := range nodes {
-				nodeName := cp.Colorize(n.Name, output.Header)
-				fmt.Fprintf(cmd.OutOrStdout(), "Node: %s\n", nodeName)
-				fmt.Fprintf(cmd.OutOrStdout(), "CPU: %s alloc / %s cap\n",
-					n.CPUAllocatable.String(), n.CPUCapacity.String())
-				fmt.Fprintf(cmd.OutOrStdout(), "Memory: %s alloc / %s cap\n\n",
-					n.MemAllocatable.String(), n.MemCapacity.String())
+			if format == output.FormatCSV {
+				return writeNodeCapacityCSV(cmd, client)
 			}
 
-			return nil
+			return printNodeCapacity(cmd, client, cp)
 		},
 	}
+
+	c.Flags().String("output", "text", "output format: text, or csv (one row per node with zone and instance type)")
+	addPoolFlag(c)
+	c.Flags().Bool("by-zone", false, "group nodes by "+capacity.ZoneLabel+" and warn when one zone holds a disproportionate share")
+	c.MarkFlagsMutuallyExclusive("by-zone", "group-by-pool")
+
+	c.AddCommand(newCapacityFitCmd())
+	c.AddCommand(newCapacityImbalanceCmd())
+
+	return c
+}
+
+func newCapacityFitCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "fit",
+		Short: "Check whether the cluster has room for a pod of a given size",
+		Long: `Compares the requested CPU and memory with unrequested allocatable capacity
+(allocatable minus requests). Aggregate cluster headroom can be split across many
+nodes, so the answer is based on whether any single schedulable node has room.`,
+		RunE: runCapacityFit,
+	}
+
+	c.Flags().String("cpu", "", "CPU request of the pod (e.g. 2, 500m)")
+	c.Flags().String("memory", "", "memory request of the pod (e.g. 4Gi)")
+	c.Flags().Bool("subtract-daemonsets", false, "hold back the DaemonSet requests every node ends up carrying, including nodes not yet running them all")
+	_ = c.MarkFlagRequired("cpu")
+	_ = c.MarkFlagRequired("memory")
+
+	return c
+}
+
+func runCapacityFit(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	cpuFlag, _ := c.Flags().GetString("cpu")
+	memFlag, _ := c.Flags().GetString("memory")
+	subtractDaemonSets, _ := c.Flags().GetBool("subtract-daemonsets")
+
+	cpuReq, err := resource.ParseQuantity(cpuFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --cpu %q: %w", cpuFlag, err)
+	}
+	memReq, err := resource.ParseQuantity(memFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --memory %q: %w", memFlag, err)
+	}
+
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	output.SetGlobalColorEnabled(settings.Color && !nocolor)
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("creating k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fit, err := capacity.AnalyzeFitWithOptions(ctx, client, cpuReq, memReq, capacity.FitOptions{
+		SubtractDaemonSets: subtractDaemonSets,
+	})
+	if err != nil {
+		return fmt.Errorf("analysing fit: %w", err)
+	}
+
+	fmt.Fprintln(c.OutOrStdout(), output.RenderFit(fit))
+	return nil
+}
+
+// printNodeCapacity prints allocatable and capacity for every node
+func printNodeCapacity(c *cobra.Command, client kubernetes.Interface, cp *output.ColorProvider) error {
+	nodes, err := capacity.AnalyzeWithOptions(commandContext(c), client, capacity.AnalyzeOptions{IncludeRequests: true})
+	if err != nil {
+		return fmt.Errorf("analysing capacity: %w", err)
+	}
+
+	if len(nodes) == 0 {
+		printNoData(c, output.FormatText, "nodes")
+		return nil
+	}
+
+	for _, n := range nodes {
+		nodeName := cp.Colorize(n.Name, output.Header)
+		fmt.Fprintf(c.OutOrStdout(), "Node: %s\n", nodeName)
+		fmt.Fprintf(c.OutOrStdout(), "CPU: %s/%s (%.0f%%) requested/alloc, %s cap\n",
+			n.CPURequests.String(), n.CPUAllocatable.String(), n.CPURequestPercent(), n.CPUCapacity.String())
+		fmt.Fprintf(c.OutOrStdout(), "Memory: %s/%s (%.0f%%) requested/alloc, %s cap\n\n",
+			n.MemRequests.String(), n.MemAllocatable.String(), n.MemRequestPercent(), n.MemCapacity.String())
+	}
+
+	return nil
+}
+
+// writeNodeCapacityCSV writes one CSV row per node, for node pool inventories
+func writeNodeCapacityCSV(c *cobra.Command, client kubernetes.Interface) error {
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	nodes, err := capacity.Analyze(ctx, client)
+	if err != nil {
+		return fmt.Errorf("analysing capacity: %w", err)
+	}
+
+	csv, err := output.RenderOutput(nodes, output.FormatCSV)
+	if err != nil {
+		return fmt.Errorf("rendering output: %w", err)
+	}
+	fmt.Fprint(c.OutOrStdout(), csv)
+	return nil
+}
+
+// addPoolFlag registers --group-by-pool; without a value it groups by instance type
+func addPoolFlag(c *cobra.Command) {
+	c.Flags().String("group-by-pool", "", "group nodes into pools by a node label (default label: "+capacity.DefaultPoolLabel+")")
+	c.Flags().Lookup("group-by-pool").NoOptDefVal = capacity.DefaultPoolLabel
+}
+
+// printNodePools renders per-pool totals and pressure for nodes grouped by labelKey,
+// optionally followed by warnings for pools holding a disproportionate share
+func printNodePools(c *cobra.Command, client kubernetes.Interface, settings *config.Settings, labelKey string, warnImbalance bool) error {
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	pools, err := capacity.AnalyzePools(ctx, client, labelKey, capacity.PressureOptions{
+		Thresholds: thresholdsFromSettings(settings),
+	})
+	if err != nil {
+		return fmt.Errorf("analysing node pools: %w", err)
+	}
+
+	if len(pools) == 0 {
+		printNoData(c, output.FormatText, "nodes")
+		return nil
+	}
+
+	fmt.Fprintln(c.OutOrStdout(), output.RenderNodePoolTable(pools))
+
+	if warnImbalance {
+		if warnings := output.RenderPoolImbalance(capacity.DisproportionatePools(pools, capacity.ZoneImbalanceFactor)); warnings != "" {
+			fmt.Fprintf(c.OutOrStdout(), "\n%s\n", warnings)
+		}
+	}
+
+	return nil
+}
+
+func newCapacityImbalanceCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "imbalance",
+		Short: "Show how evenly requests are spread across nodes",
+		Long: `Computes the mean, standard deviation and range of per-node CPU and memory
+request utilization and warns when the hottest node sits far above the mean,
+which usually points at poor bin-packing or restrictive affinity rules.`,
+		RunE: runCapacityImbalance,
+	}
+
+	c.Flags().Float64("max-deviation", capacity.DefaultImbalanceDeviation, "percentage points the hottest node may exceed the mean utilization before warning")
+
+	return c
+}
+
+func runCapacityImbalance(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	maxDeviation, _ := c.Flags().GetFloat64("max-deviation")
+
+	if maxDeviation < 0 {
+		return fmt.Errorf("invalid --max-deviation %v: must not be negative", maxDeviation)
+	}
+
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	output.SetGlobalColorEnabled(settings.Color && !nocolor)
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("creating k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	return printNodeImbalance(ctx, c, client, thresholdsFromSettings(settings), maxDeviation)
+}
+
+// printNodeImbalance calculates node pressure and prints the utilization spread
+func printNodeImbalance(ctx context.Context, c *cobra.Command, client kubernetes.Interface, thresholds capacity.PressureThresholds, maxDeviation float64) error {
+	pressure, err := capacity.CalculatePressureWithOptions(ctx, client, "", capacity.PressureOptions{
+		Thresholds: thresholds,
+	})
+	if err != nil {
+		return fmt.Errorf("calculating pressure: %w", err)
+	}
+
+	if len(pressure.NodePressures) == 0 {
+		printNoData(c, output.FormatText, "nodes")
+		return nil
+	}
+
+	imbalance := capacity.AnalyzeImbalance(pressure.NodePressures)
+	fmt.Fprintln(c.OutOrStdout(), output.RenderNodeImbalance(imbalance, maxDeviation))
+	return nil
 }
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPrintNodeCapacity_EmptyCluster(t *testing.T) {
+	var out bytes.Buffer
+	c := &cobra.Command{}
+	c.SetOut(&out)
+
+	if err := printNodeCapacity(c, fake.NewSimpleClientset(), output.NewColorProvider(false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No nodes found in scope") {
+		t.Errorf("expected empty-scope note, got %q", out.String())
+	}
+}
+
+func TestWriteNodeCapacityCSV(t *testing.T) {
+	node := func(name, zone, instanceType string) *corev1.Node {
+		labels := map[string]string{capacity.ZoneLabel: zone}
+		if instanceType != "" {
+			labels[capacity.DefaultPoolLabel] = instanceType
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("3800m"),
+					corev1.ResourceMemory: resource.MustParse("15Gi"),
+				},
+			},
+		}
+	}
+
+	var out bytes.Buffer
+	c := &cobra.Command{}
+	c.SetOut(&out)
+	client := fake.NewSimpleClientset(node("worker-1", "eu-1a", "m5.xlarge"), node("worker-2", "eu-1b", ""))
+	if err := writeNodeCapacityCSV(c, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	want := [][]string{
+		{"node", "cpu_capacity", "cpu_allocatable", "mem_capacity", "mem_allocatable", "zone", "instance_type"},
+		{"worker-1", "4", "3800m", "16Gi", "15Gi", "eu-1a", "m5.xlarge"},
+		{"worker-2", "4", "3800m", "16Gi", "15Gi", "eu-1b", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("unexpected CSV rows:\n got %v\nwant %v", records, want)
+	}
+}
+
+func TestPrintNodeImbalance_EmptyCluster(t *testing.T) {
+	var out bytes.Buffer
+	c := &cobra.Command{}
+	c.SetOut(&out)
+
+	err := printNodeImbalance(context.Background(), c, fake.NewSimpleClientset(), capacity.DefaultPressureThresholds(), capacity.DefaultImbalanceDeviation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No nodes found in scope") {
+		t.Errorf("expected empty-scope note, got %q", out.String())
+	}
+}
@@ -2,8 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/marcgeld/cobrak/pkg/capacity"
 	"github.com/marcgeld/cobrak/pkg/config"
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -17,28 +22,175 @@ func newConfigCmd() *cobra.Command {
 	c.AddCommand(newConfigSetCmd())
 	c.AddCommand(newConfigShowCmd())
 	c.AddCommand(newConfigResetCmd())
+	c.AddCommand(newConfigListProfilesCmd())
 
 	return c
 }
 
+// selectedProfile returns the profile chosen via --profile, falling back to
+// COBRAK_PROFILE when neither --config nor COBRAK_CONFIG is set
+func selectedProfile(c *cobra.Command) string {
+	configFlag, _ := c.Root().PersistentFlags().GetString("config")
+	profile, _ := c.Root().PersistentFlags().GetString("profile")
+	if profile == "" && configFlag == "" && os.Getenv("COBRAK_CONFIG") == "" {
+		profile = config.ProfileFromEnv()
+	}
+	return profile
+}
+
+// resolveConfigPath resolves the settings file for a command.
+// Precedence: --config > --profile > COBRAK_CONFIG > COBRAK_PROFILE > ~/.cobrak/settings.toml.
+func resolveConfigPath(c *cobra.Command) (string, error) {
+	configFlag, _ := c.Root().PersistentFlags().GetString("config")
+	if configFlag == "" {
+		if profile := selectedProfile(c); profile != "" {
+			path, err := config.ResolveProfilePath(profile)
+			if err != nil {
+				return "", fmt.Errorf("resolving profile %q: %w", profile, err)
+			}
+			return path, nil
+		}
+	}
+
+	configPath, err := config.ResolveConfigPath(configFlag)
+	if err != nil {
+		return "", fmt.Errorf("resolving config path: %w", err)
+	}
+	return configPath, nil
+}
+
+// loadSettings loads settings for a command, honouring --config and --profile,
+// then applies COBRAK_* environment overrides (flags > env > file > defaults).
+// A selected profile must exist; the default settings file may be absent.
+func loadSettings(c *cobra.Command) (*config.Settings, error) {
+	settings, err := loadFileSettings(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := settings.ApplyEnv(); err != nil {
+		return nil, err
+	}
+	if err := output.SetGlobalTheme(settings.Theme); err != nil {
+		return nil, err
+	}
+	output.SetGlobalPressureStyle(pressureStyleFromSettings(settings))
+	return settings, nil
+}
+
+// pressureStyleFromSettings converts configured pressure labels and colors
+// into the output package's style
+func pressureStyleFromSettings(settings *config.Settings) output.PressureStyle {
+	return output.PressureStyle{
+		Labels: map[capacity.PressureLevel]string{
+			capacity.PressureLow:       settings.PressureLabels.Low,
+			capacity.PressureMedium:    settings.PressureLabels.Medium,
+			capacity.PressureHigh:      settings.PressureLabels.High,
+			capacity.PressureSaturated: settings.PressureLabels.Saturated,
+		},
+		Colors: map[capacity.PressureLevel]string{
+			capacity.PressureLow:       settings.PressureColors.Low,
+			capacity.PressureMedium:    settings.PressureColors.Medium,
+			capacity.PressureHigh:      settings.PressureColors.High,
+			capacity.PressureSaturated: settings.PressureColors.Saturated,
+		},
+	}
+}
+
+// pressureSimpleOptionsFromSettings converts the configured namespace display
+// threshold into options for the simple pressure view
+func pressureSimpleOptionsFromSettings(settings *config.Settings) output.PressureSimpleOptions {
+	return output.PressureSimpleOptions{NamespaceThreshold: settings.NamespaceDisplayThreshold}
+}
+
+// scopedNamespace returns namespace unless it is empty and default_scope is
+// "current", in which case the kubeconfig context's namespace is used.
+// An explicit --all-namespaces keeps the scan cluster-wide.
+func scopedNamespace(c *cobra.Command, settings *config.Settings, namespace string) (string, error) {
+	if namespace != "" || settings.DefaultScope != config.ScopeCurrent {
+		return namespace, nil
+	}
+	if flag := c.Flags().Lookup("all-namespaces"); flag != nil && flag.Changed && flag.Value.String() == "true" {
+		return "", nil
+	}
+
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	current, err := k8s.ContextNamespace(kubeconfig, kubeCtx)
+	if err != nil {
+		return "", fmt.Errorf("resolving namespace for default_scope %q: %w", config.ScopeCurrent, err)
+	}
+	return current, nil
+}
+
+// loadFileSettings loads the selected profile or config file without env overrides
+func loadFileSettings(c *cobra.Command) (*config.Settings, error) {
+	configFlag, _ := c.Root().PersistentFlags().GetString("config")
+	if configFlag == "" {
+		if profile := selectedProfile(c); profile != "" {
+			settings, err := config.LoadProfile(profile)
+			if err != nil {
+				return nil, fmt.Errorf("loading profile: %w", err)
+			}
+			return settings, nil
+		}
+	}
+
+	configPath, err := resolveConfigPath(c)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := config.LoadSettingsAt(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	return settings, nil
+}
+
 func newConfigSetCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "set",
-		Short: "Set configuration value",
-		Long:  "Set a configuration value in ~/.cobrak/settings.toml",
-		Args:  cobra.ExactArgs(2),
-		RunE:  runConfigSet,
+		Use:   "set <key> <value> | set <key>=<value>...",
+		Short: "Set configuration values",
+		Long: `Set configuration values in ~/.cobrak/settings.toml, either one as
+"set <key> <value>" or several as "set <key>=<value>...". All values are
+validated before the file is written, so one invalid value leaves it untouched.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runConfigSet,
 	}
 }
 
+// configAssignment is a single key/value pair passed to config set
+type configAssignment struct {
+	key   string
+	value string
+}
+
+// parseConfigAssignments accepts either "<key> <value>" or any number of
+// "<key>=<value>" arguments
+func parseConfigAssignments(args []string) ([]configAssignment, error) {
+	if len(args) == 2 && !strings.Contains(args[0], "=") {
+		return []configAssignment{{key: args[0], value: args[1]}}, nil
+	}
+
+	assignments := make([]configAssignment, 0, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid argument %q: expected <key>=<value>", arg)
+		}
+		assignments = append(assignments, configAssignment{key: key, value: value})
+	}
+	return assignments, nil
+}
+
 func runConfigSet(c *cobra.Command, args []string) error {
-	key := args[0]
-	value := args[1]
+	assignments, err := parseConfigAssignments(args)
+	if err != nil {
+		return err
+	}
 
-	configFlag, _ := c.Root().PersistentFlags().GetString("config")
-	configPath, err := config.ResolveConfigPath(configFlag)
+	configPath, err := resolveConfigPath(c)
 	if err != nil {
-		return fmt.Errorf("resolving config path: %w", err)
+		return err
 	}
 
 	// Load current settings
@@ -47,13 +199,46 @@ func runConfigSet(c *cobra.Command, args []string) error {
 		return fmt.Errorf("loading config: %w", err)
 	}
 
-	// Update the setting based on key
+	// Apply every value before saving so an invalid one leaves the file untouched
+	for _, a := range assignments {
+		if err := applyConfigValue(settings, a.key, a.value); err != nil {
+			return err
+		}
+	}
+
+	// Save settings
+	if err := config.SaveSettingsAt(configPath, settings); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	fmt.Fprintf(c.OutOrStdout(), "✓ Configuration updated\n")
+	fmt.Fprintf(c.OutOrStdout(), "  Config file: %s\n", configPath)
+	for _, a := range assignments {
+		fmt.Fprintf(c.OutOrStdout(), "  %s = %s\n", a.key, a.value)
+	}
+
+	return nil
+}
+
+// applyConfigValue validates value and stores it under key in settings
+func applyConfigValue(settings *config.Settings, key, value string) error {
 	switch key {
 	case "output":
 		settings.Output = value
 	case "namespace":
+		if err := config.ValidateNamespace(value); err != nil {
+			return fmt.Errorf("invalid value for 'namespace': %w", err)
+		}
 		settings.Namespace = value
+	case "default_scope":
+		if err := config.ValidateScope(value); err != nil {
+			return fmt.Errorf("invalid value for 'default_scope': %w", err)
+		}
+		settings.DefaultScope = value
 	case "context":
+		if err := config.ValidateContext(value); err != nil {
+			return fmt.Errorf("invalid value for 'context': %w", err)
+		}
 		settings.Context = value
 	case "top":
 		var topVal int
@@ -62,6 +247,16 @@ func runConfigSet(c *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid value for 'top': must be a number")
 		}
 		settings.Top = topVal
+	case "namespace_display_threshold":
+		var val float64
+		_, err := fmt.Sscanf(value, "%f", &val)
+		if err != nil {
+			return fmt.Errorf("invalid value for 'namespace_display_threshold': must be a number")
+		}
+		if err := config.ValidateNamespaceDisplayThreshold(val); err != nil {
+			return fmt.Errorf("invalid value for 'namespace_display_threshold': %w", err)
+		}
+		settings.NamespaceDisplayThreshold = val
 	case "pressure_thresholds.low":
 		var val float64
 		_, err := fmt.Sscanf(value, "%f", &val)
@@ -90,39 +285,62 @@ func runConfigSet(c *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid value for pressure threshold: must be a number")
 		}
 		settings.PressureThresholds.Saturated = val
+	case "pressure_labels.low":
+		settings.PressureLabels.Low = value
+	case "pressure_labels.medium":
+		settings.PressureLabels.Medium = value
+	case "pressure_labels.high":
+		settings.PressureLabels.High = value
+	case "pressure_labels.saturated":
+		settings.PressureLabels.Saturated = value
+	case "pressure_colors.low", "pressure_colors.medium", "pressure_colors.high", "pressure_colors.saturated":
+		if err := config.ValidatePressureColor(value); err != nil {
+			return fmt.Errorf("invalid value for '%s': %w", key, err)
+		}
+		switch key {
+		case "pressure_colors.low":
+			settings.PressureColors.Low = value
+		case "pressure_colors.medium":
+			settings.PressureColors.Medium = value
+		case "pressure_colors.high":
+			settings.PressureColors.High = value
+		default:
+			settings.PressureColors.Saturated = value
+		}
 	case "color":
 		colorVal := value == "true" || value == "1" || value == "yes"
 		settings.Color = colorVal
+	case "display.show_requests":
+		settings.Display.ShowRequests = value == "true" || value == "1" || value == "yes"
+	case "display.show_limits":
+		settings.Display.ShowLimits = value == "true" || value == "1" || value == "yes"
+	case "theme":
+		if err := config.ValidateTheme(value); err != nil {
+			return fmt.Errorf("invalid value for 'theme': %w", err)
+		}
+		settings.Theme = value
 	default:
-		return fmt.Errorf("unknown config key: %s (valid keys: output, namespace, context, top, color, pressure_thresholds.low, pressure_thresholds.medium, pressure_thresholds.high, pressure_thresholds.saturated)", key)
+		return fmt.Errorf("unknown config key: %s (valid keys: output, namespace, default_scope, context, top, color, theme, namespace_display_threshold, pressure_thresholds.low, pressure_thresholds.medium, pressure_thresholds.high, pressure_thresholds.saturated, pressure_labels.<level>, pressure_colors.<level>, display.show_requests, display.show_limits)", key)
 	}
 
-	// Save settings
-	if err := config.SaveSettingsAt(configPath, settings); err != nil {
-		return fmt.Errorf("saving config: %w", err)
-	}
-
-	fmt.Fprintf(c.OutOrStdout(), "✓ Configuration updated\n")
-	fmt.Fprintf(c.OutOrStdout(), "  Config file: %s\n", configPath)
-	fmt.Fprintf(c.OutOrStdout(), "  %s = %s\n", key, value)
-
 	return nil
 }
 
 func newConfigShowCmd() *cobra.Command {
-	return &cobra.Command{
+	c := &cobra.Command{
 		Use:   "show",
 		Short: "Show current configuration",
 		Long:  "Display the current configuration from ~/.cobrak/settings.toml",
 		RunE:  runConfigShow,
 	}
+	c.Flags().Bool("explain-thresholds", false, "also show which utilization range maps to each pressure level")
+	return c
 }
 
 func runConfigShow(c *cobra.Command, _ []string) error {
-	configFlag, _ := c.Root().PersistentFlags().GetString("config")
-	configPath, err := config.ResolveConfigPath(configFlag)
+	configPath, err := resolveConfigPath(c)
 	if err != nil {
-		return fmt.Errorf("resolving config path: %w", err)
+		return err
 	}
 
 	// Load settings
@@ -132,8 +350,9 @@ func runConfigShow(c *cobra.Command, _ []string) error {
 	}
 
 	fmt.Fprintf(c.OutOrStdout(), "Configuration file: %s\n\n", configPath)
-	fmt.Fprintf(c.OutOrStdout(), "output:    %s (text, json, yaml)\n", settings.Output)
-	fmt.Fprintf(c.OutOrStdout(), "namespace: %s (empty = all namespaces)\n", settings.Namespace)
+	fmt.Fprintf(c.OutOrStdout(), "output:    %s (text, json, json-compact, jsonl, yaml)\n", settings.Output)
+	fmt.Fprintf(c.OutOrStdout(), "namespace: %s (empty = use default_scope)\n", settings.Namespace)
+	fmt.Fprintf(c.OutOrStdout(), "default_scope: %s (all or current; applies when no namespace is set)\n", settings.DefaultScope)
 	fmt.Fprintf(c.OutOrStdout(), "context:   %s (empty = current context)\n", settings.Context)
 	fmt.Fprintf(c.OutOrStdout(), "top:       %d\n", settings.Top)
 	colorStatus := "enabled"
@@ -141,11 +360,28 @@ func runConfigShow(c *cobra.Command, _ []string) error {
 		colorStatus = "disabled"
 	}
 	fmt.Fprintf(c.OutOrStdout(), "color:     %s (true or false)\n", colorStatus)
+	fmt.Fprintf(c.OutOrStdout(), "theme:     %s (%s)\n", settings.Theme, strings.Join(config.ThemeNames, ", "))
 	fmt.Fprintf(c.OutOrStdout(), "\nPressure Thresholds:\n")
 	fmt.Fprintf(c.OutOrStdout(), "  low:       %.1f (0-100)\n", settings.PressureThresholds.Low)
 	fmt.Fprintf(c.OutOrStdout(), "  medium:    %.1f (0-100, must be > low)\n", settings.PressureThresholds.Medium)
 	fmt.Fprintf(c.OutOrStdout(), "  high:      %.1f (0-100, must be > medium)\n", settings.PressureThresholds.High)
 	fmt.Fprintf(c.OutOrStdout(), "  saturated: %.1f (0-100, must be > high)\n", settings.PressureThresholds.Saturated)
+	if explain, _ := c.Flags().GetBool("explain-thresholds"); explain {
+		nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+		output.SetGlobalColorEnabled(settings.Color && !nocolor)
+		output.SetGlobalPressureStyle(pressureStyleFromSettings(settings))
+		fmt.Fprintf(c.OutOrStdout(), "\n%s\n", output.RenderPressureLegend(thresholdsFromSettings(settings)))
+	}
+	fmt.Fprintf(c.OutOrStdout(), "\nPressure Labels (empty = built-in name):\n")
+	fmt.Fprintf(c.OutOrStdout(), "  low:       %s\n", settings.PressureLabels.Low)
+	fmt.Fprintf(c.OutOrStdout(), "  medium:    %s\n", settings.PressureLabels.Medium)
+	fmt.Fprintf(c.OutOrStdout(), "  high:      %s\n", settings.PressureLabels.High)
+	fmt.Fprintf(c.OutOrStdout(), "  saturated: %s\n", settings.PressureLabels.Saturated)
+	fmt.Fprintf(c.OutOrStdout(), "\nPressure Colors (empty = built-in color; %s):\n", strings.Join(config.PressureColorNames, ", "))
+	fmt.Fprintf(c.OutOrStdout(), "  low:       %s\n", settings.PressureColors.Low)
+	fmt.Fprintf(c.OutOrStdout(), "  medium:    %s\n", settings.PressureColors.Medium)
+	fmt.Fprintf(c.OutOrStdout(), "  high:      %s\n", settings.PressureColors.High)
+	fmt.Fprintf(c.OutOrStdout(), "  saturated: %s\n", settings.PressureColors.Saturated)
 	fmt.Fprintf(c.OutOrStdout(), "\nNote: Command-line flags (like --nocolor) override these settings\n")
 
 	return nil
@@ -161,10 +397,9 @@ func newConfigResetCmd() *cobra.Command {
 }
 
 func runConfigReset(c *cobra.Command, _ []string) error {
-	configFlag, _ := c.Root().PersistentFlags().GetString("config")
-	configPath, err := config.ResolveConfigPath(configFlag)
+	configPath, err := resolveConfigPath(c)
 	if err != nil {
-		return fmt.Errorf("resolving config path: %w", err)
+		return err
 	}
 
 	// Create default settings
@@ -183,3 +418,38 @@ func runConfigReset(c *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+func newConfigListProfilesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-profiles",
+		Short: "List named configuration profiles",
+		Long:  "List profiles stored in ~/.cobrak/profiles/<name>.toml. Select one with --profile or COBRAK_PROFILE.",
+		Args:  cobra.NoArgs,
+		RunE:  runConfigListProfiles,
+	}
+}
+
+func runConfigListProfiles(c *cobra.Command, _ []string) error {
+	names, err := config.ListProfiles()
+	if err != nil {
+		return fmt.Errorf("listing profiles: %w", err)
+	}
+
+	w := c.OutOrStdout()
+	if len(names) == 0 {
+		dir, _ := config.ProfilesDir()
+		fmt.Fprintf(w, "No profiles found in %s\n", dir)
+		return nil
+	}
+
+	active := selectedProfile(c)
+	for _, name := range names {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %s\n", marker, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/config"
+	"github.com/marcgeld/cobrak/pkg/output"
+)
+
+func TestScopedNamespace_TogglesDefaultScope(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	kubeconfigYAML := `apiVersion: v1
+kind: Config
+current-context: team
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: test
+  user:
+    token: secret
+contexts:
+- name: team
+  context:
+    cluster: test
+    user: test
+    namespace: payments
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfigYAML), 0600); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+
+	root := NewRootCmd()
+	if err := root.PersistentFlags().Set("kubeconfig", kubeconfigPath); err != nil {
+		t.Fatalf("setting --kubeconfig: %v", err)
+	}
+	c, _, err := root.Find([]string{"resources", "inventory"})
+	if err != nil {
+		t.Fatalf("finding command: %v", err)
+	}
+
+	settings := config.DefaultSettings()
+	if ns, err := scopedNamespace(c, settings, ""); err != nil || ns != "" {
+		t.Errorf("expected all namespaces with default_scope=all, got %q (err %v)", ns, err)
+	}
+
+	settings.DefaultScope = config.ScopeCurrent
+	if ns, err := scopedNamespace(c, settings, ""); err != nil || ns != "payments" {
+		t.Errorf("expected context namespace with default_scope=current, got %q (err %v)", ns, err)
+	}
+	if ns, err := scopedNamespace(c, settings, "kube-system"); err != nil || ns != "kube-system" {
+		t.Errorf("expected explicit namespace to win, got %q (err %v)", ns, err)
+	}
+}
+
+// runConfigSetIn runs `config set args...` against a settings file under a temporary home
+func runConfigSetIn(t *testing.T, home string, args ...string) error {
+	t.Helper()
+	t.Setenv("HOME", home)
+	t.Setenv("COBRAK_CONFIG", "")
+	t.Setenv("COBRAK_PROFILE", "")
+
+	root := NewRootCmd()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{"config", "set"}, args...))
+	return root.Execute()
+}
+
+func TestConfigSet_MultipleKeys(t *testing.T) {
+	home := t.TempDir()
+	if err := runConfigSetIn(t, home, "output=json", "top=50", "color=false"); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+
+	settings, err := config.LoadSettingsAt(filepath.Join(home, ".cobrak", "settings.toml"))
+	if err != nil {
+		t.Fatalf("loading settings: %v", err)
+	}
+	if settings.Output != "json" || settings.Top != 50 || settings.Color {
+		t.Errorf("expected output=json top=50 color=false, got output=%s top=%d color=%v", settings.Output, settings.Top, settings.Color)
+	}
+
+	// The two-argument form keeps working
+	if err := runConfigSetIn(t, home, "top", "20"); err != nil {
+		t.Fatalf("config set <key> <value> failed: %v", err)
+	}
+	settings, err = config.LoadSettingsAt(filepath.Join(home, ".cobrak", "settings.toml"))
+	if err != nil {
+		t.Fatalf("loading settings: %v", err)
+	}
+	if settings.Top != 20 || settings.Output != "json" {
+		t.Errorf("expected top=20 with output kept, got top=%d output=%s", settings.Top, settings.Output)
+	}
+}
+
+func TestConfigSet_InvalidKeyAbortsAll(t *testing.T) {
+	home := t.TempDir()
+	path := filepath.Join(home, ".cobrak", "settings.toml")
+
+	err := runConfigSetIn(t, home, "output=json", "top=fifty", "color=false")
+	if err == nil {
+		t.Fatal("expected an error for top=fifty")
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Errorf("expected no settings file to be written, stat returned %v", statErr)
+	}
+
+	if err := runConfigSetIn(t, home, "output=yaml", "bogus=1"); err == nil {
+		t.Fatal("expected an error for an unknown key")
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Errorf("expected no settings file after an unknown key, stat returned %v", statErr)
+	}
+}
+
+func TestConfigShow_ExplainThresholds(t *testing.T) {
+	home := t.TempDir()
+	if err := runConfigSetIn(t, home, "pressure_thresholds.low=30", "pressure_thresholds.medium=60",
+		"pressure_thresholds.high=80", "pressure_thresholds.saturated=95"); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	defer output.SetGlobalPressureStyle(output.PressureStyle{})
+
+	var buf bytes.Buffer
+	root := NewRootCmd()
+	root.SetOut(&buf)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"config", "show", "--explain-thresholds", "--nocolor"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("config show failed: %v", err)
+	}
+
+	for _, want := range []string{"LOW        below 60%", "MEDIUM     60% to 80%", "HIGH       80% to 95%", "SATURATED  95% and above"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in output, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestConfigSet_RejectsInvalidNamespace(t *testing.T) {
+	home := t.TempDir()
+	path := filepath.Join(home, ".cobrak", "settings.toml")
+
+	for _, ns := range []string{"UPPER", "bad ns!"} {
+		if err := runConfigSetIn(t, home, "namespace", ns); err == nil {
+			t.Errorf("expected an error for namespace %q", ns)
+		}
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Errorf("expected no settings file to be written, stat returned %v", statErr)
+	}
+
+	if err := runConfigSetIn(t, home, "namespace=", "context=prod"); err != nil {
+		t.Fatalf("expected empty namespace to be accepted, got %v", err)
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"sort"
 	"time"
 
-	"github.com/marcgeld/cobrak/pkg/config"
+	"github.com/marcgeld/cobrak/pkg/capacity"
 	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/logging"
 	"github.com/marcgeld/cobrak/pkg/nodeinfo"
 	"github.com/marcgeld/cobrak/pkg/output"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 )
 
 func newNodeInfoCmd() *cobra.Command {
@@ -23,7 +25,10 @@ func newNodeInfoCmd() *cobra.Command {
 
 	c.Flags().String("node", "", "specific node name (default: all nodes)")
 	c.Flags().Bool("compact", false, "show compact format")
+	c.Flags().Bool("summary", false, "show a few key lines per node: health, allocatable, GPU and runtime")
 	c.Flags().Bool("health", false, "show only health status")
+	c.Flags().String("sort-by", nodeSortName, "order of nodes when listing all: name, cpu, mem (hottest first) or health (CRITICAL first)")
+	addPoolFlag(c)
 
 	return c
 }
@@ -34,17 +39,18 @@ func runNodeInfo(c *cobra.Command, _ []string) error {
 	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
 	nodeName, _ := c.Flags().GetString("node")
 	compact, _ := c.Flags().GetBool("compact")
+	summary, _ := c.Flags().GetBool("summary")
 	healthOnly, _ := c.Flags().GetBool("health")
+	poolLabel, _ := c.Flags().GetString("group-by-pool")
+	sortBy, _ := c.Flags().GetString("sort-by")
+	if err := validateNodeSort(sortBy); err != nil {
+		return err
+	}
 
 	// Load settings and merge with flags
-	configFlag, _ := c.Root().PersistentFlags().GetString("config")
-	configPath, err := config.ResolveConfigPath(configFlag)
-	if err != nil {
-		return fmt.Errorf("resolving config path: %w", err)
-	}
-	settings, err := config.LoadSettingsAt(configPath)
+	settings, err := loadSettings(c)
 	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return err
 	}
 
 	// Set global color state
@@ -61,61 +67,191 @@ func runNodeInfo(c *cobra.Command, _ []string) error {
 		return fmt.Errorf("building k8s client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	metrics, err := nodeinfo.NewNodeMetricsReaderFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building metrics client: %w", err)
+	}
+
+	if poolLabel != "" {
+		return printNodePools(c, client, settings, poolLabel, false)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 30*time.Second)
 	defer cancel()
 
-	// Analyze specific node or all nodes
-	if nodeName != "" {
-		info, err := nodeinfo.AnalyzeNode(ctx, client, nodeName)
+	view := nodeViewFull
+	switch {
+	case healthOnly:
+		view = nodeViewHealth
+	case compact:
+		view = nodeViewCompact
+	case summary:
+		view = nodeViewSummary
+	}
+
+	if nodeName == "" {
+		return printAllNodeInfo(ctx, c, client, metrics, view, sortBy)
+	}
+
+	info, err := nodeinfo.AnalyzeNode(ctx, client, nodeName)
+	if err != nil {
+		return fmt.Errorf("analyzing node %s: %w", nodeName, err)
+	}
+	if view != nodeViewHealth {
+		infos := []nodeinfo.NodeInfo{*info}
+		applyNodeMetrics(ctx, metrics, infos)
+		info = &infos[0]
+	}
+
+	switch view {
+	case nodeViewHealth:
+		health, err := nodeinfo.GetNodeHealthStatus(ctx, client, nodeName)
 		if err != nil {
-			return fmt.Errorf("analyzing node %s: %w", nodeName, err)
+			return fmt.Errorf("getting node health: %w", err)
 		}
+		fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderNodeHealth(health))
+	case nodeViewCompact:
+		fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderNodeInfoCompact(info))
+	case nodeViewSummary:
+		fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderNodeInfoSummary(info))
+	default:
+		fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderNodeInfo(info))
+	}
 
-		if healthOnly {
-			health, err := nodeinfo.GetNodeHealthStatus(ctx, client, nodeName)
-			if err != nil {
-				return fmt.Errorf("getting node health: %w", err)
-			}
-			fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderNodeHealth(health))
-		} else if compact {
-			fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderNodeInfoCompact(info))
-		} else {
-			fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderNodeInfo(info))
+	return nil
+}
+
+// nodeInfoView selects how much nodeinfo prints per node
+type nodeInfoView int
+
+const (
+	nodeViewFull nodeInfoView = iota
+	nodeViewSummary
+	nodeViewCompact
+	nodeViewHealth
+)
+
+// printAllNodeInfo prints every node in sortBy order using view
+func printAllNodeInfo(ctx context.Context, c *cobra.Command, client kubernetes.Interface, metrics nodeinfo.NodeMetricsReader, view nodeInfoView, sortBy string) error {
+	// Health is evaluated from the listed nodes, without a get per node
+	infos, health, err := nodeinfo.AnalyzeAllNodesWithHealth(ctx, client)
+	if err != nil {
+		return fmt.Errorf("analyzing all nodes: %w", err)
+	}
+	if view != nodeViewHealth {
+		applyNodeMetrics(ctx, metrics, infos)
+	}
+
+	if len(infos) == 0 {
+		printNoData(c, output.FormatText, "nodes")
+		return nil
+	}
+
+	if err := sortNodeInfos(ctx, client, infos, sortBy); err != nil {
+		return err
+	}
+
+	switch view {
+	case nodeViewHealth:
+		// Show health status for all nodes
+		fmt.Fprintf(c.OutOrStdout(), "=== NODE HEALTH STATUS ===\n\n")
+		for _, info := range infos {
+			fmt.Fprintf(c.OutOrStdout(), "%s\n\n", nodeinfo.RenderNodeHealth(health[info.NodeName]))
 		}
-	} else {
-		// Analyze all nodes
-		infos, err := nodeinfo.AnalyzeAllNodes(ctx, client)
-		if err != nil {
-			return fmt.Errorf("analyzing all nodes: %w", err)
+	case nodeViewCompact:
+		fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderMultipleNodeInfoCompact(infos))
+	case nodeViewSummary:
+		fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderMultipleNodeInfoSummary(infos))
+	default:
+		// Show detailed info for all nodes
+		for i, info := range infos {
+			fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderNodeInfo(&info))
+			if i < len(infos)-1 {
+				fmt.Fprintf(c.OutOrStdout(), "\n---\n\n")
+			}
 		}
+	}
 
-		// Sort by node name
-		sort.Slice(infos, func(i, j int) bool {
-			return infos[i].NodeName < infos[j].NodeName
-		})
+	return nil
+}
 
-		if healthOnly {
-			// Show health status for all nodes
-			fmt.Fprintf(c.OutOrStdout(), "=== NODE HEALTH STATUS ===\n\n")
-			for _, info := range infos {
-				health, err := nodeinfo.GetNodeHealthStatus(ctx, client, info.NodeName)
-				if err != nil {
-					continue
-				}
-				fmt.Fprintf(c.OutOrStdout(), "%s\n\n", nodeinfo.RenderNodeHealth(health))
+// applyNodeMetrics fills live memory usage into infos when reader is set and
+// metrics-server is available. Metrics are optional here, so failures are only logged.
+func applyNodeMetrics(ctx context.Context, reader nodeinfo.NodeMetricsReader, infos []nodeinfo.NodeInfo) {
+	if reader == nil {
+		return
+	}
+
+	log := logging.FromContext(ctx)
+	if available, _ := reader.IsAvailable(ctx); !available {
+		log.Infof("node metrics unavailable; memory utilization not shown")
+		return
+	}
+	if err := nodeinfo.ApplyNodeMetrics(ctx, reader, infos); err != nil {
+		log.Infof("skipping node metrics: %v", err)
+	}
+}
+
+// Values accepted by nodeinfo --sort-by
+const (
+	nodeSortName   = "name"
+	nodeSortCPU    = "cpu"
+	nodeSortMem    = "mem"
+	nodeSortHealth = "health"
+)
+
+// validateNodeSort checks a --sort-by value
+func validateNodeSort(sortBy string) error {
+	switch sortBy {
+	case nodeSortName, nodeSortCPU, nodeSortMem, nodeSortHealth:
+		return nil
+	default:
+		return fmt.Errorf("invalid --sort-by %q: must be name, cpu, mem, or health", sortBy)
+	}
+}
+
+// healthRank orders health statuses from worst to best
+var healthRank = map[string]int{"CRITICAL": 0, "WARNING": 1, "HEALTHY": 2}
+
+// sortNodeInfos orders infos by name, by requested CPU or memory share of
+// allocatable (hottest first), or by health (CRITICAL first). Ties fall back
+// to the node name.
+func sortNodeInfos(ctx context.Context, client kubernetes.Interface, infos []nodeinfo.NodeInfo, sortBy string) error {
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].NodeName < infos[j].NodeName
+	})
+
+	rank := make(map[string]float64, len(infos))
+	switch sortBy {
+	case nodeSortCPU, nodeSortMem:
+		pressure, err := capacity.CalculatePressureWithOptions(ctx, client, "", capacity.PressureOptions{
+			Thresholds: capacity.DefaultPressureThresholds(),
+		})
+		if err != nil {
+			return fmt.Errorf("calculating node pressure: %w", err)
+		}
+		for _, np := range pressure.NodePressures {
+			// Negate so that the hottest node sorts first
+			if sortBy == nodeSortCPU {
+				rank[np.NodeName] = -np.CPUUtilization
+			} else {
+				rank[np.NodeName] = -np.MemUtilization
 			}
-		} else if compact {
-			fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderMultipleNodeInfoCompact(infos))
-		} else {
-			// Show detailed info for all nodes
-			for i, info := range infos {
-				fmt.Fprintf(c.OutOrStdout(), "%s\n", nodeinfo.RenderNodeInfo(&info))
-				if i < len(infos)-1 {
-					fmt.Fprintf(c.OutOrStdout(), "\n---\n\n")
-				}
+		}
+	case nodeSortHealth:
+		// Health comes from the listed node objects; unknown statuses go last
+		for _, info := range infos {
+			rank[info.NodeName] = float64(len(healthRank))
+			if r, ok := healthRank[info.Health]; ok {
+				rank[info.NodeName] = float64(r)
 			}
 		}
+	default:
+		return nil
 	}
 
+	sort.SliceStable(infos, func(i, j int) bool {
+		return rank[infos[i].NodeName] < rank[infos[j].NodeName]
+	})
 	return nil
 }
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPrintAllNodeInfo_EmptyCluster(t *testing.T) {
+	for _, view := range []nodeInfoView{nodeViewFull, nodeViewHealth} {
+		healthOnly := view == nodeViewHealth
+		var out bytes.Buffer
+		c := &cobra.Command{}
+		c.SetOut(&out)
+
+		if err := printAllNodeInfo(context.Background(), c, fake.NewSimpleClientset(), nil, view, nodeSortName); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(out.String(), "No nodes found in scope") {
+			t.Errorf("health=%v: expected empty-scope note, got %q", healthOnly, out.String())
+		}
+		if strings.Contains(out.String(), "NODE HEALTH STATUS") {
+			t.Errorf("health=%v: expected no header for an empty cluster, got %q", healthOnly, out.String())
+		}
+	}
+}
+
+func TestPrintAllNodeInfo_SortByCPU(t *testing.T) {
+	node := func(name string) *corev1.Node {
+		resources := corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Capacity: resources, Allocatable: resources},
+		}
+	}
+	pod := func(name, nodeName, cpu string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+					},
+				}},
+			},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		node("a-cool"), node("b-hot"),
+		pod("small", "a-cool", "500m"), pod("big", "b-hot", "3"),
+	)
+
+	var out bytes.Buffer
+	c := &cobra.Command{}
+	c.SetOut(&out)
+	if err := printAllNodeInfo(context.Background(), c, client, nil, nodeViewCompact, nodeSortCPU); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hot, cool := strings.Index(out.String(), "b-hot"), strings.Index(out.String(), "a-cool")
+	if hot < 0 || cool < 0 {
+		t.Fatalf("expected both nodes in output, got %q", out.String())
+	}
+	if hot > cool {
+		t.Errorf("expected hottest node first with --sort-by cpu, got %q", out.String())
+	}
+}
+
+func TestPrintAllNodeInfo_HealthWithoutNodeGets(t *testing.T) {
+	node := func(name string, pressure corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: pressure},
+			}},
+		}
+	}
+	client := fake.NewSimpleClientset(node("a-fine", corev1.ConditionFalse), node("b-pressured", corev1.ConditionTrue))
+
+	var gets, lists int
+	client.PrependReactor("get", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+	client.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		lists++
+		return false, nil, nil
+	})
+
+	var out bytes.Buffer
+	c := &cobra.Command{}
+	c.SetOut(&out)
+	if err := printAllNodeInfo(context.Background(), c, client, nil, nodeViewHealth, nodeSortHealth); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gets != 0 {
+		t.Errorf("expected no get nodes calls, got %d", gets)
+	}
+	if lists != 1 {
+		t.Errorf("expected exactly 1 node list call, got %d", lists)
+	}
+	pressured, fine := strings.Index(out.String(), "b-pressured"), strings.Index(out.String(), "a-fine")
+	if pressured < 0 || fine < 0 {
+		t.Fatalf("expected both nodes in output, got %q", out.String())
+	}
+	if pressured > fine {
+		t.Errorf("expected the WARNING node first with --sort-by health, got %q", out.String())
+	}
+}
@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"github.com/marcgeld/cobrak/pkg/config"
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+func newPressureCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "pressure",
+		Short: "Show cluster, node and namespace pressure",
+		Long: `Shows how much of the allocatable CPU and memory is requested, as pressure levels
+(LOW, MEDIUM, HIGH, SATURATED) for the cluster, each node and each namespace.
+Thresholds come from the config file, COBRAK_THRESHOLD_* env vars or the active profile.
+With --against=capacity, requests are measured against raw node capacity instead.`,
+		RunE: runPressure,
+	}
+
+	c.Flags().String("output", "text", "output format: text, json, json-compact, yaml, or code (overall level as 0-3)")
+	c.Flags().String("node", "", "only show pressure for this node")
+	c.Flags().String("namespace", "", "only count pods in this namespace (default: all namespaces)")
+	c.Flags().Bool("legend", false, "explain which utilization maps to each pressure level")
+	c.Flags().String("preset", "", "threshold preset overriding the configured thresholds: conservative, balanced, or aggressive")
+	c.Flags().Bool("allow-partial", false, "show node capacity with a warning when pods cannot be listed (e.g. RBAC) instead of failing")
+	c.Flags().StringSlice("contexts", nil, "kubeconfig contexts to compare in a fleet table, one row per cluster (repeatable or comma-separated)")
+	c.Flags().Bool("all-contexts", false, "show a fleet table for every context in the kubeconfig")
+	c.Flags().String("against", string(capacity.BasisAllocatable), "denominator of utilization: allocatable or capacity (includes system/kubelet reservations)")
+	addFieldSelectorFlag(c)
+	c.MarkFlagsMutuallyExclusive("contexts", "all-contexts")
+	c.MarkFlagsMutuallyExclusive("contexts", "node")
+	c.MarkFlagsMutuallyExclusive("all-contexts", "node")
+
+	return c
+}
+
+// pressureOutputCode prints only the overall pressure level code, for scripts
+const pressureOutputCode = "code"
+
+// pressureOptions carries the resolved flags and settings of runPressure
+type pressureOptions struct {
+	namespace  string
+	node       string
+	output     string
+	legend     bool
+	thresholds capacity.PressureThresholds
+	// fieldSelector filters the pod list server-side
+	fieldSelector string
+	// allowPartial keeps node capacity when the pod list fails
+	allowPartial bool
+	// against selects allocatable or capacity as the utilization denominator
+	against capacity.PressureBasis
+	// simple tunes the text view, e.g. its namespace display threshold
+	simple output.PressureSimpleOptions
+}
+
+func runPressure(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	flagOutput, _ := c.Flags().GetString("output")
+	flagNamespace, _ := c.Flags().GetString("namespace")
+	node, _ := c.Flags().GetString("node")
+	legend, _ := c.Flags().GetBool("legend")
+	preset, _ := c.Flags().GetString("preset")
+	allowPartial, _ := c.Flags().GetBool("allow-partial")
+	fleetContexts, _ := c.Flags().GetStringSlice("contexts")
+	allContexts, _ := c.Flags().GetBool("all-contexts")
+	againstFlag, _ := c.Flags().GetString("against")
+
+	against, err := capacity.ParsePressureBasis(againstFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --against: %w", err)
+	}
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	output.SetGlobalColorEnabled(settings.Color && !nocolor)
+
+	overrides := config.FlagOverrides{}
+	if c.Flag("output").Changed {
+		overrides.Output = &flagOutput
+	}
+	if c.Flag("namespace").Changed {
+		overrides.Namespace = &flagNamespace
+	}
+	settings.Merge(overrides)
+
+	// A preset replaces thresholds from config, env and profile
+	thresholds := thresholdsFromSettings(settings)
+	if preset != "" {
+		thresholds, err = capacity.PresetThresholds(preset)
+		if err != nil {
+			return fmt.Errorf("invalid --preset: %w", err)
+		}
+	}
+
+	if len(fleetContexts) > 0 || allContexts {
+		if settings.Output != "text" && settings.Output != "table" {
+			return fmt.Errorf("--contexts/--all-contexts only support text output, got %q", settings.Output)
+		}
+		if allContexts {
+			fleetContexts, err = k8s.ContextNames(kubeconfig)
+			if err != nil {
+				return err
+			}
+		}
+		clients, err := k8s.NewClientFactory().Clients(kubeconfig, fleetContexts)
+		if err != nil {
+			return fmt.Errorf("building k8s clients: %w", err)
+		}
+		members := make([]capacity.FleetMember, len(clients))
+		for i, cc := range clients {
+			members[i] = capacity.FleetMember{Context: cc.Context, Client: cc.Client}
+		}
+
+		// Each cluster gets the usual single-cluster budget
+		ctx, cancel := context.WithTimeout(commandContext(c), time.Duration(len(members))*20*time.Second)
+		defer cancel()
+
+		return writeFleetPressure(ctx, c, members, capacity.PressureOptions{
+			Thresholds:    thresholds,
+			FieldSelector: fieldSelector,
+			AllowPartial:  allowPartial,
+			Against:       against,
+		})
+	}
+
+	namespace, err := scopedNamespace(c, settings, settings.Namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	return writePressure(ctx, c, client, pressureOptions{
+		namespace:     namespace,
+		node:          node,
+		output:        settings.Output,
+		legend:        legend,
+		thresholds:    thresholds,
+		fieldSelector: fieldSelector,
+		allowPartial:  allowPartial,
+		against:       against,
+		simple:        pressureSimpleOptionsFromSettings(settings),
+	})
+}
+
+// writePressure calculates pressure and writes it in the requested format
+func writePressure(ctx context.Context, c *cobra.Command, client kubernetes.Interface, opts pressureOptions) error {
+	format := output.FormatText
+	if opts.output != pressureOutputCode {
+		var err error
+		format, err = output.ParseOutputFormat(opts.output)
+		if err != nil {
+			return err
+		}
+	}
+
+	pressure, err := capacity.CalculatePressureWithOptions(ctx, client, opts.namespace, capacity.PressureOptions{
+		Thresholds:    opts.thresholds,
+		FieldSelector: opts.fieldSelector,
+		AllowPartial:  opts.allowPartial,
+		Against:       opts.against,
+	})
+	if err != nil {
+		return fmt.Errorf("calculating pressure: %w", err)
+	}
+	for _, warning := range pressure.Warnings {
+		fmt.Fprintf(c.ErrOrStderr(), "warning: %s\n", warning)
+	}
+
+	if opts.node != "" {
+		var nodes []capacity.NodePressure
+		for _, np := range pressure.NodePressures {
+			if np.NodeName == opts.node {
+				nodes = append(nodes, np)
+			}
+		}
+		if len(nodes) == 0 {
+			return fmt.Errorf("node %q not found", opts.node)
+		}
+		pressure.NodePressures = nodes
+	}
+
+	if opts.output == pressureOutputCode {
+		fmt.Fprintln(c.OutOrStdout(), pressure.Overall.Code())
+		return nil
+	}
+
+	if format != output.FormatText {
+		outputStr, err := output.RenderOutput(buildPressureSummary(pressure), format)
+		if err != nil {
+			return fmt.Errorf("rendering output: %w", err)
+		}
+		fmt.Fprintln(c.OutOrStdout(), outputStr)
+		return nil
+	}
+
+	fmt.Fprintln(c.OutOrStdout(), output.RenderPressureSimpleWithOptions(pressure, opts.simple))
+	if opts.node != "" {
+		// The simple view hides LOW nodes; a selected node is always shown
+		fmt.Fprintf(c.OutOrStdout(), "\n%s\n", output.RenderPressureExplain(pressure))
+	}
+	if opts.legend {
+		fmt.Fprintf(c.OutOrStdout(), "\n%s\n", output.RenderPressureLegend(opts.thresholds))
+	}
+
+	return nil
+}
+
+// writeFleetPressure calculates cluster-wide pressure for each fleet member and
+// writes one table row per cluster
+func writeFleetPressure(ctx context.Context, c *cobra.Command, members []capacity.FleetMember, opts capacity.PressureOptions) error {
+	fleet := capacity.CalculateFleetPressure(ctx, members, opts)
+	fmt.Fprintln(c.OutOrStdout(), output.RenderFleetPressure(fleet))
+	return nil
+}
+
+// buildPressureSummary creates a structured pressure summary for JSON/YAML output
+func buildPressureSummary(pressure *capacity.ClusterPressure) *output.PressureSummary {
+	nodes := make([]output.NodePressure, len(pressure.NodePressures))
+	for i, np := range pressure.NodePressures {
+		nodes[i] = output.NodePressure{
+			NodeName:       np.NodeName,
+			CPUPressure:    string(np.CPUPressure),
+			CPUUtilization: np.CPUUtilization,
+			MemPressure:    string(np.MemPressure),
+			MemUtilization: np.MemUtilization,
+		}
+	}
+
+	namespaces := make([]output.NSPressure, len(pressure.NamespacePressures))
+	for i, nsp := range pressure.NamespacePressures {
+		namespaces[i] = output.NSPressure{
+			Namespace:  nsp.Namespace,
+			CPUPercent: nsp.CPUPercent,
+			MemPercent: nsp.MemPercent,
+		}
+	}
+
+	return &output.PressureSummary{
+		ClusterPressure:    string(pressure.Overall),
+		CPUPressure:        string(pressure.OverallCPU),
+		MemPressure:        string(pressure.OverallMem),
+		CPUUtilization:     pressure.CPUUtilization,
+		MemUtilization:     pressure.MemUtilization,
+		NodePressures:      nodes,
+		NamespacePressures: namespaces,
+	}
+}
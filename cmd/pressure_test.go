@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"github.com/marcgeld/cobrak/pkg/output"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func pressureTestClient() *fake.Clientset {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "worker-1",
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("3"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	return fake.NewSimpleClientset(node, pod)
+}
+
+func TestWritePressure_Text(t *testing.T) {
+	output.SetGlobalColorEnabled(false)
+
+	var out bytes.Buffer
+	c := newPressureCmd()
+	c.SetOut(&out)
+
+	err := writePressure(context.Background(), c, pressureTestClient(), pressureOptions{
+		output:     "text",
+		legend:     true,
+		thresholds: capacity.DefaultPressureThresholds(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 3 of 4 CPUs requested is 75%, the default MEDIUM threshold
+	for _, want := range []string{"Cluster Pressure: MEDIUM", "Legend"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWritePressure_NodeAndJSON(t *testing.T) {
+	var out bytes.Buffer
+	c := newPressureCmd()
+	c.SetOut(&out)
+
+	err := writePressure(context.Background(), c, pressureTestClient(), pressureOptions{
+		output:     "json",
+		node:       "worker-1",
+		thresholds: capacity.DefaultPressureThresholds(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var summary output.PressureSummary
+	if err := json.Unmarshal(out.Bytes(), &summary); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if summary.ClusterPressure != "MEDIUM" {
+		t.Errorf("expected cluster pressure MEDIUM, got %q", summary.ClusterPressure)
+	}
+	if len(summary.NodePressures) != 1 || summary.NodePressures[0].NodeName != "worker-1" {
+		t.Errorf("expected only worker-1, got %+v", summary.NodePressures)
+	}
+
+	err = writePressure(context.Background(), c, pressureTestClient(), pressureOptions{
+		output:     "text",
+		node:       "missing",
+		thresholds: capacity.DefaultPressureThresholds(),
+	})
+	if err == nil {
+		t.Error("expected error for unknown node")
+	}
+}
+
+func TestWritePressure_Code(t *testing.T) {
+	var out bytes.Buffer
+	c := newPressureCmd()
+	c.SetOut(&out)
+
+	err := writePressure(context.Background(), c, pressureTestClient(), pressureOptions{
+		output:     pressureOutputCode,
+		thresholds: capacity.DefaultPressureThresholds(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 75% CPU requested is MEDIUM
+	if got := strings.TrimSpace(out.String()); got != "1" {
+		t.Errorf("expected code 1, got %q", got)
+	}
+}
+
+func TestWriteFleetPressure_RowPerContext(t *testing.T) {
+	output.SetGlobalColorEnabled(false)
+
+	var out bytes.Buffer
+	c := newPressureCmd()
+	c.SetOut(&out)
+
+	members := []capacity.FleetMember{
+		{Context: "prod", Client: pressureTestClient()},
+		{Context: "staging", Client: fake.NewSimpleClientset()},
+	}
+	err := writeFleetPressure(context.Background(), c, members, capacity.PressureOptions{Thresholds: capacity.DefaultPressureThresholds()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got:\n%s", out.String())
+	}
+	if !strings.HasPrefix(lines[1], "prod") || !strings.Contains(lines[1], "MEDIUM") {
+		t.Errorf("expected prod row at MEDIUM, got %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "staging") || !strings.Contains(lines[2], "LOW") {
+		t.Errorf("expected staging row at LOW, got %q", lines[2])
+	}
+}
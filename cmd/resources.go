@@ -2,15 +2,19 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/marcgeld/cobrak/pkg/capacity"
 	"github.com/marcgeld/cobrak/pkg/config"
 	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/logging"
 	"github.com/marcgeld/cobrak/pkg/output"
 	"github.com/marcgeld/cobrak/pkg/resources"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 )
 
 func newResourcesCmd() *cobra.Command {
@@ -22,11 +26,35 @@ func newResourcesCmd() *cobra.Command {
 	}
 
 	addResourceFlags(c)
+	c.Flags().Lookup("output").Usage = "output format: text, json, json-compact, jsonl (one object per pod, streamed), yaml, html (standalone page), or markdown"
+	addPodFilterFlags(c)
+	c.Flags().String("group-by-annotation", "", "aggregate pod requests/limits by the value of this pod annotation (e.g. cost-center)")
+	c.Flags().Bool("require-metrics", false, "fail if the metrics API (metrics-server) is not available")
+	c.Flags().Bool("by-container", false, "show one row per container instead of per pod (useful for sidecar analysis)")
+	c.Flags().Bool("wide", false, "add pod requests as a percentage of cluster allocatable and container counts to the pod table")
+	c.Flags().Bool("show-zero", false, "include pods (or containers with --by-container) that request no CPU or memory in the text table")
+	c.Flags().Bool("show-node", false, "add a NODE column with each pod's node to the pod table")
+	c.Flags().String("columns", "", "comma-separated pod table columns (e.g. namespace,pod,cpu_request); valid: "+strings.Join(output.PodColumnNames(), ","))
+	addDisplayFlags(c)
+	c.Flags().Bool("anonymize", false, "replace namespace and pod names with hashes (stable within one run) for sharing reports")
 
 	c.AddCommand(newResourcesSimpleCmd())
 	c.AddCommand(newResourcesInventoryCmd())
 	c.AddCommand(newResourcesUsageCmd())
 	c.AddCommand(newResourcesDiffCmd())
+	c.AddCommand(newResourcesNsDiffCmd())
+	c.AddCommand(newResourcesGhostsCmd())
+	c.AddCommand(newResourcesThrottleCmd())
+	c.AddCommand(newResourcesWasteCmd())
+	c.AddCommand(newResourcesQuotaCheckCmd())
+	c.AddCommand(newResourcesPolicyCheckCmd())
+	c.AddCommand(newResourcesExportCmd())
+	c.AddCommand(newResourcesLintCmd())
+	c.AddCommand(newResourcesPodCmd())
+	c.AddCommand(newResourcesPlacementCmd())
+	c.AddCommand(newResourcesSpreadCmd())
+	c.AddCommand(newResourcesHPACmd())
+	c.AddCommand(newResourcesRatiosCmd())
 
 	return c
 }
@@ -34,20 +62,109 @@ func newResourcesCmd() *cobra.Command {
 func addResourceFlags(c *cobra.Command) {
 	c.Flags().String("namespace", "", "namespace to inspect (default: all namespaces)")
 	c.Flags().Bool("all-namespaces", true, "inspect all namespaces (default when --namespace is empty)")
-	c.Flags().Int("top", 20, "number of top offenders to show")
-	c.Flags().String("output", "text", "output format: text, json, or yaml")
+	c.Flags().Int("top", 20, "number of top offenders to show (0 or less = all)")
+	c.Flags().String("output", "text", "output format: text, json, json-compact, or yaml")
 }
 
-func runResources(c *cobra.Command, _ []string) error {
-	// Load configuration from resolved config path
-	configFlag, _ := c.Root().PersistentFlags().GetString("config")
-	configPath, err := config.ResolveConfigPath(configFlag)
+// addDisplayFlags registers --show-requests and --show-limits, which override
+// the display section of the config
+func addDisplayFlags(c *cobra.Command) {
+	c.Flags().Bool("show-requests", true, "show request columns in the pod and namespace tables (default from display.show_requests)")
+	c.Flags().Bool("show-limits", true, "show limit columns in the pod and namespace tables (default from display.show_limits)")
+}
+
+// displayOptionsFromFlags resolves the display options from the config,
+// overridden by --show-requests/--show-limits when given
+func displayOptionsFromFlags(c *cobra.Command, settings *config.Settings) output.DisplayOptions {
+	opts := output.DisplayOptions{
+		HideRequests: !settings.Display.ShowRequests,
+		HideLimits:   !settings.Display.ShowLimits,
+	}
+	if c.Flag("show-requests").Changed {
+		show, _ := c.Flags().GetBool("show-requests")
+		opts.HideRequests = !show
+	}
+	if c.Flag("show-limits").Changed {
+		show, _ := c.Flags().GetBool("show-limits")
+		opts.HideLimits = !show
+	}
+	return opts
+}
+
+// addPodFilterFlags registers flags that narrow which pods are analyzed
+func addPodFilterFlags(c *cobra.Command) {
+	c.Flags().Duration("since", 0, "only include pods created within this duration (e.g. 1h, 30m)")
+	c.Flags().Bool("include-terminated", false, "include pods in a terminal phase (Succeeded/Failed), e.g. completed Jobs")
+	addFieldSelectorFlag(c)
+}
+
+// addFieldSelectorFlag registers --field-selector, passed to the API server when listing pods
+func addFieldSelectorFlag(c *cobra.Command) {
+	c.Flags().String("field-selector", "", "server-side pod field selector (e.g. status.phase=Running,spec.nodeName=worker-1)")
+}
+
+// fieldSelectorFromFlags validates the flag registered by addFieldSelectorFlag
+func fieldSelectorFromFlags(c *cobra.Command) (string, error) {
+	raw, _ := c.Flags().GetString("field-selector")
+	if raw == "" {
+		return "", nil
+	}
+	selector, err := resources.ParsePodFieldSelector(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid --field-selector: %w", err)
+	}
+	return selector, nil
+}
+
+// addMaxAgeFlag registers --max-age, guarding against stale metrics-server data
+func addMaxAgeFlag(c *cobra.Command) {
+	c.Flags().Duration("max-age", 0, "fail when the newest metrics sample is older than this (e.g. 2m; 0 = no check)")
+}
+
+// checkMetricsAgeFromFlags applies the flag registered by addMaxAgeFlag
+func checkMetricsAgeFromFlags(c *cobra.Command, usages []resources.ContainerUsage) error {
+	maxAge, _ := c.Flags().GetDuration("max-age")
+	if err := resources.CheckMetricsAge(usages, maxAge, time.Now()); err != nil {
+		return fmt.Errorf("stale metrics (--max-age): %w", err)
+	}
+	return nil
+}
+
+// addPolicyFlag registers the --policy flag selecting which missing requests/limits are violations
+func addPolicyFlag(c *cobra.Command) {
+	c.Flags().String("policy", "", "which missing resources count as violations: requests-required, limits-required, or both (default both; --strict checks requests only unless set)")
+}
+
+// policyFromFlags parses the flag registered by addPolicyFlag, defaulting to PolicyBoth
+func policyFromFlags(c *cobra.Command) (resources.CompliancePolicy, error) {
+	flag, _ := c.Flags().GetString("policy")
+	if flag == "" {
+		return resources.PolicyBoth, nil
+	}
+	policy, err := resources.ParseCompliancePolicy(flag)
 	if err != nil {
-		return fmt.Errorf("resolving config path: %w", err)
+		return policy, fmt.Errorf("invalid --policy: %w", err)
 	}
-	settings, err := config.LoadSettingsAt(configPath)
+	return policy, nil
+}
+
+// podFiltersFromFlags builds pod filters from the flags registered by addPodFilterFlags
+func podFiltersFromFlags(c *cobra.Command) []resources.PodFilter {
+	var filters []resources.PodFilter
+	if include, _ := c.Flags().GetBool("include-terminated"); !include {
+		filters = append(filters, resources.ExcludeTerminated)
+	}
+	if since, _ := c.Flags().GetDuration("since"); since > 0 {
+		filters = append(filters, resources.CreatedWithin(since, time.Now))
+	}
+	return filters
+}
+
+func runResources(c *cobra.Command, _ []string) error {
+	// Load configuration from resolved config path
+	settings, err := loadSettings(c)
 	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return err
 	}
 
 	// Get flag values (may be empty/zero)
@@ -63,6 +180,31 @@ func runResources(c *cobra.Command, _ []string) error {
 	flagNamespace, _ := c.Flags().GetString("namespace")
 	flagTop, _ := c.Flags().GetInt("top")
 	flagOutput, _ := c.Flags().GetString("output")
+	groupBy, _ := c.Flags().GetString("group-by-annotation")
+	requireMetrics, _ := c.Flags().GetBool("require-metrics")
+	columnsFlag, _ := c.Flags().GetString("columns")
+	byContainer, _ := c.Flags().GetBool("by-container")
+	wide, _ := c.Flags().GetBool("wide")
+	showZero, _ := c.Flags().GetBool("show-zero")
+	showNode, _ := c.Flags().GetBool("show-node")
+	anonymize, _ := c.Flags().GetBool("anonymize")
+	includeTerminated, _ := c.Flags().GetBool("include-terminated")
+	filters := podFiltersFromFlags(c)
+
+	columnNames := output.DefaultPodColumns
+	if columnsFlag != "" {
+		columnNames = strings.Split(columnsFlag, ",")
+	}
+	if wide {
+		columnNames = append(append([]string{}, columnNames...), output.WidePodColumns...)
+	}
+	if showNode {
+		columnNames = output.WithNodeColumn(columnNames)
+	}
+	podColumns, err := output.LookupPodColumns(columnNames)
+	if err != nil {
+		return fmt.Errorf("invalid --columns: %w", err)
+	}
 
 	// Determine if flags were explicitly set (not just default values)
 	// We check if the flag was actually provided on the command line
@@ -85,8 +227,11 @@ func runResources(c *cobra.Command, _ []string) error {
 	// Merge config with flags (flags take precedence)
 	settings.Merge(overrides)
 
-	// Use merged settings
-	namespace := settings.Namespace
+	// Use merged settings; an unset namespace falls back to default_scope
+	namespace, err := scopedNamespace(c, settings, settings.Namespace)
+	if err != nil {
+		return err
+	}
 	outputFormat := settings.Output
 	top := settings.Top
 
@@ -100,44 +245,143 @@ func runResources(c *cobra.Command, _ []string) error {
 		return fmt.Errorf("building k8s client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
 	defer cancel()
 
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	// A metrics client that cannot be built just means no usage data
+	metricsReader, err := resources.NewMetricsReaderFromConfig(cfg)
+	if err != nil {
+		metricsReader = nil
+	}
+
+	return writeResourcesReport(ctx, c, client, metricsReader, resourcesReportOptions{
+		namespace:      namespace,
+		output:         outputFormat,
+		top:            top,
+		groupBy:        groupBy,
+		requireMetrics: requireMetrics,
+		byContainer:    byContainer,
+		wide:           wide,
+		showZero:       showZero,
+		podColumns:     podColumns,
+		display:        displayOptionsFromFlags(c, settings),
+		anonymize:      anonymize,
+		filters:        filters,
+		fieldSelector:  fieldSelector,
+
+		includeTerminated: includeTerminated,
+	})
+}
+
+// resourcesReportOptions carries the resolved flags and settings of runResources
+type resourcesReportOptions struct {
+	namespace      string
+	output         string
+	top            int
+	groupBy        string
+	requireMetrics bool
+	byContainer    bool
+	wide           bool
+	showZero       bool
+	podColumns     []output.PodColumn
+	display        output.DisplayOptions
+	anonymize      bool
+	filters        []resources.PodFilter
+	fieldSelector  string
+
+	// includeTerminated also counts terminal pods in the capacity summary;
+	// filters already carry it for the pod and inventory sections
+	includeTerminated bool
+}
+
+// writeResourcesReport gathers capacity, pod and inventory data and writes the
+// resources report in the requested format
+func writeResourcesReport(ctx context.Context, c *cobra.Command, client kubernetes.Interface, metricsReader resources.MetricsReader, opts resourcesReportOptions) error {
+	namespace, top, groupBy := opts.namespace, opts.top, opts.groupBy
+	filters, fieldSelector, podColumns := opts.filters, opts.fieldSelector, opts.podColumns
+
+	format, err := output.ParseOutputFormat(opts.output)
+	if err != nil {
+		return err
+	}
+
+	// JSON Lines streams pods (or containers) as they are listed, skipping the report
+	if format == output.FormatJSONL {
+		return streamResourcesJSONL(ctx, c, client, opts)
+	}
+
 	// Get cluster capacity summary
-	summary, err := capacity.AnalyzeSummary(ctx, client, namespace)
+	summary, err := capacity.AnalyzeSummaryWithOptions(ctx, client, namespace, capacity.SummaryOptions{
+		IncludeTerminated: opts.includeTerminated,
+		FieldSelector:     fieldSelector,
+	})
 	if err != nil {
 		return fmt.Errorf("analyzing capacity summary: %w", err)
 	}
 
-	// Get pod-level resource summaries
-	podSummaries, err := resources.BuildPodSummaries(ctx, client, namespace)
+	// Get pod-level resource summaries, with share of allocatable when --wide
+	buildPods := resources.BuildPodSummaries
+	if opts.wide {
+		buildPods = resources.BuildPodSummariesWithAllocatable
+	}
+	podSummaries, err := buildPods(ctx, client, namespace, fieldSelector, filters...)
 	if err != nil {
 		return fmt.Errorf("building pod summaries: %w", err)
 	}
 
 	// Get inventory
-	nsInventories, containers, policies, err := resources.BuildInventory(ctx, client, namespace)
+	nsInventories, containers, policies, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, filters...)
 	if err != nil {
 		return fmt.Errorf("building inventory: %w", err)
 	}
 
 	_ = containers
-	_ = policies
 
-	// Check metrics availability
-	metricsAvailable := false
-	metricsReader, err := resources.NewMetricsReaderFromConfig(cfg)
-	if err == nil {
-		available, _ := metricsReader.IsAvailable(ctx)
-		metricsAvailable = available
+	// Per-container rows replace the pod table when requested
+	var containerRows []resources.ContainerResources
+	if opts.byContainer {
+		containerRows, err = resources.BuildContainerSummaries(ctx, client, namespace, fieldSelector, filters...)
+		if err != nil {
+			return fmt.Errorf("building container summaries: %w", err)
+		}
 	}
 
-	// Parse output format
-	format, err := output.ParseOutputFormat(outputFormat)
+	// Hash names once everything is gathered so every section agrees
+	if opts.anonymize {
+		anonymizer, err := resources.NewAnonymizer()
+		if err != nil {
+			return err
+		}
+		anonymizer.PodSummaries(podSummaries)
+		anonymizer.Containers(containerRows)
+		anonymizer.NamespaceInventories(nsInventories)
+		anonymizer.Policies(policies)
+	}
+
+	// Check metrics availability
+	metricsAvailable, err := resolveMetricsAvailability(ctx, metricsReader, opts.requireMetrics)
 	if err != nil {
 		return err
 	}
 
+	// Nothing scheduled and nothing to schedule on: say so instead of printing zeros
+	if len(podSummaries) == 0 && summary.TotalCPUAllocatable.IsZero() && summary.TotalMemAllocatable.IsZero() {
+		printNoData(c, format, "nodes/pods")
+		if format == output.FormatText {
+			return nil
+		}
+	}
+
+	// Structured output has no room for the note below, so hint once on stderr
+	if !metricsAvailable && format != output.FormatText {
+		fmt.Fprintln(c.ErrOrStderr(), "hint: metrics API not available, usage data omitted (install metrics-server, or use --require-metrics to fail instead; -vv shows the cause)")
+	}
+
 	// For text format, use the original text output
 	if format == output.FormatText {
 		fmt.Fprintf(c.OutOrStdout(), "\n=== CLUSTER CAPACITY SUMMARY ===\n")
@@ -149,29 +393,61 @@ func runResources(c *cobra.Command, _ []string) error {
 		fmt.Fprintf(c.OutOrStdout(), "Memory Allocatable:    %s\n", summary.TotalMemAllocatable.String())
 		fmt.Fprintf(c.OutOrStdout(), "Memory Requests:       %s\n", summary.TotalMemRequests.String())
 		fmt.Fprintf(c.OutOrStdout(), "Memory Limits:         %s\n", summary.TotalMemLimits.String())
+		if hugePages := output.RenderHugePagesTable(summary); hugePages != "" {
+			fmt.Fprintf(c.OutOrStdout(), "\n%s\n", hugePages)
+		}
 
-		fmt.Fprintf(c.OutOrStdout(), "\n=== POD RESOURCE DETAILS ===\n")
-		if len(podSummaries) > 0 {
-			fmt.Fprintf(c.OutOrStdout(), "%s\n\n", output.RenderPodResourceSummary(podSummaries, top))
-			fmt.Fprintf(c.OutOrStdout(), "%s\n", output.RenderPodResourceSummaryTotals(podSummaries))
+		if opts.byContainer {
+			fmt.Fprintf(c.OutOrStdout(), "\n=== CONTAINER RESOURCE DETAILS ===\n")
+		} else {
+			fmt.Fprintf(c.OutOrStdout(), "\n=== POD RESOURCE DETAILS ===\n")
+		}
+		if len(podSummaries) == 0 {
+			printNoData(c, format, "pods")
 		} else {
-			fmt.Fprintf(c.OutOrStdout(), "No pods found.\n")
+			if opts.byContainer {
+				rows := containerRows
+				if !opts.showZero {
+					rows = resources.NonZeroRequestContainers(containerRows)
+				}
+				fmt.Fprintf(c.OutOrStdout(), "%s\n\n", output.RenderContainerSummary(rows, top))
+				printZeroHidden(c, len(containerRows)-len(rows), "containers")
+			} else {
+				rows := podSummaries
+				if !opts.showZero {
+					rows = resources.NonZeroRequestPods(podSummaries)
+				}
+				fmt.Fprintf(c.OutOrStdout(), "%s\n\n", output.RenderPodResourceSummaryColumnsWithOptions(rows, top, podColumns, opts.display))
+				printZeroHidden(c, len(podSummaries)-len(rows), "pods")
+			}
+			fmt.Fprintf(c.OutOrStdout(), "%s\n", output.RenderPodResourceSummaryTotalsWithOptions(podSummaries, opts.display))
+		}
+
+		if groupBy != "" {
+			fmt.Fprintf(c.OutOrStdout(), "\n=== GROUPED BY ANNOTATION %s ===\n", groupBy)
+			fmt.Fprintf(c.OutOrStdout(), "%s\n", output.RenderGroupedSummary(resources.GroupByAnnotation(podSummaries, groupBy)))
 		}
 
-		totalContainers := 0
-		missingRequests := 0
-		missingLimits := 0
+		var totals resources.NamespaceInventory
 		for _, ns := range nsInventories {
-			totalContainers += ns.ContainersTotal
-			missingRequests += ns.ContainersMissingAnyRequests
-			missingLimits += ns.ContainersMissingAnyLimits
+			totals.ContainersMissingAnyRequests += ns.ContainersMissingAnyRequests
+			totals.ContainersMissingAnyLimits += ns.ContainersMissingAnyLimits
+			totals.ContainersMissingCPURequest += ns.ContainersMissingCPURequest
+			totals.ContainersMissingMemRequest += ns.ContainersMissingMemRequest
+			totals.ContainersMissingCPULimit += ns.ContainersMissingCPULimit
+			totals.ContainersMissingMemLimit += ns.ContainersMissingMemLimit
 		}
 
 		fmt.Fprintf(c.OutOrStdout(), "\n=== RESOURCE INVENTORY ===\n")
 		fmt.Fprintf(c.OutOrStdout(), "Namespaces:                  %d\n", len(nsInventories))
-		fmt.Fprintf(c.OutOrStdout(), "Total containers:            %d\n", totalContainers)
-		fmt.Fprintf(c.OutOrStdout(), "Missing any requests:        %d\n", missingRequests)
-		fmt.Fprintf(c.OutOrStdout(), "Missing any limits:          %d\n", missingLimits)
+		podCounts := resources.CountPods(podSummaries)
+		fmt.Fprintf(c.OutOrStdout(), "Pods:                        %d (scheduled %d, pending %d)\n",
+			podCounts.Pods, podCounts.Scheduled, podCounts.Pending)
+		fmt.Fprintf(c.OutOrStdout(), "Total containers:            %d (init %d)\n", podCounts.Containers, podCounts.InitContainers)
+		fmt.Fprintf(c.OutOrStdout(), "Missing any requests:        %d (CPU %d, memory %d)\n",
+			totals.ContainersMissingAnyRequests, totals.ContainersMissingCPURequest, totals.ContainersMissingMemRequest)
+		fmt.Fprintf(c.OutOrStdout(), "Missing any limits:          %d (CPU %d, memory %d)\n",
+			totals.ContainersMissingAnyLimits, totals.ContainersMissingCPULimit, totals.ContainersMissingMemLimit)
 
 		if metricsAvailable {
 			fmt.Fprintf(c.OutOrStdout(), "Metrics API:                 available\n")
@@ -184,6 +460,14 @@ func runResources(c *cobra.Command, _ []string) error {
 
 	// For JSON/YAML formats, create structured output
 	resourcesSummary := buildResourcesSummary(summary, podSummaries, nsInventories, metricsAvailable, top)
+	resourcesSummary.Policies = output.BuildPolicyInventory(policies)
+	resourcesSummary.Issues = output.BuildIssues(summary, podSummaries, nsInventories, policies)
+	if opts.byContainer {
+		resourcesSummary.ContainerDetails = buildContainerRows(containerRows, top)
+	}
+	if groupBy != "" {
+		resourcesSummary.Groups = buildGroupSummaries(resources.GroupByAnnotation(podSummaries, groupBy))
+	}
 
 	outputStr, err := output.RenderOutput(resourcesSummary, format)
 	if err != nil {
@@ -194,6 +478,85 @@ func runResources(c *cobra.Command, _ []string) error {
 	return nil
 }
 
+// errStreamLimit stops a stream once top objects have been written
+var errStreamLimit = errors.New("stream limit reached")
+
+// streamResourcesJSONL writes one JSON object per pod, or per container with
+// byContainer, as pods are listed. At most top objects are written when top > 0.
+func streamResourcesJSONL(ctx context.Context, c *cobra.Command, client kubernetes.Interface, opts resourcesReportOptions) error {
+	jw := output.NewJSONLWriter(c.OutOrStdout())
+	written := 0
+	var anonymizer *resources.Anonymizer
+	if opts.anonymize {
+		var err error
+		if anonymizer, err = resources.NewAnonymizer(); err != nil {
+			return err
+		}
+	}
+	emit := func(v interface{}) error {
+		if opts.top > 0 && written >= opts.top {
+			return errStreamLimit
+		}
+		written++
+		return jw.Write(v)
+	}
+
+	var err error
+	if opts.byContainer {
+		err = resources.ForEachContainer(ctx, client, opts.namespace, opts.fieldSelector, func(cr resources.ContainerResources) error {
+			if anonymizer != nil {
+				cr.Namespace, cr.PodName = anonymizer.Namespace(cr.Namespace), anonymizer.Pod(cr.PodName)
+			}
+			return emit(output.ContainerRow{Namespace: cr.Namespace, Pod: cr.PodName, ContainerDetail: containerDetail(cr)})
+		}, opts.filters...)
+	} else {
+		err = resources.ForEachPodSummary(ctx, client, opts.namespace, opts.fieldSelector, func(pod resources.PodResourceSummary) error {
+			if anonymizer != nil {
+				pod.Namespace, pod.PodName = anonymizer.Namespace(pod.Namespace), anonymizer.Pod(pod.PodName)
+			}
+			return emit(podDetail(pod))
+		}, opts.filters...)
+	}
+	if err != nil && !errors.Is(err, errStreamLimit) {
+		return fmt.Errorf("streaming resources: %w", err)
+	}
+
+	if written == 0 {
+		printNoData(c, output.FormatJSONL, "pods")
+	}
+	return nil
+}
+
+// printZeroHidden notes how many rows without requests were left out of the text table
+func printZeroHidden(c *cobra.Command, hidden int, kind string) {
+	if hidden > 0 {
+		fmt.Fprintf(c.OutOrStdout(), "Hidden %s without requests: %d (use --show-zero to include them)\n\n", kind, hidden)
+	}
+}
+
+// errMetricsUnavailable is returned by commands that cannot run without metrics-server
+var errMetricsUnavailable = errors.New("metrics API (metrics.k8s.io) not available; install metrics-server")
+
+// resolveMetricsAvailability reports whether the metrics API can be used.
+// A nil reader counts as unavailable; when require is set, unavailability is an error.
+func resolveMetricsAvailability(ctx context.Context, reader resources.MetricsReader, require bool) (bool, error) {
+	log := logging.FromContext(ctx)
+	available := false
+	if reader != nil {
+		var err error
+		available, err = reader.IsAvailable(ctx)
+		if err != nil {
+			log.Infof("metrics API check failed: %v", err)
+		}
+	} else {
+		log.Infof("metrics client could not be built; continuing without usage data")
+	}
+	if !available && require {
+		return false, errMetricsUnavailable
+	}
+	return available, nil
+}
+
 // buildResourcesSummary creates a structured summary for JSON/YAML output
 func buildResourcesSummary(
 	summary *capacity.ClusterCapacitySummary,
@@ -202,6 +565,9 @@ func buildResourcesSummary(
 	metricsAvailable bool,
 	top int,
 ) *output.ResourcesSummary {
+	// Counts cover every pod, not just the top N listed
+	counts := resources.CountPods(podSummaries)
+
 	// Limit pods to top N if specified
 	if top > 0 && len(podSummaries) > top {
 		podSummaries = podSummaries[:top]
@@ -217,50 +583,151 @@ func buildResourcesSummary(
 		MemRequests:    summary.TotalMemRequests.String(),
 		MemLimits:      summary.TotalMemLimits.String(),
 	}
+	for _, size := range summary.HugePageSizes() {
+		allocatable := summary.HugePagesAllocatable[size]
+		requests := summary.HugePagesRequests[size]
+		clusterCap.HugePages = append(clusterCap.HugePages, output.HugePagesSummary{
+			Resource:    string(size),
+			Allocatable: allocatable.String(),
+			Requests:    requests.String(),
+		})
+	}
 
 	// Build pod details
 	podDetails := make([]output.PodDetail, len(podSummaries))
 	for i, pod := range podSummaries {
-		podDetails[i] = output.PodDetail{
-			Namespace:  pod.Namespace,
-			Pod:        pod.PodName,
-			CPURequest: pod.CPURequest.String(),
-			CPULimit:   pod.CPULimit.String(),
-			MemRequest: pod.MemRequest.String(),
-			MemLimit:   pod.MemLimit.String(),
-		}
+		podDetails[i] = podDetail(pod)
 	}
 
 	// Build namespace inventory
 	nsInv := make([]output.NamespaceSummary, len(nsInventories))
 	for i, ns := range nsInventories {
-		nsInv[i] = output.NamespaceSummary{
-			Namespace:       ns.Namespace,
-			ContainersTotal: ns.ContainersTotal,
-			MissingRequests: ns.ContainersMissingAnyRequests,
-			MissingLimits:   ns.ContainersMissingAnyLimits,
-			CPURequests:     ns.CPURequestsTotal.String(),
-			CPULimits:       ns.CPULimitsTotal.String(),
-			MemRequests:     ns.MemRequestsTotal.String(),
-			MemLimits:       ns.MemLimitsTotal.String(),
-		}
+		nsInv[i] = namespaceSummary(ns)
+	}
+
+	podCounts := &output.PodCountSummary{
+		Pods:           counts.Pods,
+		Scheduled:      counts.Scheduled,
+		Pending:        counts.Pending,
+		Containers:     counts.Containers,
+		InitContainers: counts.InitContainers,
 	}
 
 	return &output.ResourcesSummary{
 		ClusterCapacity:    clusterCap,
 		PodDetails:         podDetails,
+		PodCounts:          podCounts,
 		NamespaceInventory: nsInv,
 		MetricsAvailable:   metricsAvailable,
 	}
 }
 
+// podDetail converts a pod summary to its structured output form
+func podDetail(pod resources.PodResourceSummary) output.PodDetail {
+	return output.PodDetail{
+		Namespace:  pod.Namespace,
+		Pod:        pod.PodName,
+		CPURequest: pod.CPURequest.String(),
+		CPULimit:   pod.CPULimit.String(),
+		MemRequest: pod.MemRequest.String(),
+		MemLimit:   pod.MemLimit.String(),
+
+		CPUPercentOfAllocatable: pod.CPUPercentOfAllocatable,
+		MemPercentOfAllocatable: pod.MemPercentOfAllocatable,
+	}
+}
+
+// namespaceSummary converts a namespace inventory to its structured output form
+func namespaceSummary(ns resources.NamespaceInventory) output.NamespaceSummary {
+	return output.NamespaceSummary{
+		Namespace:          ns.Namespace,
+		Labels:             ns.Labels,
+		ContainersTotal:    ns.ContainersTotal,
+		MissingRequests:    ns.ContainersMissingAnyRequests,
+		MissingLimits:      ns.ContainersMissingAnyLimits,
+		MissingCPURequests: ns.ContainersMissingCPURequest,
+		MissingMemRequests: ns.ContainersMissingMemRequest,
+		MissingCPULimits:   ns.ContainersMissingCPULimit,
+		MissingMemLimits:   ns.ContainersMissingMemLimit,
+		CPUOnlyRequests:    ns.ContainersCPUOnlyRequest,
+		MemOnlyRequests:    ns.ContainersMemOnlyRequest,
+		Guaranteed:         ns.ContainersGuaranteed,
+		CPURequests:        ns.CPURequestsTotal.String(),
+		CPULimits:          ns.CPULimitsTotal.String(),
+		MemRequests:        ns.MemRequestsTotal.String(),
+		MemLimits:          ns.MemLimitsTotal.String(),
+	}
+}
+
+// buildContainerRows converts per-container resources to their structured output form,
+// limited to top rows when top > 0
+func buildContainerRows(containers []resources.ContainerResources, top int) []output.ContainerRow {
+	if top > 0 && len(containers) > top {
+		containers = containers[:top]
+	}
+	rows := make([]output.ContainerRow, len(containers))
+	for i, cr := range containers {
+		rows[i] = output.ContainerRow{
+			Namespace:       cr.Namespace,
+			Pod:             cr.PodName,
+			ContainerDetail: containerDetail(cr),
+		}
+	}
+	return rows
+}
+
+// containerDetail converts container resources to their structured output form
+func containerDetail(cr resources.ContainerResources) output.ContainerDetail {
+	return output.ContainerDetail{
+		Container:  cr.ContainerName,
+		Init:       cr.IsInit,
+		CPURequest: cr.CPURequest.String(),
+		CPULimit:   cr.CPULimit.String(),
+		MemRequest: cr.MemRequest.String(),
+		MemLimit:   cr.MemLimit.String(),
+	}
+}
+
+// buildGroupSummaries converts annotation groups to their structured output form
+func buildGroupSummaries(groups []resources.ResourceGroupSummary) []output.GroupSummary {
+	result := make([]output.GroupSummary, len(groups))
+	for i, g := range groups {
+		result[i] = output.GroupSummary{
+			Key:        g.Key,
+			Value:      g.Value,
+			Pods:       g.Pods,
+			CPURequest: g.CPURequest.String(),
+			CPULimit:   g.CPULimit.String(),
+			MemRequest: g.MemRequest.String(),
+			MemLimit:   g.MemLimit.String(),
+		}
+	}
+	return result
+}
+
 func newResourcesSimpleCmd() *cobra.Command {
-	return &cobra.Command{
+	c := &cobra.Command{
 		Use:   "simple",
 		Short: "Quick cluster resource pressure summary",
 		Long:  "Shows a simple one-liner summary of cluster pressure and resource constraints per node and namespace.",
 		RunE:  runResourcesSimple,
 	}
+
+	c.Flags().Bool("explain", false, "show per-node utilization, pressure levels, and system reservation")
+	c.Flags().Bool("oneline", false, "print only the overall cluster pressure on a single line (for status bars)")
+	c.Flags().Bool("include-terminated", false, "include pods in a terminal phase (Succeeded/Failed), e.g. completed Jobs")
+
+	return c
+}
+
+// thresholdsFromSettings converts configured pressure thresholds to capacity thresholds
+func thresholdsFromSettings(settings *config.Settings) capacity.PressureThresholds {
+	return capacity.PressureThresholds{
+		Low:       settings.PressureThresholds.Low,
+		Medium:    settings.PressureThresholds.Medium,
+		High:      settings.PressureThresholds.High,
+		Saturated: settings.PressureThresholds.Saturated,
+	}
 }
 
 func runResourcesSimple(c *cobra.Command, _ []string) error {
@@ -270,14 +737,9 @@ func runResourcesSimple(c *cobra.Command, _ []string) error {
 	namespace, _ := c.Root().PersistentFlags().GetString("namespace")
 
 	// Load configuration for pressure thresholds and color
-	configFlag, _ := c.Root().PersistentFlags().GetString("config")
-	configPath, err := config.ResolveConfigPath(configFlag)
+	settings, err := loadSettings(c)
 	if err != nil {
-		return fmt.Errorf("resolving config path: %w", err)
-	}
-	settings, err := config.LoadSettingsAt(configPath)
-	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return err
 	}
 
 	// Set color state (this affects all color output globally)
@@ -294,26 +756,31 @@ func runResourcesSimple(c *cobra.Command, _ []string) error {
 		return fmt.Errorf("building k8s client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
 	defer cancel()
 
-	// Convert config thresholds to capacity thresholds
-	thresholds := capacity.PressureThresholds{
-		Low:       settings.PressureThresholds.Low,
-		Medium:    settings.PressureThresholds.Medium,
-		High:      settings.PressureThresholds.High,
-		Saturated: settings.PressureThresholds.Saturated,
-	}
-
 	// Calculate cluster pressure with configured thresholds
-	pressure, err := capacity.CalculatePressureWithThresholds(ctx, client, namespace, thresholds)
+	includeTerminated, _ := c.Flags().GetBool("include-terminated")
+	pressure, err := capacity.CalculatePressureWithOptions(ctx, client, namespace, capacity.PressureOptions{
+		Thresholds:        thresholdsFromSettings(settings),
+		IncludeTerminated: includeTerminated,
+	})
 	if err != nil {
 		return fmt.Errorf("calculating pressure: %w", err)
 	}
 
+	if oneline, _ := c.Flags().GetBool("oneline"); oneline {
+		fmt.Fprintln(c.OutOrStdout(), output.RenderPressureOneline(pressure))
+		return nil
+	}
+
 	// Render and print simple summary
-	summary := output.RenderPressureSimple(pressure)
+	summary := output.RenderPressureSimpleWithOptions(pressure, pressureSimpleOptionsFromSettings(settings))
 	fmt.Fprintf(c.OutOrStdout(), "%s\n", summary)
 
+	if explain, _ := c.Flags().GetBool("explain"); explain {
+		fmt.Fprintf(c.OutOrStdout(), "\n%s\n", output.RenderPressureExplain(pressure))
+	}
+
 	return nil
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/marcgeld/cobrak/pkg/config"
 	"github.com/marcgeld/cobrak/pkg/k8s"
 	"github.com/marcgeld/cobrak/pkg/output"
 	"github.com/marcgeld/cobrak/pkg/resources"
@@ -24,6 +23,11 @@ Requires metrics-server to be installed in the cluster.`,
 	}
 
 	addResourceFlags(c)
+	addMaxAgeFlag(c)
+	addPodFilterFlags(c)
+	c.Flags().Bool("suggest", false, "show suggested requests (usage plus headroom) and the change from current requests, for containers with a metrics sample")
+	c.Flags().Bool("include-orphans", false, "also show usage of containers no longer in their pod's spec (e.g. mid-rollout)")
+	c.Flags().Float64("headroom", resources.DefaultSuggestHeadroom, "fraction added on top of usage for --suggest (0.2 = 20%)")
 
 	return c
 }
@@ -34,20 +38,26 @@ func runResourcesDiff(c *cobra.Command, _ []string) error {
 	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
 	namespace, _ := c.Flags().GetString("namespace")
 	top, _ := c.Flags().GetInt("top")
+	suggest, _ := c.Flags().GetBool("suggest")
+	headroom, _ := c.Flags().GetFloat64("headroom")
+	includeOrphans, _ := c.Flags().GetBool("include-orphans")
+	if headroom < 0 {
+		return fmt.Errorf("invalid --headroom %v: must not be negative", headroom)
+	}
 
 	// Load configuration and set color
-	configFlag, _ := c.Root().PersistentFlags().GetString("config")
-	configPath, err := config.ResolveConfigPath(configFlag)
-	if err != nil {
-		return fmt.Errorf("resolving config path: %w", err)
-	}
-	settings, err := config.LoadSettingsAt(configPath)
+	settings, err := loadSettings(c)
 	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return err
 	}
 	colorEnabled := settings.Color && !nocolor
 	output.SetGlobalColorEnabled(colorEnabled)
 
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
 	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
 	if err != nil {
 		return fmt.Errorf("building rest config: %w", err)
@@ -58,9 +68,14 @@ func runResourcesDiff(c *cobra.Command, _ []string) error {
 		return fmt.Errorf("building k8s client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
 	defer cancel()
 
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
 	metricsReader, err := resources.NewMetricsReaderFromConfig(cfg)
 	if err != nil {
 		return fmt.Errorf("building metrics client: %w", err)
@@ -71,10 +86,10 @@ func runResourcesDiff(c *cobra.Command, _ []string) error {
 		return fmt.Errorf("checking metrics availability: %w", err)
 	}
 	if !available {
-		return fmt.Errorf("metrics API (metrics.k8s.io) not available; install metrics-server")
+		return errMetricsUnavailable
 	}
 
-	_, containers, _, err := resources.BuildInventory(ctx, client, namespace)
+	_, containers, _, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
 	if err != nil {
 		return fmt.Errorf("building inventory: %w", err)
 	}
@@ -83,10 +98,17 @@ func runResourcesDiff(c *cobra.Command, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("fetching pod metrics: %w", err)
 	}
+	if err := checkMetricsAgeFromFlags(c, usages); err != nil {
+		return err
+	}
 
-	diffs := resources.BuildDiff(containers, usages)
+	diffs := resources.BuildDiffWithOptions(containers, usages, resources.DiffOptions{IncludeOrphans: includeOrphans})
 
 	w := c.OutOrStdout()
+	if suggest {
+		fmt.Fprintln(w, output.RenderRecommendationTable(resources.Recommend(diffs, headroom), top))
+		return nil
+	}
 	fmt.Fprintln(w, output.RenderDiffTable(diffs, top))
 
 	return nil
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesExportCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "export",
+		Short: "Export a combined resources report as a single JSON/YAML document",
+		Long: `Bundles the cluster capacity summary, pod summaries, namespace inventory,
+LimitRange/ResourceQuota policies and, when metrics-server is available,
+container usage and usage-vs-request diffs into one document.`,
+		RunE: runResourcesExport,
+	}
+
+	c.Flags().String("namespace", "", "namespace to inspect (default: all namespaces)")
+	c.Flags().String("output", "json", "output format: json, json-compact, or yaml (text is also accepted with --diff-baseline)")
+	c.Flags().String("diff-baseline", "", "path to an earlier export (JSON or YAML); show pods added/removed and request deltas per namespace instead of the report")
+	c.Flags().Bool("anonymize", false, "replace namespace and pod names with hashes (stable within one run) in every section for sharing")
+	addPodFilterFlags(c)
+
+	return c
+}
+
+func runResourcesExport(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	namespace, _ := c.Flags().GetString("namespace")
+	outputFlag, _ := c.Flags().GetString("output")
+	baselinePath, _ := c.Flags().GetString("diff-baseline")
+	anonymize, _ := c.Flags().GetBool("anonymize")
+
+	format, err := output.ParseOutputFormat(outputFlag)
+	if err != nil {
+		return err
+	}
+	if format == output.FormatText && baselinePath == "" {
+		return fmt.Errorf("export supports json, json-compact or yaml output, got %q", outputFlag)
+	}
+	// Hashes are keyed per run, so an anonymized export cannot match a baseline
+	if anonymize && baselinePath != "" {
+		return fmt.Errorf("--anonymize cannot be combined with --diff-baseline")
+	}
+
+	// Read the baseline before talking to the cluster so a bad path fails fast
+	var baseline *output.FullReport
+	if baselinePath != "" {
+		baseline, err = output.LoadFullReport(baselinePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	filters := podFiltersFromFlags(c)
+	includeTerminated, _ := c.Flags().GetBool("include-terminated")
+
+	summary, err := capacity.AnalyzeSummaryWithOptions(ctx, client, namespace, capacity.SummaryOptions{
+		IncludeTerminated: includeTerminated,
+		FieldSelector:     fieldSelector,
+	})
+	if err != nil {
+		return fmt.Errorf("analyzing capacity summary: %w", err)
+	}
+
+	podSummaries, err := resources.BuildPodSummaries(ctx, client, namespace, fieldSelector, filters...)
+	if err != nil {
+		return fmt.Errorf("building pod summaries: %w", err)
+	}
+
+	nsInventories, containers, policies, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, filters...)
+	if err != nil {
+		return fmt.Errorf("building inventory: %w", err)
+	}
+
+	metricsReader, err := resources.NewMetricsReaderFromConfig(cfg)
+	if err != nil {
+		metricsReader = nil
+	}
+	metricsAvailable, _ := resolveMetricsAvailability(ctx, metricsReader, false)
+
+	var usages []resources.ContainerUsage
+	if metricsAvailable {
+		usages, err = metricsReader.PodMetrics(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("fetching pod metrics: %w", err)
+		}
+	}
+
+	// Hash names once everything is gathered so every section agrees
+	if anonymize {
+		if err := anonymizeExport(podSummaries, nsInventories, containers, policies, usages); err != nil {
+			return err
+		}
+	}
+
+	report := buildFullReport(summary, podSummaries, nsInventories, containers, policies, usages, metricsAvailable)
+
+	if baseline != nil {
+		return writeReportDiff(c, output.DiffReports(*baseline, *report), format)
+	}
+
+	outputStr, err := output.RenderOutput(report, format)
+	if err != nil {
+		return fmt.Errorf("rendering output: %w", err)
+	}
+
+	fmt.Fprintf(c.OutOrStdout(), "%s\n", outputStr)
+	return nil
+}
+
+// writeReportDiff writes the changes since a baseline export in the given format
+func writeReportDiff(c *cobra.Command, diff output.ReportDiff, format output.OutputFormat) error {
+	if format == output.FormatText {
+		fmt.Fprintln(c.OutOrStdout(), output.RenderReportDiff(diff))
+		return nil
+	}
+
+	outputStr, err := output.RenderOutput(diff, format)
+	if err != nil {
+		return fmt.Errorf("rendering output: %w", err)
+	}
+
+	fmt.Fprintf(c.OutOrStdout(), "%s\n", outputStr)
+	return nil
+}
+
+// anonymizeExport hashes namespace and pod names in place in everything an
+// export is built from, with one Anonymizer so the sections stay joinable
+func anonymizeExport(
+	podSummaries []resources.PodResourceSummary,
+	nsInventories []resources.NamespaceInventory,
+	containers []resources.ContainerResources,
+	policies []resources.PolicySummary,
+	usages []resources.ContainerUsage,
+) error {
+	anonymizer, err := resources.NewAnonymizer()
+	if err != nil {
+		return err
+	}
+	anonymizer.PodSummaries(podSummaries)
+	anonymizer.NamespaceInventories(nsInventories)
+	anonymizer.Containers(containers)
+	anonymizer.Policies(policies)
+	anonymizer.Usages(usages)
+	return nil
+}
+
+// buildFullReport assembles the export bundle from the existing builders
+func buildFullReport(
+	summary *capacity.ClusterCapacitySummary,
+	podSummaries []resources.PodResourceSummary,
+	nsInventories []resources.NamespaceInventory,
+	containers []resources.ContainerResources,
+	policies []resources.PolicySummary,
+	usages []resources.ContainerUsage,
+	metricsAvailable bool,
+) *output.FullReport {
+	rs := buildResourcesSummary(summary, podSummaries, nsInventories, metricsAvailable, 0)
+
+	report := &output.FullReport{
+		ClusterCapacity:    rs.ClusterCapacity,
+		PodDetails:         rs.PodDetails,
+		NamespaceInventory: rs.NamespaceInventory,
+		Policies:           output.BuildPolicyInventory(policies),
+		MetricsAvailable:   metricsAvailable,
+	}
+
+	if !metricsAvailable {
+		return report
+	}
+
+	report.Usage = output.BuildUsageDetails(usages)
+
+	diffs := resources.BuildDiff(containers, usages)
+	report.Diff = make([]output.ContainerDiffDetail, len(diffs))
+	for i, d := range diffs {
+		report.Diff[i] = output.ContainerDiffDetail{
+			Namespace:         d.Namespace,
+			Pod:               d.PodName,
+			Container:         d.ContainerName,
+			CPUUsage:          d.CPUUsage.String(),
+			CPURequest:        d.CPURequest.String(),
+			CPULimit:          d.CPULimit.String(),
+			MemUsage:          d.MemUsage.String(),
+			MemRequest:        d.MemRequest.String(),
+			MemLimit:          d.MemLimit.String(),
+			CPUUsageToRequest: d.CPUUsageToRequest,
+			MemUsageToRequest: d.MemUsageToRequest,
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func newResourcesGhostsCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "ghosts",
+		Short: "Find containers without requests that consume significant resources (requires metrics-server)",
+		Long: `Finds containers that have no CPU or memory request set but whose measured usage
+is non-trivial. These workloads are invisible to the scheduler and are the riskiest
+unbounded consumers in the cluster. Results are sorted by usage, highest first.
+Requires metrics-server to be installed in the cluster.`,
+		RunE: runResourcesGhosts,
+	}
+
+	addResourceFlags(c)
+	addPodFilterFlags(c)
+	c.Flags().String("min-cpu", resources.DefaultGhostMinCPU.String(), "minimum CPU usage for a requestless container to be reported")
+	c.Flags().String("min-memory", resources.DefaultGhostMinMemory.String(), "minimum memory usage for a requestless container to be reported")
+
+	return c
+}
+
+func runResourcesGhosts(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace, _ := c.Flags().GetString("namespace")
+	top, _ := c.Flags().GetInt("top")
+	minCPUFlag, _ := c.Flags().GetString("min-cpu")
+	minMemFlag, _ := c.Flags().GetString("min-memory")
+
+	minCPU, err := resource.ParseQuantity(minCPUFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --min-cpu %q: %w", minCPUFlag, err)
+	}
+	minMem, err := resource.ParseQuantity(minMemFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --min-memory %q: %w", minMemFlag, err)
+	}
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	metricsReader, err := resources.NewMetricsReaderFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building metrics client: %w", err)
+	}
+
+	available, err := metricsReader.IsAvailable(ctx)
+	if err != nil {
+		return fmt.Errorf("checking metrics availability: %w", err)
+	}
+	if !available {
+		return errMetricsUnavailable
+	}
+
+	_, containers, _, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
+	if err != nil {
+		return fmt.Errorf("building inventory: %w", err)
+	}
+
+	usages, err := metricsReader.PodMetrics(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("fetching pod metrics: %w", err)
+	}
+
+	ghosts := resources.FindGhostContainers(resources.BuildDiff(containers, usages), minCPU, minMem)
+
+	w := c.OutOrStdout()
+	fmt.Fprintln(w, output.RenderGhostTable(ghosts, top))
+
+	return nil
+}
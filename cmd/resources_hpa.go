@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesHPACmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "hpa",
+		Short: "Compare HorizontalPodAutoscaler utilization targets with pod requests",
+		Long: `Lists autoscaling/v2 HorizontalPodAutoscalers with CPU or memory utilization
+targets. Utilization is measured against the pod requests, so each target is shown
+with the scaled Deployment's per-pod request and the current utilization the HPA
+reports. Targets without a request, and HPAs above target at max replicas, are flagged.`,
+		RunE: runResourcesHPA,
+	}
+
+	c.Flags().String("namespace", "", "namespace to inspect (default: all namespaces)")
+
+	return c
+}
+
+func runResourcesHPA(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace, _ := c.Flags().GetString("namespace")
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	targets, err := resources.AnalyzeHPAs(ctx, client, namespace)
+	if err != nil {
+		return fmt.Errorf("analyzing HPAs: %w", err)
+	}
+
+	fmt.Fprintln(c.OutOrStdout(), output.RenderHPATable(targets))
+
+	return nil
+}
@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
-	"github.com/marcgeld/cobrak/pkg/config"
 	"github.com/marcgeld/cobrak/pkg/k8s"
 	"github.com/marcgeld/cobrak/pkg/output"
 	"github.com/marcgeld/cobrak/pkg/resources"
@@ -22,6 +24,15 @@ highlights containers missing requests/limits, and shows LimitRange/ResourceQuot
 	}
 
 	addResourceFlags(c)
+	addPodFilterFlags(c)
+	addPolicyFlag(c)
+	c.Flags().String("show-labels", "", "show namespace labels: 'all' or a comma-separated list of label keys")
+	c.Flags().Lookup("show-labels").NoOptDefVal = "all"
+	c.Flags().Bool("strict", false, "exit non-zero when any container lacks CPU or memory requests, or violates --policy when it is set")
+	c.Flags().String("kind", string(resources.KindAll), "containers listed in the missing requests/limits (or --effective) table: init, regular, or all")
+	c.Flags().Bool("effective", false, "show each container's requests/limits after LimitRange defaulting instead of the missing table")
+	c.Flags().Bool("tree", false, "print namespaces with their pods and containers nested (json, or yaml with --output yaml)")
+	addDisplayFlags(c)
 
 	return c
 }
@@ -32,20 +43,37 @@ func runResourcesInventory(c *cobra.Command, _ []string) error {
 	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
 	namespace, _ := c.Flags().GetString("namespace")
 	top, _ := c.Flags().GetInt("top")
+	showLabels, _ := c.Flags().GetString("show-labels")
+	strict, _ := c.Flags().GetBool("strict")
+	tree, _ := c.Flags().GetBool("tree")
+	effective, _ := c.Flags().GetBool("effective")
+	outputFlag, _ := c.Flags().GetString("output")
 
-	// Load configuration and set color
-	configFlag, _ := c.Root().PersistentFlags().GetString("config")
-	configPath, err := config.ResolveConfigPath(configFlag)
+	policy, err := policyFromFlags(c)
+	if err != nil {
+		return err
+	}
+	strictPolicy := strictPolicyFromFlags(c, policy)
+
+	kindFlag, _ := c.Flags().GetString("kind")
+	kind, err := resources.ParseContainerKind(kindFlag)
 	if err != nil {
-		return fmt.Errorf("resolving config path: %w", err)
+		return fmt.Errorf("invalid --kind: %w", err)
 	}
-	settings, err := config.LoadSettingsAt(configPath)
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
 	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return err
 	}
 	colorEnabled := settings.Color && !nocolor
 	output.SetGlobalColorEnabled(colorEnabled)
 
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
 	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
 	if err != nil {
 		return fmt.Errorf("building rest config: %w", err)
@@ -56,21 +84,159 @@ func runResourcesInventory(c *cobra.Command, _ []string) error {
 		return fmt.Errorf("building k8s client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
 	defer cancel()
 
-	nsInventories, containers, policies, err := resources.BuildInventory(ctx, client, namespace)
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	nsInventories, containers, policies, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
 	if err != nil {
 		return fmt.Errorf("building inventory: %w", err)
 	}
+	resources.ApplyCompliancePolicy(nsInventories, policy)
+
+	if showLabels != "" {
+		var keys []string
+		if showLabels != "all" {
+			keys = strings.Split(showLabels, ",")
+		}
+		resources.AttachNamespaceLabels(ctx, client, nsInventories, keys)
+	}
 
 	w := c.OutOrStdout()
 
-	fmt.Fprintln(w, output.RenderNamespaceInventoryTable(nsInventories))
-	if top > 0 {
-		fmt.Fprintln(w, output.RenderMissingResourcesTable(containers, top))
+	if tree {
+		format, err := output.ParseOutputFormat(outputFlag)
+		if err != nil {
+			return err
+		}
+		// A tree has no table form, so text falls back to JSON
+		if format == output.FormatText {
+			format = output.FormatJSON
+		}
+
+		podSummaries, err := resources.BuildPodSummaries(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
+		if err != nil {
+			return fmt.Errorf("building pod summaries: %w", err)
+		}
+
+		outputStr, err := output.RenderOutput(buildNamespaceTree(nsInventories, podSummaries, containers), format)
+		if err != nil {
+			return fmt.Errorf("rendering output: %w", err)
+		}
+		fmt.Fprintln(w, outputStr)
+
+		if strict {
+			return enforcePolicy(c.ErrOrStderr(), containers, strictPolicy)
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, output.RenderNamespaceInventoryTableWithOptions(nsInventories, displayOptionsFromFlags(c, settings)))
+	if effective {
+		effectiveContainers := resources.ApplyLimitRangeDefaults(resources.FilterContainersByKind(containers, kind), policies)
+		fmt.Fprintln(w, output.RenderEffectiveResourcesTable(effectiveContainers, top))
+	} else {
+		fmt.Fprintln(w, output.RenderMissingResourcesTable(resources.FilterContainersByKind(containers, kind), top))
 	}
 	fmt.Fprintln(w, output.RenderPolicySummary(policies))
 
+	if strict {
+		return enforcePolicy(c.ErrOrStderr(), containers, strictPolicy)
+	}
+
 	return nil
 }
+
+// errMissingRequests is returned by --strict when containers have no CPU or memory request
+var errMissingRequests = errors.New("containers missing CPU or memory requests")
+
+// errMissingLimits is returned by --strict when the policy requires limits and containers have no CPU or memory limit
+var errMissingLimits = errors.New("containers missing CPU or memory limits")
+
+// strictPolicyFromFlags returns the policy --strict enforces: requests only
+// unless --policy was given explicitly
+func strictPolicyFromFlags(c *cobra.Command, policy resources.CompliancePolicy) resources.CompliancePolicy {
+	if flag, _ := c.Flags().GetString("policy"); flag == "" {
+		return resources.PolicyRequestsRequired
+	}
+	return policy
+}
+
+// enforcePolicy lists containers violating the policy on w and returns
+// errMissingRequests when any lacks a required request, otherwise errMissingLimits
+func enforcePolicy(w io.Writer, containers []resources.ContainerResources, policy resources.CompliancePolicy) error {
+	var violators []resources.ContainerResources
+	for _, cr := range containers {
+		if policy.Violates(cr) {
+			violators = append(violators, cr)
+		}
+	}
+	if len(violators) == 0 {
+		return nil
+	}
+
+	sentinel, what := errMissingRequests, "requests"
+	switch policy {
+	case resources.PolicyLimitsRequired:
+		sentinel, what = errMissingLimits, "limits"
+	case resources.PolicyBoth:
+		// Report missing requests first; they affect scheduling
+		what = "requests or limits"
+		if !anyMissingRequests(violators) {
+			sentinel = errMissingLimits
+		}
+	}
+
+	fmt.Fprintf(w, "strict: %d container(s) missing CPU or memory %s:\n", len(violators), what)
+	for _, cr := range violators {
+		fmt.Fprintf(w, "  %s/%s [%s]\n", cr.Namespace, cr.PodName, cr.ContainerName)
+	}
+
+	return fmt.Errorf("%w: %d", sentinel, len(violators))
+}
+
+// anyMissingRequests reports whether any container lacks a CPU or memory request
+func anyMissingRequests(containers []resources.ContainerResources) bool {
+	for _, cr := range containers {
+		if !cr.HasCPURequest || !cr.HasMemRequest {
+			return true
+		}
+	}
+	return false
+}
+
+// buildNamespaceTree nests pod summaries and their containers under each namespace inventory
+func buildNamespaceTree(
+	nsInventories []resources.NamespaceInventory,
+	podSummaries []resources.PodResourceSummary,
+	containers []resources.ContainerResources,
+) []output.NamespaceTree {
+	type podKey struct{ namespace, pod string }
+
+	byPod := make(map[podKey][]output.ContainerDetail)
+	for _, cr := range containers {
+		key := podKey{cr.Namespace, cr.PodName}
+		byPod[key] = append(byPod[key], containerDetail(cr))
+	}
+
+	byNamespace := make(map[string][]output.PodTree)
+	for _, pod := range podSummaries {
+		byNamespace[pod.Namespace] = append(byNamespace[pod.Namespace], output.PodTree{
+			PodDetail:  podDetail(pod),
+			Containers: byPod[podKey{pod.Namespace, pod.PodName}],
+		})
+	}
+
+	trees := make([]output.NamespaceTree, len(nsInventories))
+	for i, ns := range nsInventories {
+		trees[i] = output.NamespaceTree{
+			NamespaceSummary: namespaceSummary(ns),
+			Pods:             byNamespace[ns.Namespace],
+		}
+	}
+	return trees
+}
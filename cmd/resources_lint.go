@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/logging"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+func newResourcesLintCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "lint",
+		Short: "Flag risky request/limit combinations on containers",
+		Long: `Checks every container against a set of rules for risky resource settings:
+
+  mem-limit-without-request  memory limit but no request; the API server defaults
+                             the request to the limit, reserving all of it
+  cpu-limit-without-request  CPU limit but no request; the API server defaults
+                             the request to the limit, reserving all of it
+  unschedulable-by-size      pod requests more CPU or memory than the largest
+                             node allocatable, so it can never be scheduled
+
+With --policy=limits-required, a missing request is not a violation (Kubernetes
+defaults the request to the limit), so both rules are skipped.`,
+		RunE: runResourcesLint,
+	}
+
+	addResourceFlags(c)
+	addPodFilterFlags(c)
+	addPolicyFlag(c)
+
+	return c
+}
+
+func runResourcesLint(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace, _ := c.Flags().GetString("namespace")
+	top, _ := c.Flags().GetInt("top")
+
+	policy, err := policyFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	_, containers, _, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
+	if err != nil {
+		return fmt.Errorf("building inventory: %w", err)
+	}
+
+	findings := resources.Lint(containers, resources.LintRulesForPolicy(resources.DefaultLintRules, policy))
+	findings = append(findings, findOversizedPods(ctx, client, containers)...)
+	resources.SortLintFindings(findings)
+
+	w := c.OutOrStdout()
+	fmt.Fprintln(w, output.RenderLintTable(findings, top))
+
+	return nil
+}
+
+// findOversizedPods flags pods requesting more than the largest node allocatable.
+// Listing nodes needs cluster-scope access, so without it the check is skipped.
+func findOversizedPods(ctx context.Context, client kubernetes.Interface, containers []resources.ContainerResources) []resources.LintFinding {
+	nodes, err := capacity.Analyze(ctx, client)
+	if err != nil {
+		logging.FromContext(ctx).Infof("skipping %s check: %v", resources.UnschedulableBySize, err)
+		return nil
+	}
+
+	var maxCPU, maxMem resource.Quantity
+	for _, n := range nodes {
+		if n.CPUAllocatable.Cmp(maxCPU) > 0 {
+			maxCPU = n.CPUAllocatable
+		}
+		if n.MemAllocatable.Cmp(maxMem) > 0 {
+			maxMem = n.MemAllocatable
+		}
+	}
+
+	return resources.FindOversizedPods(containers, maxCPU, maxMem)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesNsDiffCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "nsdiff",
+		Short: "Compare usage vs requests per namespace (requires metrics-server)",
+		Long: `Sums actual CPU/memory usage and requests per namespace and reports how much
+of the requested resources each namespace actually uses. Namespaces are listed
+from the least efficient up, and the most wasteful one is highlighted.
+Requires metrics-server to be installed in the cluster.`,
+		RunE: runResourcesNsDiff,
+	}
+
+	addResourceFlags(c)
+	addPodFilterFlags(c)
+
+	return c
+}
+
+func runResourcesNsDiff(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace, _ := c.Flags().GetString("namespace")
+	top, _ := c.Flags().GetInt("top")
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	metricsReader, err := resources.NewMetricsReaderFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building metrics client: %w", err)
+	}
+
+	available, err := metricsReader.IsAvailable(ctx)
+	if err != nil {
+		return fmt.Errorf("checking metrics availability: %w", err)
+	}
+	if !available {
+		return errMetricsUnavailable
+	}
+
+	_, containers, _, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
+	if err != nil {
+		return fmt.Errorf("building inventory: %w", err)
+	}
+
+	usages, err := metricsReader.PodMetrics(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("fetching pod metrics: %w", err)
+	}
+
+	nsDiffs := resources.AggregateDiffByNamespace(resources.BuildDiff(containers, usages))
+	fmt.Fprintln(c.OutOrStdout(), output.RenderNamespaceDiffTable(nsDiffs, top))
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesPlacementCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "placement <namespace>",
+		Short: "Show which nodes host a namespace's pods",
+		Long: `Maps a namespace's pods to the nodes they run on and shows how many pods and
+how much requested CPU/memory each node carries for that namespace. A single node
+carrying most of a namespace is a single point of failure.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runResourcesPlacement,
+	}
+
+	addPodFilterFlags(c)
+
+	return c
+}
+
+func runResourcesPlacement(c *cobra.Command, args []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace := args[0]
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	placements, err := resources.BuildNamespacePlacement(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
+	if err != nil {
+		return fmt.Errorf("building placement: %w", err)
+	}
+
+	if len(placements) == 0 {
+		printNoData(c, output.FormatText, "pods in namespace "+namespace)
+		return nil
+	}
+
+	fmt.Fprintln(c.OutOrStdout(), output.RenderNamespacePlacement(placements))
+	return nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesPodCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pod <namespace>/<name>",
+		Short: "Show requests, limits and (if available) usage for a single pod",
+		Long: `Fetches a single pod and shows its total and per-container requests and limits.
+When metrics-server is available, measured usage and usage/request ratios are
+included for each container.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runResourcesPod,
+	}
+}
+
+func runResourcesPod(c *cobra.Command, args []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+
+	namespace, name, err := parsePodRef(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	summary, containers, err := resources.BuildSinglePodSummary(ctx, client, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	// Usage is optional; without metrics-server only requests/limits are shown
+	var diffs []resources.ContainerDiff
+	metricsReader, err := resources.NewMetricsReaderFromConfig(cfg)
+	if err != nil {
+		metricsReader = nil
+	}
+	if available, _ := resolveMetricsAvailability(ctx, metricsReader, false); available {
+		usages, err := metricsReader.PodMetrics(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("fetching pod metrics: %w", err)
+		}
+		diffs = resources.BuildDiff(containers, usages)
+	}
+
+	fmt.Fprintln(c.OutOrStdout(), output.RenderPodDetail(summary, containers, diffs))
+	return nil
+}
+
+// parsePodRef splits a "<namespace>/<name>" argument
+func parsePodRef(ref string) (string, string, error) {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return "", "", fmt.Errorf("invalid pod %q: expected <namespace>/<name>", ref)
+	}
+	return namespace, name, nil
+}
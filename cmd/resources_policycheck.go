@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesPolicyCheckCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "policycheck",
+		Short: "Compare container requests/limits against LimitRange min/max",
+		Long: `Checks each container's CPU/memory requests and limits against the min/max
+of the Container-type LimitRanges in its namespace and reports values outside
+those bounds. Such pods were admitted before the LimitRange changed and would be
+rejected if recreated.`,
+		RunE: runResourcesPolicyCheck,
+	}
+
+	c.Flags().String("namespace", "", "namespace to inspect (default: all namespaces)")
+	addPodFilterFlags(c)
+
+	return c
+}
+
+func runResourcesPolicyCheck(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace, _ := c.Flags().GetString("namespace")
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	_, containers, policies, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
+	if err != nil {
+		return fmt.Errorf("building inventory: %w", err)
+	}
+
+	violations := resources.FindLimitRangeViolations(containers, policies)
+
+	fmt.Fprintln(c.OutOrStdout(), output.RenderLimitRangeViolationTable(violations))
+
+	return nil
+}
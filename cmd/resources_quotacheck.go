@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesQuotaCheckCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "quotacheck",
+		Short: "Compare ResourceQuota usage against summed pod requests/limits",
+		Long: `Compares each namespace's ResourceQuota Used values with the CPU/memory
+requests and limits summed from its running pods, and reports entries that differ
+by more than the tolerance. Drift usually points at stale quota accounting.
+Quotas with hard limits but nothing used are listed as likely leftovers.`,
+		RunE: runResourcesQuotaCheck,
+	}
+
+	c.Flags().String("namespace", "", "namespace to inspect (default: all namespaces)")
+	c.Flags().Float64("tolerance", resources.DefaultQuotaDriftTolerance, "relative difference tolerated before reporting drift (0.05 = 5%)")
+
+	return c
+}
+
+func runResourcesQuotaCheck(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace, _ := c.Flags().GetString("namespace")
+	tolerance, _ := c.Flags().GetFloat64("tolerance")
+
+	if tolerance < 0 {
+		return fmt.Errorf("invalid --tolerance %v: must not be negative", tolerance)
+	}
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	// Quota accounting ignores pods in a terminal phase, so do the same here
+	_, containers, policies, err := resources.BuildInventory(ctx, client, namespace, "", resources.ExcludeTerminated)
+	if err != nil {
+		return fmt.Errorf("building inventory: %w", err)
+	}
+
+	drifts := resources.FindQuotaDrift(containers, policies, tolerance)
+
+	fmt.Fprintln(c.OutOrStdout(), output.RenderQuotaDriftTable(drifts))
+	if unused := output.RenderUnusedQuotas(resources.FindUnusedQuotas(policies)); unused != "" {
+		fmt.Fprintln(c.OutOrStdout(), unused)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesRatiosCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "ratios",
+		Short: "Show the distribution of limit/request ratios across containers",
+		Long: `Buckets every container by its CPU and memory limit/request ratio (1x, 1-2x,
+2-4x, >4x) and prints a histogram per resource. The ratio is the burst headroom
+above what the scheduler reserved: a cluster with many >4x containers can be
+badly overcommitted when they burst together. Containers missing either the
+request or the limit have no ratio and are counted separately.`,
+		RunE: runResourcesRatios,
+	}
+
+	addResourceFlags(c)
+	addPodFilterFlags(c)
+
+	return c
+}
+
+func runResourcesRatios(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace, _ := c.Flags().GetString("namespace")
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	_, containers, _, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
+	if err != nil {
+		return fmt.Errorf("building inventory: %w", err)
+	}
+
+	cpu, mem := resources.BuildRatioHistograms(containers)
+
+	w := c.OutOrStdout()
+	fmt.Fprintln(w, output.RenderRatioHistograms(cpu, mem))
+
+	return nil
+}
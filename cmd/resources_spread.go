@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesSpreadCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "spread <namespace>",
+		Short: "Show how each workload's pods are spread across nodes and zones",
+		Long: `Groups a namespace's pods by the workload controlling them (Deployment,
+StatefulSet, ...) and counts the nodes and zones they run on. Workloads with
+several replicas that all share one node or one zone are flagged: losing that
+node or zone takes the whole workload down, which anti-affinity or topology
+spread constraints would prevent.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runResourcesSpread,
+	}
+
+	addPodFilterFlags(c)
+
+	return c
+}
+
+func runResourcesSpread(c *cobra.Command, args []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace := args[0]
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	spreads, err := resources.BuildWorkloadSpread(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
+	if err != nil {
+		return fmt.Errorf("building workload spread: %w", err)
+	}
+
+	if len(spreads) == 0 {
+		printNoData(c, output.FormatText, "workload pods in namespace "+namespace)
+		return nil
+	}
+
+	fmt.Fprintln(c.OutOrStdout(), output.RenderWorkloadSpread(spreads))
+	return nil
+}
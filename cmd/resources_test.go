@@ -2,12 +2,21 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/marcgeld/cobrak/pkg/capacity"
 	"github.com/marcgeld/cobrak/pkg/output"
 	"github.com/marcgeld/cobrak/pkg/resources"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestBuildResourcesSummary_TopLimit(t *testing.T) {
@@ -81,12 +90,13 @@ func TestBuildResourcesSummary_TopZero(t *testing.T) {
 		TotalMemLimits:      *resource.NewQuantity(2*1024*1024*1024, resource.BinarySI),
 	}
 
-	// Test with top=0 (no limit)
-	top := 0
-	result := buildResourcesSummary(summary, podSummaries, nsInventories, false, top)
+	// Test with top=0 and negative top (no limit)
+	for _, top := range []int{0, -1} {
+		result := buildResourcesSummary(summary, podSummaries, nsInventories, false, top)
 
-	if len(result.PodDetails) != 3 {
-		t.Errorf("Expected 3 pods in result with top=0, got %d", len(result.PodDetails))
+		if len(result.PodDetails) != 3 {
+			t.Errorf("Expected 3 pods in result with top=%d, got %d", top, len(result.PodDetails))
+		}
 	}
 }
 
@@ -169,3 +179,632 @@ func TestRenderOutput_TopLimit(t *testing.T) {
 func containsSubstring(text, substring string) bool {
 	return bytes.Contains([]byte(text), []byte(substring))
 }
+
+// fakeMetricsReader reports a fixed availability
+type fakeMetricsReader struct {
+	available bool
+}
+
+func (f *fakeMetricsReader) IsAvailable(_ context.Context) (bool, error) {
+	return f.available, nil
+}
+
+func (f *fakeMetricsReader) PodMetrics(_ context.Context, _ string) ([]resources.ContainerUsage, error) {
+	return nil, nil
+}
+
+func TestResolveMetricsAvailability(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := resolveMetricsAvailability(ctx, &fakeMetricsReader{available: false}, true); !errors.Is(err, errMetricsUnavailable) {
+		t.Errorf("Expected errMetricsUnavailable with --require-metrics, got %v", err)
+	}
+
+	available, err := resolveMetricsAvailability(ctx, &fakeMetricsReader{available: false}, false)
+	if err != nil || available {
+		t.Errorf("Expected unavailable without error when not required, got %v, %v", available, err)
+	}
+
+	available, err = resolveMetricsAvailability(ctx, &fakeMetricsReader{available: true}, true)
+	if err != nil || !available {
+		t.Errorf("Expected available without error, got %v, %v", available, err)
+	}
+
+	if _, err := resolveMetricsAvailability(ctx, nil, true); !errors.Is(err, errMetricsUnavailable) {
+		t.Errorf("Expected errMetricsUnavailable for nil reader, got %v", err)
+	}
+}
+
+func TestPodFiltersFromFlags_ExcludesTerminatedByDefault(t *testing.T) {
+	done := &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}}
+
+	c := newResourcesCmd()
+	if accepted(podFiltersFromFlags(c), done) {
+		t.Error("expected succeeded pod to be excluded by default")
+	}
+
+	if err := c.Flags().Set("include-terminated", "true"); err != nil {
+		t.Fatalf("setting flag: %v", err)
+	}
+	if !accepted(podFiltersFromFlags(c), done) {
+		t.Error("expected succeeded pod to be included with --include-terminated")
+	}
+}
+
+func accepted(filters []resources.PodFilter, pod *corev1.Pod) bool {
+	for _, f := range filters {
+		if !f(pod) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEnforcePolicy_Strict(t *testing.T) {
+	requests := corev1.ResourceRequirements{Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("64Mi"),
+	}}
+	limits := corev1.ResourceRequirements{Limits: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("64Mi"),
+	}}
+	pod := func(name string, res corev1.ResourceRequirements) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Resources: res}},
+			},
+		}
+	}
+
+	both := corev1.ResourceRequirements{Requests: requests.Requests, Limits: limits.Limits}
+
+	tests := []struct {
+		name      string
+		policy    resources.CompliancePolicy
+		pods      []runtime.Object
+		wantErr   error
+		wantPodID string
+	}{
+		{name: "clean cluster", policy: resources.PolicyBoth, pods: []runtime.Object{pod("ok", both)}},
+		{name: "missing requests", policy: resources.PolicyBoth, pods: []runtime.Object{pod("ok", both), pod("bare", corev1.ResourceRequirements{})}, wantErr: errMissingRequests, wantPodID: "default/bare"},
+		{name: "both flags missing limits", policy: resources.PolicyBoth, pods: []runtime.Object{pod("ok", requests)}, wantErr: errMissingLimits, wantPodID: "default/ok"},
+		{name: "requests-required ignores limits", policy: resources.PolicyRequestsRequired, pods: []runtime.Object{pod("ok", requests)}},
+		{name: "limits-required accepts limits without requests", policy: resources.PolicyLimitsRequired, pods: []runtime.Object{pod("capped", limits)}},
+		{name: "limits-required flags missing limits", policy: resources.PolicyLimitsRequired, pods: []runtime.Object{pod("ok", requests)}, wantErr: errMissingLimits, wantPodID: "default/ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(tt.pods...)
+			_, containers, _, err := resources.BuildInventory(context.Background(), client, "", "")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var stderr bytes.Buffer
+			err = enforcePolicy(&stderr, containers, tt.policy)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if !strings.Contains(stderr.String(), tt.wantPodID) {
+				t.Errorf("expected offending container listed, got: %s", stderr.String())
+			}
+		})
+	}
+}
+
+func TestStrictPolicyFromFlags(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "ok", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			}}}},
+		},
+	}
+	_, containers, _, err := resources.BuildInventory(context.Background(), fake.NewSimpleClientset(pod), "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "strict without policy checks requests only", args: []string{"--strict"}},
+		{name: "strict with policy both requires limits", args: []string{"--strict", "--policy=both"}, wantErr: errMissingLimits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newResourcesInventoryCmd()
+			if err := c.ParseFlags(tt.args); err != nil {
+				t.Fatalf("parsing flags: %v", err)
+			}
+			policy, err := policyFromFlags(c)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var stderr bytes.Buffer
+			err = enforcePolicy(&stderr, containers, strictPolicyFromFlags(c, policy))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBuildFullReport_RoundTrip(t *testing.T) {
+	summary := &capacity.ClusterCapacitySummary{
+		TotalCPUCapacity: *resource.NewMilliQuantity(4000, resource.DecimalSI),
+	}
+	podSummaries := []resources.PodResourceSummary{createMockPod("pod1")}
+	nsInventories := []resources.NamespaceInventory{{Namespace: "default", ContainersTotal: 1}}
+	containers := []resources.ContainerResources{
+		{
+			Namespace:     "default",
+			PodName:       "pod1",
+			ContainerName: "app",
+			CPURequest:    resource.MustParse("100m"),
+			HasCPURequest: true,
+		},
+	}
+	policies := []resources.PolicySummary{
+		{
+			Namespace: "default",
+			ResourceQuotas: []resources.ResourceQuotaSummary{{
+				Name: "compute",
+				Hard: map[corev1.ResourceName]resource.Quantity{corev1.ResourceRequestsCPU: resource.MustParse("2")},
+			}},
+		},
+	}
+	usages := []resources.ContainerUsage{
+		{Namespace: "default", PodName: "pod1", ContainerName: "app", CPUUsage: resource.MustParse("50m")},
+	}
+
+	report := buildFullReport(summary, podSummaries, nsInventories, containers, policies, usages, true)
+
+	for _, format := range []output.OutputFormat{output.FormatJSON, output.FormatYAML} {
+		rendered, err := output.RenderOutput(report, format)
+		if err != nil {
+			t.Fatalf("rendering %s: %v", format, err)
+		}
+
+		var decoded output.FullReport
+		if format == output.FormatJSON {
+			err = json.Unmarshal([]byte(rendered), &decoded)
+		} else {
+			err = yaml.Unmarshal([]byte(rendered), &decoded)
+		}
+		if err != nil {
+			t.Fatalf("decoding %s: %v", format, err)
+		}
+
+		if decoded.ClusterCapacity == nil || decoded.ClusterCapacity.CPUCapacity != "4" {
+			t.Errorf("%s: expected cluster capacity section, got %+v", format, decoded.ClusterCapacity)
+		}
+		if len(decoded.PodDetails) != 1 || len(decoded.NamespaceInventory) != 1 || len(decoded.Policies) != 1 {
+			t.Errorf("%s: expected pod, inventory and policy sections, got %+v", format, decoded)
+		}
+		if !decoded.MetricsAvailable || len(decoded.Usage) != 1 || len(decoded.Diff) != 1 {
+			t.Errorf("%s: expected usage and diff sections, got %+v", format, decoded)
+		}
+		if decoded.Diff[0].CPUUsageToRequest != 0.5 {
+			t.Errorf("%s: expected usage/request ratio 0.5, got %f", format, decoded.Diff[0].CPUUsageToRequest)
+		}
+	}
+}
+
+func TestBuildFullReport_Anonymized(t *testing.T) {
+	podSummaries := []resources.PodResourceSummary{{Namespace: "payments", PodName: "checkout-api", NodeName: "worker-1"}}
+	nsInventories := []resources.NamespaceInventory{{Namespace: "payments", ContainersTotal: 1}}
+	containers := []resources.ContainerResources{
+		{
+			Namespace:     "payments",
+			PodName:       "checkout-api",
+			ContainerName: "app",
+			CPURequest:    resource.MustParse("100m"),
+			HasCPURequest: true,
+		},
+	}
+	policies := []resources.PolicySummary{
+		{
+			Namespace: "payments",
+			ResourceQuotas: []resources.ResourceQuotaSummary{{
+				Name: "compute",
+				Hard: map[corev1.ResourceName]resource.Quantity{corev1.ResourceRequestsCPU: resource.MustParse("2")},
+			}},
+		},
+	}
+	usages := []resources.ContainerUsage{
+		{Namespace: "payments", PodName: "checkout-api", ContainerName: "app", CPUUsage: resource.MustParse("50m")},
+	}
+
+	if err := anonymizeExport(podSummaries, nsInventories, containers, policies, usages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report := buildFullReport(&capacity.ClusterCapacitySummary{}, podSummaries, nsInventories, containers, policies, usages, true)
+
+	rendered, err := output.RenderOutput(report, output.FormatJSON)
+	if err != nil {
+		t.Fatalf("rendering: %v", err)
+	}
+	if strings.Contains(rendered, "payments") || strings.Contains(rendered, "checkout-api") {
+		t.Errorf("expected no original names anywhere in the export:\n%s", rendered)
+	}
+	// Usage and spec still join, so the diff section keeps its row
+	if len(report.Diff) != 1 || report.Diff[0].CPUUsageToRequest != 0.5 {
+		t.Errorf("expected one joined diff row with ratio 0.5, got %+v", report.Diff)
+	}
+	if report.PodDetails[0].Namespace != report.Policies[0].Namespace || report.Usage[0].Pod != report.PodDetails[0].Pod {
+		t.Errorf("expected names to hash identically across sections, got %+v", report)
+	}
+}
+
+func TestBuildFullReport_NoMetrics(t *testing.T) {
+	report := buildFullReport(&capacity.ClusterCapacitySummary{}, nil, nil, nil, nil, nil, false)
+	if report.Usage != nil || report.Diff != nil {
+		t.Errorf("expected usage and diff to be omitted without metrics, got %+v", report)
+	}
+}
+
+func TestBuildNamespaceTree_NestsPodsUnderNamespace(t *testing.T) {
+	other := createMockPod("api")
+	other.Namespace = "payments"
+	podSummaries := []resources.PodResourceSummary{createMockPod("web"), other}
+	nsInventories := []resources.NamespaceInventory{
+		{Namespace: "default", ContainersTotal: 1},
+		{Namespace: "payments", ContainersTotal: 2, Labels: map[string]string{"owner": "billing"}},
+	}
+	containers := []resources.ContainerResources{
+		{Namespace: "default", PodName: "web", ContainerName: "nginx"},
+		{Namespace: "payments", PodName: "api", ContainerName: "migrate", IsInit: true},
+		{Namespace: "payments", PodName: "api", ContainerName: "server"},
+	}
+
+	rendered, err := output.RenderOutput(buildNamespaceTree(nsInventories, podSummaries, containers), output.FormatJSON)
+	if err != nil {
+		t.Fatalf("rendering tree: %v", err)
+	}
+
+	var decoded []output.NamespaceTree
+	if err := json.Unmarshal([]byte(rendered), &decoded); err != nil {
+		t.Fatalf("decoding tree: %v", err)
+	}
+
+	if len(decoded) != 2 {
+		t.Fatalf("expected 2 namespaces, got %d", len(decoded))
+	}
+	if decoded[1].Namespace != "payments" || decoded[1].ContainersTotal != 2 {
+		t.Errorf("expected namespace summary fields inline, got %+v", decoded[1].NamespaceSummary)
+	}
+	if decoded[1].Labels["owner"] != "billing" {
+		t.Errorf("expected namespace labels in JSON, got %v", decoded[1].Labels)
+	}
+	if len(decoded[0].Pods) != 1 || decoded[0].Pods[0].Pod != "web" {
+		t.Errorf("expected pod 'web' under default, got %+v", decoded[0].Pods)
+	}
+	if len(decoded[1].Pods) != 1 || decoded[1].Pods[0].Pod != "api" {
+		t.Fatalf("expected pod 'api' under payments, got %+v", decoded[1].Pods)
+	}
+	apiContainers := decoded[1].Pods[0].Containers
+	if len(apiContainers) != 2 || !apiContainers[0].Init || apiContainers[1].Container != "server" {
+		t.Errorf("expected init and app containers under api, got %+v", apiContainers)
+	}
+
+	renderedYAML, err := output.RenderOutput(buildNamespaceTree(nsInventories, podSummaries, containers), output.FormatYAML)
+	if err != nil {
+		t.Fatalf("rendering tree: %v", err)
+	}
+	if !strings.Contains(renderedYAML, "owner: billing") {
+		t.Errorf("expected namespace labels in YAML, got:\n%s", renderedYAML)
+	}
+}
+
+func TestParsePodRef(t *testing.T) {
+	ns, name, err := parsePodRef("shop/web")
+	if err != nil || ns != "shop" || name != "web" {
+		t.Errorf("expected shop/web, got %q/%q (err %v)", ns, name, err)
+	}
+	for _, bad := range []string{"web", "/web", "shop/", ""} {
+		if _, _, err := parsePodRef(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestWriteResourcesReport_EmptyCluster(t *testing.T) {
+	for _, format := range []string{"text", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			c := newResourcesCmd()
+			c.SetOut(&stdout)
+			c.SetErr(&stderr)
+
+			err := writeResourcesReport(context.Background(), c, fake.NewSimpleClientset(), nil, resourcesReportOptions{output: format})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			note, other := stdout.String(), stderr.String()
+			if format != "text" {
+				note, other = other, note
+			}
+			if !strings.Contains(note, "No nodes/pods found in scope") {
+				t.Errorf("expected empty-scope note, got %q", note)
+			}
+			if strings.Contains(other, "No nodes/pods") {
+				t.Errorf("empty-scope note leaked into the wrong stream: %q", other)
+			}
+			if format == "text" && strings.Contains(note, "CLUSTER CAPACITY SUMMARY") {
+				t.Errorf("expected no capacity section for an empty cluster, got %q", note)
+			}
+		})
+	}
+}
+
+func TestWriteResourcesReport_JSONLStream(t *testing.T) {
+	var objects []runtime.Object
+	for _, name := range []string{"api", "web", "worker"} {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers:     []corev1.Container{{Name: "app"}},
+			},
+		})
+	}
+
+	tests := []struct {
+		name        string
+		opts        resourcesReportOptions
+		wantObjects int
+	}{
+		{name: "pods", opts: resourcesReportOptions{output: "jsonl"}, wantObjects: 3},
+		{name: "containers", opts: resourcesReportOptions{output: "jsonl", byContainer: true}, wantObjects: 6},
+		{name: "top", opts: resourcesReportOptions{output: "jsonl", top: 2}, wantObjects: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			c := newResourcesCmd()
+			c.SetOut(&buf)
+
+			err := writeResourcesReport(context.Background(), c, fake.NewSimpleClientset(objects...), nil, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+			if len(lines) != tt.wantObjects {
+				t.Fatalf("expected %d lines, got %d:\n%s", tt.wantObjects, len(lines), buf.String())
+			}
+			for _, line := range lines {
+				var obj map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &obj); err != nil {
+					t.Fatalf("line is not a JSON object: %q: %v", line, err)
+				}
+				if obj["namespace"] != "default" {
+					t.Errorf("expected namespace default, got %v", obj["namespace"])
+				}
+			}
+		})
+	}
+}
+
+func TestWriteResourcesReport_ShowZero(t *testing.T) {
+	output.SetGlobalColorEnabled(false)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	requested := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "requested", Namespace: "default"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "app",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			},
+		}}},
+	}
+	bare := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bare", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	columns, err := output.LookupPodColumns(output.DefaultPodColumns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	podTable := func(showZero bool) string {
+		var buf bytes.Buffer
+		c := newResourcesCmd()
+		c.SetOut(&buf)
+		err := writeResourcesReport(context.Background(), c, fake.NewSimpleClientset(node, requested, bare), nil, resourcesReportOptions{
+			output:     "text",
+			showZero:   showZero,
+			podColumns: columns,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		const header = "=== POD RESOURCE DETAILS ==="
+		section := buf.String()[strings.Index(buf.String(), header)+len(header):]
+		if end := strings.Index(section, "==="); end >= 0 {
+			section = section[:end]
+		}
+		return section
+	}
+
+	hidden := podTable(false)
+	if strings.Contains(hidden, "bare") || !strings.Contains(hidden, "requested") {
+		t.Errorf("expected zero-request pod hidden by default, got:\n%s", hidden)
+	}
+	if !strings.Contains(hidden, "Hidden pods without requests: 1") {
+		t.Errorf("expected hidden-row note, got:\n%s", hidden)
+	}
+
+	shown := podTable(true)
+	if !strings.Contains(shown, "bare") || strings.Contains(shown, "Hidden pods") {
+		t.Errorf("expected zero-request pod with --show-zero, got:\n%s", shown)
+	}
+}
+
+func TestWriteResourcesReport_PodCounts(t *testing.T) {
+	output.SetGlobalColorEnabled(false)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	objects := []runtime.Object{node}
+	for _, p := range []struct{ name, node string }{{"api", "worker-1"}, {"web", "worker-1"}, {"batch", ""}} {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: p.name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName:       p.node,
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers:     []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+			},
+		})
+	}
+
+	var buf bytes.Buffer
+	c := newResourcesCmd()
+	c.SetOut(&buf)
+	err := writeResourcesReport(context.Background(), c, fake.NewSimpleClientset(objects...), nil, resourcesReportOptions{output: "json", top: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var summary output.ResourcesSummary
+	if err := json.Unmarshal(buf.Bytes(), &summary); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	want := output.PodCountSummary{Pods: 3, Scheduled: 2, Pending: 1, Containers: 9, InitContainers: 3}
+	if summary.PodCounts == nil || *summary.PodCounts != want {
+		t.Errorf("expected pod counts %+v regardless of --top, got %+v", want, summary.PodCounts)
+	}
+
+	buf.Reset()
+	err = writeResourcesReport(context.Background(), c, fake.NewSimpleClientset(objects...), nil, resourcesReportOptions{output: "text"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range []string{"Pods:                        3 (scheduled 2, pending 1)", "Total containers:            9 (init 3)"} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("expected %q in inventory section, got:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestWriteUsage_JSONTotals(t *testing.T) {
+	usages := []resources.ContainerUsage{
+		{Namespace: "default", PodName: "a", ContainerName: "app", CPUUsage: resource.MustParse("100m"), MemUsage: resource.MustParse("64Mi")},
+		{Namespace: "default", PodName: "b", ContainerName: "app", CPUUsage: resource.MustParse("750m"), MemUsage: resource.MustParse("256Mi")},
+		{Namespace: "default", PodName: "c", ContainerName: "app", CPUUsage: resource.MustParse("250m"), MemUsage: resource.MustParse("1Gi")},
+	}
+
+	var buf bytes.Buffer
+	c := newResourcesUsageCmd()
+	c.SetOut(&buf)
+	if err := writeUsage(c, usages, output.FormatJSON, resources.UsageSortCPU, 2, output.UsageTableOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report output.UsageReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(report.Containers) != 2 || report.Containers[0].Pod != "b" || report.Containers[1].Pod != "c" {
+		t.Fatalf("expected the top 2 rows by CPU (b, c), got %+v", report.Containers)
+	}
+
+	cpu := resource.MustParse("0")
+	mem := resource.MustParse("0")
+	for _, row := range report.Containers {
+		cpu.Add(resource.MustParse(row.CPUUsage))
+		mem.Add(resource.MustParse(row.MemUsage))
+	}
+	if report.Totals.Containers != len(report.Containers) {
+		t.Errorf("expected totals over %d containers, got %d", len(report.Containers), report.Totals.Containers)
+	}
+	if got := resource.MustParse(report.Totals.CPUUsage); got.Cmp(cpu) != 0 {
+		t.Errorf("expected CPU total %s, got %s", cpu.String(), report.Totals.CPUUsage)
+	}
+	if got := resource.MustParse(report.Totals.MemUsage); got.Cmp(mem) != 0 {
+		t.Errorf("expected memory total %s, got %s", mem.String(), report.Totals.MemUsage)
+	}
+}
+
+func TestWriteResourcesReport_Anonymize(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout-api", Namespace: "payments"},
+		Spec: corev1.PodSpec{
+			NodeName: "worker-1",
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	c := newResourcesCmd()
+	c.SetOut(&buf)
+	err := writeResourcesReport(context.Background(), c, fake.NewSimpleClientset(pod), nil, resourcesReportOptions{
+		output:      "json",
+		byContainer: true,
+		anonymize:   true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var summary output.ResourcesSummary
+	if err := json.Unmarshal(buf.Bytes(), &summary); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(summary.PodDetails) != 1 || len(summary.ContainerDetails) != 1 {
+		t.Fatalf("expected one pod and one container row, got %+v and %+v", summary.PodDetails, summary.ContainerDetails)
+	}
+	pods, containers := summary.PodDetails[0], summary.ContainerDetails[0]
+	if pods.Pod == "checkout-api" || pods.Namespace == "payments" {
+		t.Errorf("expected names to be anonymized, got %s/%s", pods.Namespace, pods.Pod)
+	}
+	if containers.Pod != pods.Pod || containers.Namespace != pods.Namespace {
+		t.Errorf("expected the same pod to hash identically in both sections, got %s/%s and %s/%s",
+			pods.Namespace, pods.Pod, containers.Namespace, containers.Pod)
+	}
+	if strings.Contains(buf.String(), "checkout-api") || strings.Contains(buf.String(), "payments") {
+		t.Errorf("expected no original names anywhere in the report:\n%s", buf.String())
+	}
+}
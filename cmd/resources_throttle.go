@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"github.com/spf13/cobra"
+)
+
+func newResourcesThrottleCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "throttle",
+		Short: "Find containers whose CPU usage is close to their CPU limit (requires metrics-server)",
+		Long: `Finds containers with a CPU limit whose measured usage is within a margin of that
+limit. The kernel throttles such containers on every burst past the limit, adding
+latency even when the node has idle CPU. Results are sorted by usage as a share of
+the limit, closest first.
+Requires metrics-server to be installed in the cluster.`,
+		RunE: runResourcesThrottle,
+	}
+
+	addResourceFlags(c)
+	addPodFilterFlags(c)
+	c.Flags().Float64("margin", resources.DefaultThrottleMargin, "report containers whose CPU usage is within this fraction of their limit (0.1 = 10%)")
+
+	return c
+}
+
+func runResourcesThrottle(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
+	namespace, _ := c.Flags().GetString("namespace")
+	top, _ := c.Flags().GetInt("top")
+	margin, _ := c.Flags().GetFloat64("margin")
+	if margin < 0 || margin >= 1 {
+		return fmt.Errorf("invalid --margin %v: must be at least 0 and below 1", margin)
+	}
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+	colorEnabled := settings.Color && !nocolor
+	output.SetGlobalColorEnabled(colorEnabled)
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
+	defer cancel()
+
+	fieldSelector, err := fieldSelectorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	metricsReader, err := resources.NewMetricsReaderFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building metrics client: %w", err)
+	}
+
+	available, err := metricsReader.IsAvailable(ctx)
+	if err != nil {
+		return fmt.Errorf("checking metrics availability: %w", err)
+	}
+	if !available {
+		return errMetricsUnavailable
+	}
+
+	_, containers, _, err := resources.BuildInventory(ctx, client, namespace, fieldSelector, podFiltersFromFlags(c)...)
+	if err != nil {
+		return fmt.Errorf("building inventory: %w", err)
+	}
+
+	usages, err := metricsReader.PodMetrics(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("fetching pod metrics: %w", err)
+	}
+
+	risks := resources.FindThrottleRisks(resources.BuildDiff(containers, usages), margin)
+
+	w := c.OutOrStdout()
+	fmt.Fprintln(w, output.RenderThrottleTable(risks, top))
+
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/marcgeld/cobrak/pkg/config"
 	"github.com/marcgeld/cobrak/pkg/k8s"
 	"github.com/marcgeld/cobrak/pkg/output"
 	"github.com/marcgeld/cobrak/pkg/resources"
@@ -22,6 +21,9 @@ Requires metrics-server to be installed in the cluster.`,
 	}
 
 	addResourceFlags(c)
+	addMaxAgeFlag(c)
+	c.Flags().String("sort-by", resources.UsageSortName, "row order: name, cpu or mem (highest usage first)")
+	c.Flags().Bool("show-node", false, "add a NODE column with each pod's node (also lists pods)")
 
 	return c
 }
@@ -32,26 +34,37 @@ func runResourcesUsage(c *cobra.Command, _ []string) error {
 	nocolor, _ := c.Root().PersistentFlags().GetBool("nocolor")
 	namespace, _ := c.Flags().GetString("namespace")
 	top, _ := c.Flags().GetInt("top")
+	sortBy, _ := c.Flags().GetString("sort-by")
+	outputFlag, _ := c.Flags().GetString("output")
+	showNode, _ := c.Flags().GetBool("show-node")
 
-	// Load configuration and set color
-	configFlag, _ := c.Root().PersistentFlags().GetString("config")
-	configPath, err := config.ResolveConfigPath(configFlag)
+	format, err := output.ParseOutputFormat(outputFlag)
 	if err != nil {
-		return fmt.Errorf("resolving config path: %w", err)
+		return err
+	}
+	if err := resources.ValidateUsageSort(sortBy); err != nil {
+		return fmt.Errorf("invalid --sort-by: %w", err)
 	}
-	settings, err := config.LoadSettingsAt(configPath)
+
+	// Load configuration and set color
+	settings, err := loadSettings(c)
 	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return err
 	}
 	colorEnabled := settings.Color && !nocolor
 	output.SetGlobalColorEnabled(colorEnabled)
 
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
 	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
 	if err != nil {
 		return fmt.Errorf("building rest config: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	ctx, cancel := context.WithTimeout(commandContext(c), 20*time.Second)
 	defer cancel()
 
 	metricsReader, err := resources.NewMetricsReaderFromConfig(cfg)
@@ -64,16 +77,51 @@ func runResourcesUsage(c *cobra.Command, _ []string) error {
 		return fmt.Errorf("checking metrics availability: %w", err)
 	}
 	if !available {
-		return fmt.Errorf("metrics API (metrics.k8s.io) not available; install metrics-server")
+		return errMetricsUnavailable
 	}
 
 	usages, err := metricsReader.PodMetrics(ctx, namespace)
 	if err != nil {
 		return fmt.Errorf("fetching pod metrics: %w", err)
 	}
+	if err := checkMetricsAgeFromFlags(c, usages); err != nil {
+		return err
+	}
+
+	// Metrics carry no placement, so join the pods' Spec.NodeName
+	if showNode {
+		client, err := k8s.NewClientFromConfig(cfg)
+		if err != nil {
+			return fmt.Errorf("building k8s client: %w", err)
+		}
+		if err := resources.AttachUsageNodes(ctx, client, namespace, usages); err != nil {
+			return fmt.Errorf("resolving pod nodes: %w", err)
+		}
+	}
+
+	return writeUsage(c, usages, format, sortBy, top, output.UsageTableOptions{ShowNode: showNode})
+}
+
+// writeUsage sorts usages and writes the first top rows as a table, or as a
+// UsageReport with totals over those rows for structured formats
+func writeUsage(c *cobra.Command, usages []resources.ContainerUsage, format output.OutputFormat, sortBy string, top int, opts output.UsageTableOptions) error {
+	if err := resources.SortContainerUsages(usages, sortBy); err != nil {
+		return fmt.Errorf("invalid --sort-by: %w", err)
+	}
 
 	w := c.OutOrStdout()
-	fmt.Fprintln(w, output.RenderUsageTable(usages, top))
+	if format == output.FormatText {
+		fmt.Fprintln(w, output.RenderUsageTableWithOptions(usages, top, opts))
+		return nil
+	}
 
+	if top > 0 && len(usages) > top {
+		usages = usages[:top]
+	}
+	outputStr, err := output.RenderOutput(output.BuildUsageReport(usages), format)
+	if err != nil {
+		return fmt.Errorf("rendering output: %w", err)
+	}
+	fmt.Fprintln(w, outputStr)
 	return nil
 }
@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/marcgeld/cobrak/pkg/logging"
+	"github.com/marcgeld/cobrak/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -30,18 +35,59 @@ func NewRootCmd() *cobra.Command {
 		Use:   "cobrak",
 		Short: "cobrak - analytical CLI for Kubernetes cluster state",
 		Long:  "cobrak is a modular, lightweight, fast analytical tool for inspecting cluster state from the command line.",
+		PersistentPreRun: func(c *cobra.Command, _ []string) {
+			output.SetGlobalTableOptions(tableOptionsFromFlags(c))
+
+			// Diagnostics go to stderr so they never mix with data on stdout
+			verbose, _ := c.Root().PersistentFlags().GetCount("verbose")
+			c.SetContext(logging.WithLogger(commandContext(c), logging.New(c.ErrOrStderr(), verbose)))
+		},
 	}
 
 	root.PersistentFlags().String("kubeconfig", "", "path to kubeconfig file (default: KUBECONFIG env or ~/.kube/config)")
 	root.PersistentFlags().String("context", "", "kubeconfig context to use")
 	root.PersistentFlags().Bool("nocolor", false, "disable colored output")
+	root.PersistentFlags().CountP("verbose", "v", "log skipped objects and fallbacks to stderr (-vv for more detail)")
 	root.PersistentFlags().String("config", "", "config file relative to ~/.cobrak/ (default: settings.toml, overrides COBRAK_CONFIG env)")
+	root.PersistentFlags().Int("max-width", 0, "truncate pod/container names in tables to this many characters (0: no limit)")
+	root.PersistentFlags().Int("table-min-width", 0, "minimum table column width")
+	root.PersistentFlags().Int("table-padding", output.DefaultTableOptions().Padding, "spaces between table columns")
+	root.PersistentFlags().String("profile", "", "named config profile from ~/.cobrak/profiles/<name>.toml (overrides COBRAK_PROFILE env)")
 
 	root.AddCommand(newResourcesCmd())
 	root.AddCommand(newCapacityCmd(&kubeconfig))
 	root.AddCommand(newNodeInfoCmd())
+	root.AddCommand(newPressureCmd())
+	root.AddCommand(newServeCmd())
 	root.AddCommand(newConfigCmd())
 	root.AddCommand(newVersionCmd())
 
 	return root
 }
+
+// tableOptionsFromFlags builds the text table layout from the root persistent flags
+func tableOptionsFromFlags(c *cobra.Command) output.TableOptions {
+	maxWidth, _ := c.Root().PersistentFlags().GetInt("max-width")
+	minWidth, _ := c.Root().PersistentFlags().GetInt("table-min-width")
+	padding, _ := c.Root().PersistentFlags().GetInt("table-padding")
+	return output.TableOptions{MaxWidth: maxWidth, MinWidth: minWidth, Padding: padding}
+}
+
+// commandContext returns the command's context, or a background context when
+// the command was not started through Execute (e.g. in tests)
+func commandContext(c *cobra.Command) context.Context {
+	if ctx := c.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// printNoData reports that a command found nothing in scope. Structured formats
+// keep stdout parseable, so the note goes to stderr for them.
+func printNoData(c *cobra.Command, format output.OutputFormat, what string) {
+	w := c.OutOrStdout()
+	if format != output.FormatText {
+		w = c.ErrOrStderr()
+	}
+	fmt.Fprintf(w, "No %s found in scope.\n", what)
+}
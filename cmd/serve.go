@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"github.com/marcgeld/cobrak/pkg/k8s"
+	"github.com/marcgeld/cobrak/pkg/output"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+func newServeCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve cluster pressure over HTTP as JSON and Prometheus metrics",
+		Long: `Recalculates cluster pressure on an interval against the configured context and
+serves the latest result:
+  /pressure  the pressure summary as JSON (same shape as pressure --output=json)
+  /metrics   cluster, node and namespace utilization as Prometheus gauges, plus
+             cobrak_last_refresh_success_timestamp_seconds to alert on stale data
+Both endpoints answer 503 until the first calculation succeeds. A failed refresh
+keeps serving the previous result and is reported on stderr.`,
+		RunE: runServe,
+	}
+
+	c.Flags().String("addr", ":8080", "address to listen on")
+	c.Flags().Duration("interval", 30*time.Second, "how often to recalculate pressure")
+	c.Flags().String("namespace", "", "only count pods in this namespace (default: all namespaces)")
+
+	return c
+}
+
+func runServe(c *cobra.Command, _ []string) error {
+	kubeconfig, _ := c.Root().PersistentFlags().GetString("kubeconfig")
+	kubeCtx, _ := c.Root().PersistentFlags().GetString("context")
+	addr, _ := c.Flags().GetString("addr")
+	interval, _ := c.Flags().GetDuration("interval")
+	namespace, _ := c.Flags().GetString("namespace")
+	if interval <= 0 {
+		return fmt.Errorf("invalid --interval %v: must be positive", interval)
+	}
+
+	settings, err := loadSettings(c)
+	if err != nil {
+		return err
+	}
+
+	namespace, err = scopedNamespace(c, settings, namespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := k8s.NewRestConfig(kubeconfig, kubeCtx)
+	if err != nil {
+		return fmt.Errorf("building rest config: %w", err)
+	}
+
+	client, err := k8s.NewClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("building k8s client: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(commandContext(c), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := newPressureServer(client, namespace, capacity.PressureOptions{Thresholds: thresholdsFromSettings(settings)}, c.ErrOrStderr())
+	go srv.run(ctx, interval)
+
+	httpServer := &http.Server{Addr: addr, Handler: srv.handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(c.ErrOrStderr(), "serving pressure on %s (/pressure, /metrics), refreshing every %s\n", addr, interval)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving: %w", err)
+	}
+	return nil
+}
+
+// pressureServer keeps the latest pressure calculation and serves it over HTTP
+type pressureServer struct {
+	client    kubernetes.Interface
+	namespace string
+	opts      capacity.PressureOptions
+	// errOut receives refresh failures
+	errOut io.Writer
+
+	mu          sync.RWMutex
+	latest      *capacity.ClusterPressure
+	lastSuccess time.Time
+}
+
+func newPressureServer(client kubernetes.Interface, namespace string, opts capacity.PressureOptions, errOut io.Writer) *pressureServer {
+	return &pressureServer{client: client, namespace: namespace, opts: opts, errOut: errOut}
+}
+
+// refresh recalculates pressure; on failure the previous result is kept
+func (s *pressureServer) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	pressure, err := capacity.CalculatePressureWithOptions(ctx, s.client, s.namespace, s.opts)
+	if err != nil {
+		return fmt.Errorf("calculating pressure: %w", err)
+	}
+
+	s.mu.Lock()
+	s.latest = pressure
+	s.lastSuccess = time.Now()
+	s.mu.Unlock()
+	return nil
+}
+
+// run refreshes immediately and then every interval until ctx is done.
+// Failures are always written to errOut: a lasting one (RBAC, expired
+// credentials) would otherwise leave the server stale without a trace.
+func (s *pressureServer) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.refresh(ctx); err != nil {
+			fmt.Fprintf(s.errOut, "warning: refresh failed, serving previous result: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// handler routes /pressure and /metrics
+func (s *pressureServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pressure", s.servePressure)
+	mux.HandleFunc("/metrics", s.serveMetrics)
+	return mux
+}
+
+// snapshot returns the latest pressure and when it was calculated, or nil
+// before the first successful refresh
+func (s *pressureServer) snapshot() (*capacity.ClusterPressure, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.latest, s.lastSuccess
+}
+
+func (s *pressureServer) servePressure(w http.ResponseWriter, _ *http.Request) {
+	pressure, _ := s.snapshot()
+	if pressure == nil {
+		http.Error(w, "pressure not calculated yet", http.StatusServiceUnavailable)
+		return
+	}
+	body, err := output.RenderOutput(buildPressureSummary(pressure), output.FormatJSON)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, body)
+}
+
+func (s *pressureServer) serveMetrics(w http.ResponseWriter, _ *http.Request) {
+	pressure, lastSuccess := s.snapshot()
+	if pressure == nil {
+		http.Error(w, "pressure not calculated yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprint(w, output.RenderPrometheusWithOptions(pressure, output.PrometheusOptions{LastRefreshSuccess: lastSuccess}))
+}
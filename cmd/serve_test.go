@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"github.com/marcgeld/cobrak/pkg/output"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPressureServer_Endpoints(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+		Spec: corev1.PodSpec{
+			NodeName: "worker-1",
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	srv := newPressureServer(fake.NewSimpleClientset(node, pod), "", capacity.PressureOptions{
+		Thresholds: capacity.DefaultPressureThresholds(),
+	}, io.Discard)
+	handler := srv.handler()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	if rec := get("/metrics"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 before the first refresh, got %d", rec.Code)
+	}
+
+	if err := srv.refresh(context.Background()); err != nil {
+		t.Fatalf("refresh failed: %v", err)
+	}
+
+	rec := get("/metrics")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 from /metrics, got %d: %s", rec.Code, rec.Body.String())
+	}
+	for _, line := range []string{
+		"# TYPE cobrak_cluster_cpu_utilization_percent gauge",
+		"cobrak_cluster_cpu_utilization_percent 50",
+		`cobrak_node_memory_utilization_percent{node="worker-1"} 25`,
+		`cobrak_namespace_cpu_requests_percent{namespace="shop"} 50`,
+		"# TYPE cobrak_last_refresh_success_timestamp_seconds gauge",
+	} {
+		if !strings.Contains(rec.Body.String(), line+"\n") {
+			t.Errorf("expected line %q in /metrics, got:\n%s", line, rec.Body.String())
+		}
+	}
+
+	rec = get("/pressure")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 from /pressure, got %d", rec.Code)
+	}
+	var summary output.PressureSummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("invalid JSON from /pressure: %v", err)
+	}
+	if summary.CPUUtilization != 50 || summary.ClusterPressure != string(capacity.PressureLow) {
+		t.Errorf("expected 50%% CPU at LOW pressure, got %+v", summary)
+	}
+}
+
+func TestPressureServer_ReportsRefreshFailures(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("nodes is forbidden")
+	})
+
+	var stderr bytes.Buffer
+	srv := newPressureServer(client, "", capacity.PressureOptions{Thresholds: capacity.DefaultPressureThresholds()}, &stderr)
+
+	// A cancelled context makes run stop after its first refresh
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	srv.run(ctx, time.Hour)
+
+	if !strings.Contains(stderr.String(), "refresh failed") || !strings.Contains(stderr.String(), "forbidden") {
+		t.Errorf("expected the refresh failure on stderr without -v, got: %q", stderr.String())
+	}
+}
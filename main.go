@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/marcgeld/cobrak/cmd"
+	"github.com/marcgeld/cobrak/pkg/k8s"
 )
 
 // Version variables set at build time
@@ -21,6 +22,9 @@ func main() {
 	root := cmd.NewRootCmd()
 	if err := root.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		if hint := k8s.ExplainError(err); hint != "" {
+			fmt.Fprintln(os.Stderr, hint)
+		}
 		os.Exit(1)
 	}
 }
@@ -2,12 +2,15 @@ package capacity
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 // TestCalculateClusterPressure_Integration tests full cluster pressure calculation
@@ -537,3 +540,408 @@ func TestNamespaceFilter_Pressure(t *testing.T) {
 		t.Error("production namespace not found after filtering")
 	}
 }
+
+// TestPressureOrdering_Deterministic tests that node and namespace pressures are sorted by name
+func TestPressureOrdering_Deterministic(t *testing.T) {
+	var objs []runtime.Object
+	for _, name := range []string{"node-c", "node-a", "node-b"} {
+		objs = append(objs, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+		})
+	}
+	namespaces := []string{"zeta", "alpha", "mike", "bravo", "yankee", "charlie"}
+	for _, ns := range namespaces {
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: ns},
+			Spec: corev1.PodSpec{
+				NodeName:   "node-a",
+				Containers: []corev1.Container{{Name: "app"}},
+			},
+		})
+	}
+
+	client := fake.NewSimpleClientset(objs...)
+	ctx := context.Background()
+
+	wantNamespaces := []string{"alpha", "bravo", "charlie", "mike", "yankee", "zeta"}
+	wantNodes := []string{"node-a", "node-b", "node-c"}
+
+	for run := 0; run < 10; run++ {
+		pressure, err := CalculatePressure(ctx, client, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(pressure.NamespacePressures) != len(wantNamespaces) {
+			t.Fatalf("expected %d namespace pressures, got %d", len(wantNamespaces), len(pressure.NamespacePressures))
+		}
+		for i, ns := range wantNamespaces {
+			if pressure.NamespacePressures[i].Namespace != ns {
+				t.Fatalf("run %d: namespace position %d: expected %s, got %s", run, i, ns, pressure.NamespacePressures[i].Namespace)
+			}
+		}
+		for i, node := range wantNodes {
+			if pressure.NodePressures[i].NodeName != node {
+				t.Fatalf("run %d: node position %d: expected %s, got %s", run, i, node, pressure.NodePressures[i].NodeName)
+			}
+		}
+	}
+}
+
+// TestNodeReservation_HighRatio tests that heavily reserved nodes are flagged
+func TestNodeReservation_HighRatio(t *testing.T) {
+	reserved := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "reserved"},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("7Gi"),
+			},
+		},
+	}
+	lean := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "lean"},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3900m"),
+				corev1.ResourceMemory: resource.MustParse("7Gi"),
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(reserved, lean)
+	pressure, err := CalculatePressure(context.Background(), client, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, np := range pressure.NodePressures {
+		switch np.NodeName {
+		case "reserved":
+			if np.CPUReservationRatio < 0.49 || np.CPUReservationRatio > 0.51 {
+				t.Errorf("expected CPU reservation ratio ~0.5, got %f", np.CPUReservationRatio)
+			}
+			if !np.HighReservation() {
+				t.Error("expected node 'reserved' to be flagged for high reservation")
+			}
+		case "lean":
+			if np.HighReservation() {
+				t.Errorf("expected node 'lean' not to be flagged, got CPU %f / mem %f", np.CPUReservationRatio, np.MemReservationRatio)
+			}
+		}
+	}
+}
+
+func TestPressure_SkipsTerminatedPodsByDefault(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+	job := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-done", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Name: "job",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+
+	client := fake.NewSimpleClientset(node, job)
+	ctx := context.Background()
+
+	pressure, err := CalculatePressure(ctx, client, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := pressure.NodePressures[0].CPUUtilization; got != 0 {
+		t.Errorf("expected succeeded pod to be ignored, got CPU utilization %.1f%%", got)
+	}
+
+	pressure, err = CalculatePressureWithOptions(ctx, client, "", PressureOptions{
+		Thresholds:        DefaultPressureThresholds(),
+		IncludeTerminated: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := pressure.NodePressures[0].CPUUtilization; got != 50 {
+		t.Errorf("expected 50%% CPU utilization with terminated pods included, got %.1f%%", got)
+	}
+}
+
+func TestAnalyzeSummary_HugePages(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "hpc-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+				"hugepages-2Mi":       resource.MustParse("1Gi"),
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "dpdk", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "hpc-1",
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{"hugepages-2Mi": resource.MustParse("256Mi")},
+						Limits:   corev1.ResourceList{"hugepages-2Mi": resource.MustParse("256Mi")},
+					},
+				},
+			},
+		},
+	}
+
+	summary, err := AnalyzeSummary(context.Background(), fake.NewSimpleClientset(node, pod), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sizes := summary.HugePageSizes()
+	if len(sizes) != 1 || sizes[0] != "hugepages-2Mi" {
+		t.Fatalf("expected [hugepages-2Mi], got %v", sizes)
+	}
+	allocatable := summary.HugePagesAllocatable["hugepages-2Mi"]
+	if allocatable.Cmp(resource.MustParse("1Gi")) != 0 {
+		t.Errorf("expected 1Gi allocatable, got %s", allocatable.String())
+	}
+	requests := summary.HugePagesRequests["hugepages-2Mi"]
+	if requests.Cmp(resource.MustParse("256Mi")) != 0 {
+		t.Errorf("expected 256Mi requested, got %s", requests.String())
+	}
+	if !summary.TotalMemRequests.IsZero() {
+		t.Errorf("expected hugepages not to count as memory, got %s", summary.TotalMemRequests.String())
+	}
+}
+
+func TestAnalyzeSummary_SkipsTerminatedPods(t *testing.T) {
+	requests := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Resources: requests}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	completed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup-28471", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "job", Resources: requests}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	client := fake.NewSimpleClientset(running, completed)
+	ctx := context.Background()
+
+	summary, err := AnalyzeSummary(ctx, client, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.TotalCPURequests.Cmp(resource.MustParse("500m")) != 0 {
+		t.Errorf("expected the succeeded pod to be skipped (500m), got %s", summary.TotalCPURequests.String())
+	}
+
+	summary, err = AnalyzeSummaryWithOptions(ctx, client, "", SummaryOptions{IncludeTerminated: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.TotalMemRequests.Cmp(resource.MustParse("1Gi")) != 0 {
+		t.Errorf("expected the succeeded pod with IncludeTerminated (1Gi), got %s", summary.TotalMemRequests.String())
+	}
+}
+
+func TestAnalyzeSummary_FieldSelector(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var selector string
+	client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		selector = action.(k8stesting.ListAction).GetListRestrictions().Fields.String()
+		return false, nil, nil
+	})
+
+	_, err := AnalyzeSummaryWithOptions(context.Background(), client, "", SummaryOptions{FieldSelector: "spec.nodeName=worker-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if selector != "spec.nodeName=worker-1" {
+		t.Errorf("expected the pod list to use the field selector, got %q", selector)
+	}
+}
+
+// TestPartialResults_PodListDenied checks that node capacity survives a failed pod list
+func TestPartialResults_PodListDenied(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(node)
+	client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("pods is forbidden")
+	})
+	ctx := context.Background()
+
+	if _, err := AnalyzeSummary(ctx, client, ""); err == nil {
+		t.Fatal("expected AnalyzeSummary to fail without partial mode")
+	}
+
+	summary, err := AnalyzeSummaryWithOptions(ctx, client, "", SummaryOptions{AllowPartial: true})
+	if err != nil {
+		t.Fatalf("unexpected error in partial mode: %v", err)
+	}
+	if summary.TotalCPUAllocatable.String() != "4" {
+		t.Errorf("expected node allocatable CPU 4, got %s", summary.TotalCPUAllocatable.String())
+	}
+	if len(summary.Warnings) != 1 {
+		t.Errorf("expected one warning, got %v", summary.Warnings)
+	}
+
+	if _, err := CalculatePressureWithOptions(ctx, client, "", PressureOptions{Thresholds: DefaultPressureThresholds()}); err == nil {
+		t.Fatal("expected pressure to fail without partial mode")
+	}
+
+	pressure, err := CalculatePressureWithOptions(ctx, client, "", PressureOptions{
+		Thresholds:   DefaultPressureThresholds(),
+		AllowPartial: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error in partial mode: %v", err)
+	}
+	if len(pressure.NodePressures) != 1 || pressure.NodePressures[0].NodeName != "worker-1" {
+		t.Errorf("expected worker-1 pressure, got %+v", pressure.NodePressures)
+	}
+	if len(pressure.Warnings) != 1 {
+		t.Errorf("expected one warning, got %v", pressure.Warnings)
+	}
+}
+
+func TestClusterPressure_PerResourceOverall(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10"),
+				corev1.ResourceMemory: resource.MustParse("10Gi"),
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "cruncher", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("9500m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			}},
+		},
+	}
+
+	pressure, err := CalculatePressure(context.Background(), fake.NewSimpleClientset(node, pod), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pressure.OverallCPU != PressureHigh {
+		t.Errorf("expected CPU pressure HIGH, got %s", pressure.OverallCPU)
+	}
+	if pressure.OverallMem != PressureLow {
+		t.Errorf("expected memory pressure LOW, got %s", pressure.OverallMem)
+	}
+	if pressure.Overall != PressureHigh {
+		t.Errorf("expected overall pressure HIGH, got %s", pressure.Overall)
+	}
+}
+
+func TestAnalyzeWithOptions_NodeRequestPercent(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	scheduled := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("6Gi"),
+					},
+				},
+			}},
+		},
+	}
+	elsewhere := scheduled.DeepCopy()
+	elsewhere.Name = "other"
+	elsewhere.Spec.NodeName = "node-2"
+
+	client := fake.NewSimpleClientset(node, scheduled, elsewhere)
+	nodes, err := AnalyzeWithOptions(context.Background(), client, AnalyzeOptions{IncludeRequests: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nodes) != 1 || !nodes[0].HasRequests {
+		t.Fatalf("expected 1 node with requests, got %+v", nodes)
+	}
+	if got := nodes[0].MemRequests.String(); got != "6Gi" {
+		t.Errorf("expected 6Gi memory requested, got %s", got)
+	}
+	if got := nodes[0].MemRequestPercent(); got != 75 {
+		t.Errorf("expected 75%% memory requested, got %.1f%%", got)
+	}
+	if got := nodes[0].CPURequestPercent(); got != 25 {
+		t.Errorf("expected 25%% CPU requested, got %.1f%%", got)
+	}
+
+	nodes, err = Analyze(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nodes[0].HasRequests {
+		t.Error("expected Analyze to skip pod requests")
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/marcgeld/cobrak/pkg/resources"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +20,37 @@ type NodeCapacity struct {
 	CPUCapacity    resource.Quantity
 	MemAllocatable resource.Quantity
 	MemCapacity    resource.Quantity
+
+	// Requests of the active pods scheduled to the node; only filled when
+	// HasRequests is set (AnalyzeOptions.IncludeRequests)
+	CPURequests resource.Quantity
+	MemRequests resource.Quantity
+	HasRequests bool
+
+	// Labels of the node, e.g. ZoneLabel and DefaultPoolLabel
+	Labels map[string]string
+}
+
+// CPURequestPercent returns CPU requests as a percentage of allocatable (0 when allocatable is zero)
+func (n NodeCapacity) CPURequestPercent() float64 {
+	if n.CPUAllocatable.IsZero() {
+		return 0
+	}
+	return float64(n.CPURequests.MilliValue()) / float64(n.CPUAllocatable.MilliValue()) * 100
+}
+
+// MemRequestPercent returns memory requests as a percentage of allocatable (0 when allocatable is zero)
+func (n NodeCapacity) MemRequestPercent() float64 {
+	if n.MemAllocatable.IsZero() {
+		return 0
+	}
+	return float64(n.MemRequests.Value()) / float64(n.MemAllocatable.Value()) * 100
+}
+
+// AnalyzeOptions controls AnalyzeWithOptions
+type AnalyzeOptions struct {
+	// IncludeRequests lists pods and sums the requests scheduled to each node
+	IncludeRequests bool
 }
 
 // ClusterCapacitySummary holds aggregated capacity and request data for the entire cluster.
@@ -28,20 +61,79 @@ type ClusterCapacitySummary struct {
 	TotalMemCapacity    resource.Quantity
 	TotalMemAllocatable resource.Quantity
 
-	// Requested/Limited resources from pods
+	// Requested/Limited resources from pods. A pod counts the sum of its
+	// containers, or its largest init container when that is bigger.
 	TotalCPURequests resource.Quantity
 	TotalCPULimits   resource.Quantity
 	TotalMemRequests resource.Quantity
 	TotalMemLimits   resource.Quantity
+
+	// Requests/limits of active pods only, even with
+	// SummaryOptions.IncludeTerminated; what nodes actually have to hold
+	ActiveCPURequests resource.Quantity
+	ActiveCPULimits   resource.Quantity
+	ActiveMemRequests resource.Quantity
+	ActiveMemLimits   resource.Quantity
+
+	// Hugepages per page size (e.g. hugepages-2Mi); requests always equal limits
+	HugePagesAllocatable map[corev1.ResourceName]resource.Quantity
+	HugePagesRequests    map[corev1.ResourceName]resource.Quantity
+
+	// Warnings lists data that could not be gathered in partial mode
+	Warnings []string
+}
+
+// SummaryOptions controls AnalyzeSummaryWithOptions
+type SummaryOptions struct {
+	// AllowPartial returns node capacity with a warning when pods cannot be
+	// listed, instead of failing the whole summary
+	AllowPartial bool
+	// IncludeTerminated also sums pods in a terminal phase (Succeeded/Failed)
+	IncludeTerminated bool
+	// FieldSelector filters the pod list server-side (e.g. "spec.nodeName=worker-1").
+	// Node capacity is still summed over every node.
+	FieldSelector string
+}
+
+// HugePageSizes returns the hugepage resource names seen on nodes or pods, sorted.
+func (s *ClusterCapacitySummary) HugePageSizes() []corev1.ResourceName {
+	seen := make(map[corev1.ResourceName]bool)
+	for name := range s.HugePagesAllocatable {
+		seen[name] = true
+	}
+	for name := range s.HugePagesRequests {
+		seen[name] = true
+	}
+
+	sizes := make([]corev1.ResourceName, 0, len(seen))
+	for name := range seen {
+		sizes = append(sizes, name)
+	}
+	sort.Slice(sizes, func(i, j int) bool { return sizes[i] < sizes[j] })
+	return sizes
 }
 
 // Analyze lists all nodes and returns their capacity data sorted by node name.
 func Analyze(ctx context.Context, client kubernetes.Interface) ([]NodeCapacity, error) {
+	return AnalyzeWithOptions(ctx, client, AnalyzeOptions{})
+}
+
+// AnalyzeWithOptions is Analyze with the given options
+func AnalyzeWithOptions(ctx context.Context, client kubernetes.Interface, opts AnalyzeOptions) ([]NodeCapacity, error) {
 	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("listing nodes: %w", err)
 	}
 
+	var pods []corev1.Pod
+	if opts.IncludeRequests {
+		podList, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("listing pods: %w", err)
+		}
+		pods = activePods(podList.Items)
+	}
+
 	result := make([]NodeCapacity, 0, len(nodes.Items))
 	for _, node := range nodes.Items {
 		nc := NodeCapacity{
@@ -50,6 +142,18 @@ func Analyze(ctx context.Context, client kubernetes.Interface) ([]NodeCapacity,
 			CPUCapacity:    node.Status.Capacity.Cpu().DeepCopy(),
 			MemAllocatable: node.Status.Allocatable.Memory().DeepCopy(),
 			MemCapacity:    node.Status.Capacity.Memory().DeepCopy(),
+			Labels:         node.Labels,
+		}
+		if opts.IncludeRequests {
+			var cpu, mem int64
+			for i := range pods {
+				if pods[i].Spec.NodeName == node.Name {
+					addPodResourcesForNode(&cpu, &mem, &pods[i])
+				}
+			}
+			nc.CPURequests = *resource.NewMilliQuantity(cpu, resource.DecimalSI)
+			nc.MemRequests = *resource.NewQuantity(mem, resource.BinarySI)
+			nc.HasRequests = true
 		}
 		result = append(result, nc)
 	}
@@ -61,8 +165,14 @@ func Analyze(ctx context.Context, client kubernetes.Interface) ([]NodeCapacity,
 	return result, nil
 }
 
-// AnalyzeSummary aggregates all node capacity and pod requests/limits into a cluster summary.
+// AnalyzeSummary aggregates all node capacity and the requests/limits of active
+// pods into a cluster summary.
 func AnalyzeSummary(ctx context.Context, client kubernetes.Interface, namespace string) (*ClusterCapacitySummary, error) {
+	return AnalyzeSummaryWithOptions(ctx, client, namespace, SummaryOptions{})
+}
+
+// AnalyzeSummaryWithOptions is AnalyzeSummary with the given options
+func AnalyzeSummaryWithOptions(ctx context.Context, client kubernetes.Interface, namespace string, opts SummaryOptions) (*ClusterCapacitySummary, error) {
 	summary := newEmptySummary()
 
 	// Get and sum node capacities
@@ -73,11 +183,16 @@ func AnalyzeSummary(ctx context.Context, client kubernetes.Interface, namespace
 	sumNodeCapacities(summary, nodes.Items)
 
 	// Get and sum pod requests/limits
-	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: opts.FieldSelector})
 	if err != nil {
-		return nil, fmt.Errorf("listing pods: %w", err)
+		warnings, err := partialResult(&podListError{err: err}, opts.AllowPartial)
+		if err != nil {
+			return nil, err
+		}
+		summary.Warnings = warnings
+		return summary, nil
 	}
-	sumPodResources(summary, pods.Items)
+	sumPodResources(summary, pods.Items, opts.IncludeTerminated)
 
 	return summary, nil
 }
@@ -93,6 +208,13 @@ func newEmptySummary() *ClusterCapacitySummary {
 		TotalCPULimits:      *resource.NewQuantity(0, resource.DecimalSI),
 		TotalMemRequests:    *resource.NewQuantity(0, resource.BinarySI),
 		TotalMemLimits:      *resource.NewQuantity(0, resource.BinarySI),
+		ActiveCPURequests:   *resource.NewQuantity(0, resource.DecimalSI),
+		ActiveCPULimits:     *resource.NewQuantity(0, resource.DecimalSI),
+		ActiveMemRequests:   *resource.NewQuantity(0, resource.BinarySI),
+		ActiveMemLimits:     *resource.NewQuantity(0, resource.BinarySI),
+
+		HugePagesAllocatable: make(map[corev1.ResourceName]resource.Quantity),
+		HugePagesRequests:    make(map[corev1.ResourceName]resource.Quantity),
 	}
 }
 
@@ -103,35 +225,100 @@ func sumNodeCapacities(summary *ClusterCapacitySummary, nodes []corev1.Node) {
 		summary.TotalCPUAllocatable.Add(*node.Status.Allocatable.Cpu())
 		summary.TotalMemCapacity.Add(*node.Status.Capacity.Memory())
 		summary.TotalMemAllocatable.Add(*node.Status.Allocatable.Memory())
+		addHugePages(summary.HugePagesAllocatable, node.Status.Allocatable)
 	}
 }
 
-// sumPodResources aggregates requests and limits from all containers in all pods.
-func sumPodResources(summary *ClusterCapacitySummary, pods []corev1.Pod) {
-	for _, pod := range pods {
-		sumContainerResources(summary, pod.Spec.Containers)
-		sumContainerResources(summary, pod.Spec.InitContainers)
+// sumPodResources aggregates the requests and limits of pods into the
+// summary. Terminated pods only count towards the Total* values, and only
+// when includeTerminated is set.
+func sumPodResources(summary *ClusterCapacitySummary, pods []corev1.Pod, includeTerminated bool) {
+	for i := range pods {
+		pod := &pods[i]
+		terminated := resources.IsTerminated(pod)
+		if terminated && !includeTerminated {
+			continue
+		}
+
+		requests, limits := podResources(pod)
+		cpuReq := *resource.NewMilliQuantity(requests.CPU, resource.DecimalSI)
+		memReq := *resource.NewQuantity(requests.Memory, resource.BinarySI)
+		cpuLim := *resource.NewMilliQuantity(limits.CPU, resource.DecimalSI)
+		memLim := *resource.NewQuantity(limits.Memory, resource.BinarySI)
+
+		summary.TotalCPURequests.Add(cpuReq)
+		summary.TotalMemRequests.Add(memReq)
+		summary.TotalCPULimits.Add(cpuLim)
+		summary.TotalMemLimits.Add(memLim)
+		if !terminated {
+			summary.ActiveCPURequests.Add(cpuReq)
+			summary.ActiveMemRequests.Add(memReq)
+			summary.ActiveCPULimits.Add(cpuLim)
+			summary.ActiveMemLimits.Add(memLim)
+		}
+
+		for j := range pod.Spec.Containers {
+			addHugePages(summary.HugePagesRequests, pod.Spec.Containers[j].Resources.Requests)
+		}
+		for j := range pod.Spec.InitContainers {
+			addHugePages(summary.HugePagesRequests, pod.Spec.InitContainers[j].Resources.Requests)
+		}
 	}
 }
 
-// sumContainerResources aggregates requests and limits from a slice of containers.
-func sumContainerResources(summary *ClusterCapacitySummary, containers []corev1.Container) {
-	for _, c := range containers {
-		if c.Resources.Requests != nil {
-			if cpuReq, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
-				summary.TotalCPURequests.Add(cpuReq)
-			}
-			if memReq, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
-				summary.TotalMemRequests.Add(memReq)
-			}
-		}
-		if c.Resources.Limits != nil {
-			if cpuLim, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
-				summary.TotalCPULimits.Add(cpuLim)
-			}
-			if memLim, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
-				summary.TotalMemLimits.Add(memLim)
-			}
+// podResources returns the CPU (millicores) and memory (bytes) requests and
+// limits a pod reserves: per resource, the sum of its containers or its
+// largest init container when that is bigger, as the scheduler accounts for it
+func podResources(pod *corev1.Pod) (requests, limits AllocatableResources) {
+	var initRequests, initLimits AllocatableResources
+	for i := range pod.Spec.Containers {
+		req, lim := containerResources(&pod.Spec.Containers[i])
+		requests.CPU += req.CPU
+		requests.Memory += req.Memory
+		limits.CPU += lim.CPU
+		limits.Memory += lim.Memory
+	}
+	for i := range pod.Spec.InitContainers {
+		req, lim := containerResources(&pod.Spec.InitContainers[i])
+		initRequests.CPU = max(initRequests.CPU, req.CPU)
+		initRequests.Memory = max(initRequests.Memory, req.Memory)
+		initLimits.CPU = max(initLimits.CPU, lim.CPU)
+		initLimits.Memory = max(initLimits.Memory, lim.Memory)
+	}
+
+	requests.CPU = max(requests.CPU, initRequests.CPU)
+	requests.Memory = max(requests.Memory, initRequests.Memory)
+	limits.CPU = max(limits.CPU, initLimits.CPU)
+	limits.Memory = max(limits.Memory, initLimits.Memory)
+	return requests, limits
+}
+
+// containerResources returns a container's CPU (millicores) and memory (bytes)
+// requests and limits, zero when unset
+func containerResources(c *corev1.Container) (requests, limits AllocatableResources) {
+	if q, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
+		requests.CPU = q.MilliValue()
+	}
+	if q, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+		requests.Memory = q.Value()
+	}
+	if q, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
+		limits.CPU = q.MilliValue()
+	}
+	if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+		limits.Memory = q.Value()
+	}
+	return requests, limits
+}
+
+// addHugePages adds every hugepages-* quantity in list to totals
+func addHugePages(totals map[corev1.ResourceName]resource.Quantity, list corev1.ResourceList) {
+	for name, q := range list {
+		if !strings.HasPrefix(string(name), corev1.ResourceHugePagesPrefix) {
+			continue
 		}
+		total := totals[name]
+		total.Add(q)
+		totals[name] = total
 	}
 }
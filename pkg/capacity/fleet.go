@@ -0,0 +1,45 @@
+package capacity
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// FleetMember is one cluster of a fleet, identified by its kubeconfig context
+type FleetMember struct {
+	Context string
+	Client  kubernetes.Interface
+}
+
+// ClusterFleetPressure is the overall pressure of one fleet member.
+// Err is set, and the other fields are zero, when the cluster could not be analyzed.
+type ClusterFleetPressure struct {
+	Context        string
+	Nodes          int
+	CPUUtilization float64
+	MemUtilization float64
+	Overall        PressureLevel
+	Err            error
+}
+
+// CalculateFleetPressure computes the cluster-wide pressure of each member in order.
+// A failing cluster is recorded in its entry rather than aborting the fleet.
+func CalculateFleetPressure(ctx context.Context, members []FleetMember, opts PressureOptions) []ClusterFleetPressure {
+	fleet := make([]ClusterFleetPressure, 0, len(members))
+	for _, m := range members {
+		entry := ClusterFleetPressure{Context: m.Context}
+		pressure, err := CalculatePressureWithOptions(ctx, m.Client, "", opts)
+		if err != nil {
+			entry.Err = err
+			entry.Overall = PressureUnknown
+		} else {
+			entry.Nodes = len(pressure.NodePressures)
+			entry.CPUUtilization = pressure.CPUUtilization
+			entry.MemUtilization = pressure.MemUtilization
+			entry.Overall = pressure.Overall
+		}
+		fleet = append(fleet, entry)
+	}
+	return fleet
+}
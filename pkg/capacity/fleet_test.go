@@ -0,0 +1,60 @@
+package capacity
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func fleetClusterClient(cpuRequest string) *fake.Clientset {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuRequest)},
+				},
+			}},
+		},
+	}
+	return fake.NewSimpleClientset(node, pod)
+}
+
+func TestCalculateFleetPressure_OneRowPerContext(t *testing.T) {
+	members := []FleetMember{
+		{Context: "prod", Client: fleetClusterClient("4")},
+		{Context: "staging", Client: fleetClusterClient("1")},
+	}
+
+	fleet := CalculateFleetPressure(context.Background(), members, PressureOptions{Thresholds: DefaultPressureThresholds()})
+	if len(fleet) != 2 {
+		t.Fatalf("expected 2 fleet rows, got %d", len(fleet))
+	}
+
+	if fleet[0].Context != "prod" || fleet[0].Overall != PressureSaturated {
+		t.Errorf("expected prod SATURATED, got %s %s", fleet[0].Context, fleet[0].Overall)
+	}
+	if fleet[1].Context != "staging" || fleet[1].Overall != PressureLow || fleet[1].CPUUtilization != 25 {
+		t.Errorf("expected staging LOW at 25%% CPU, got %+v", fleet[1])
+	}
+	for _, f := range fleet {
+		if f.Err != nil || f.Nodes != 1 {
+			t.Errorf("expected %s to report 1 node without error, got %+v", f.Context, f)
+		}
+	}
+}
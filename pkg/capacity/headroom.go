@@ -0,0 +1,192 @@
+package capacity
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NodeHeadroom holds unrequested allocatable resources, for a node or the whole cluster
+type NodeHeadroom struct {
+	Name   string
+	CPU    resource.Quantity
+	Memory resource.Quantity
+}
+
+// FitResult answers whether a pod of a given size can be scheduled
+type FitResult struct {
+	CPURequest resource.Quantity
+	MemRequest resource.Quantity
+
+	// Cluster is the aggregate headroom across all nodes
+	Cluster NodeHeadroom
+	// Largest is the schedulable node with the most free CPU, then memory
+	Largest NodeHeadroom
+	// FittingNodes lists the schedulable nodes with room for the pod
+	FittingNodes []string
+	// SchedulableNodes counts nodes not marked unschedulable (cordoned)
+	SchedulableNodes int
+	// DaemonSetReserve is the per-node DaemonSet footprint held back from
+	// headroom; zero unless FitOptions.SubtractDaemonSets is set
+	DaemonSetReserve NodeHeadroom
+}
+
+// FitOptions controls AnalyzeFitWithOptions and ComputeFitWithOptions
+type FitOptions struct {
+	// SubtractDaemonSets holds back, on every schedulable node, the DaemonSet
+	// requests of the node carrying the most. DaemonSet pods land on every new
+	// node too, so a node not yet running them all has less room than it shows.
+	SubtractDaemonSets bool
+}
+
+// AggregateFits reports whether the cluster as a whole has room for the pod
+func (r FitResult) AggregateFits() bool {
+	return CanSchedule(r.Cluster, r.CPURequest, r.MemRequest)
+}
+
+// Fits reports whether at least one node has room for the pod
+func (r FitResult) Fits() bool {
+	return len(r.FittingNodes) > 0
+}
+
+// Headroom returns allocatable minus requests from a cluster summary, floored at zero
+func Headroom(summary *ClusterCapacitySummary) (cpuFree, memFree resource.Quantity) {
+	cpuFree = summary.TotalCPUAllocatable.DeepCopy()
+	cpuFree.Sub(summary.TotalCPURequests)
+	memFree = summary.TotalMemAllocatable.DeepCopy()
+	memFree.Sub(summary.TotalMemRequests)
+
+	if cpuFree.Sign() < 0 {
+		cpuFree = *resource.NewQuantity(0, resource.DecimalSI)
+	}
+	if memFree.Sign() < 0 {
+		memFree = *resource.NewQuantity(0, resource.BinarySI)
+	}
+	return cpuFree, memFree
+}
+
+// CanSchedule reports whether headroom has room for the given requests
+func CanSchedule(headroom NodeHeadroom, cpuReq, memReq resource.Quantity) bool {
+	return headroom.CPU.Cmp(cpuReq) >= 0 && headroom.Memory.Cmp(memReq) >= 0
+}
+
+// AnalyzeFit checks whether a pod requesting cpuReq and memReq fits the cluster
+func AnalyzeFit(ctx context.Context, client kubernetes.Interface, cpuReq, memReq resource.Quantity) (*FitResult, error) {
+	return AnalyzeFitWithOptions(ctx, client, cpuReq, memReq, FitOptions{})
+}
+
+// AnalyzeFitWithOptions is AnalyzeFit with the given options
+func AnalyzeFitWithOptions(ctx context.Context, client kubernetes.Interface, cpuReq, memReq resource.Quantity, opts FitOptions) (*FitResult, error) {
+	nodes, pods, err := fetchClusterResources(ctx, client, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := ComputeFitWithOptions(nodes, activePods(pods), cpuReq, memReq, opts)
+	return &result, nil
+}
+
+// ComputeFit calculates per-node and aggregate headroom from already-fetched nodes
+// and pods and checks where a pod of the given size fits. Aggregate headroom can
+// exceed what any single node offers, so only per-node headroom decides schedulability.
+func ComputeFit(nodes []corev1.Node, pods []corev1.Pod, cpuReq, memReq resource.Quantity) FitResult {
+	return ComputeFitWithOptions(nodes, pods, cpuReq, memReq, FitOptions{})
+}
+
+// ComputeFitWithOptions is ComputeFit with the given options
+func ComputeFitWithOptions(nodes []corev1.Node, pods []corev1.Pod, cpuReq, memReq resource.Quantity, opts FitOptions) FitResult {
+	summary := newEmptySummary()
+	sumNodeCapacities(summary, nodes)
+	sumPodResources(summary, pods, false)
+
+	result := FitResult{CPURequest: cpuReq, MemRequest: memReq}
+	result.Cluster.CPU, result.Cluster.Memory = Headroom(summary)
+
+	podsByNode := make(map[string][]corev1.Pod)
+	for i := range pods {
+		podsByNode[pods[i].Spec.NodeName] = append(podsByNode[pods[i].Spec.NodeName], pods[i])
+	}
+
+	// Per-node DaemonSet requests, and the largest of them as the expected footprint
+	var reserve AllocatableResources
+	daemonSetByNode := make(map[string]AllocatableResources)
+	if opts.SubtractDaemonSets {
+		for i := range nodes {
+			if nodes[i].Spec.Unschedulable {
+				continue
+			}
+			var ds AllocatableResources
+			for j := range podsByNode[nodes[i].Name] {
+				if isDaemonSetPod(&podsByNode[nodes[i].Name][j]) {
+					addPodResourcesForNode(&ds.CPU, &ds.Memory, &podsByNode[nodes[i].Name][j])
+				}
+			}
+			daemonSetByNode[nodes[i].Name] = ds
+			reserve.CPU = max(reserve.CPU, ds.CPU)
+			reserve.Memory = max(reserve.Memory, ds.Memory)
+		}
+		result.DaemonSetReserve = NodeHeadroom{
+			CPU:    *resource.NewMilliQuantity(reserve.CPU, resource.DecimalSI),
+			Memory: *resource.NewQuantity(reserve.Memory, resource.BinarySI),
+		}
+	}
+
+	var headrooms []NodeHeadroom
+	for i := range nodes {
+		if nodes[i].Spec.Unschedulable {
+			continue
+		}
+
+		nodeSummary := newEmptySummary()
+		sumNodeCapacities(nodeSummary, nodes[i:i+1])
+		sumPodResources(nodeSummary, podsByNode[nodes[i].Name], false)
+
+		h := NodeHeadroom{Name: nodes[i].Name}
+		h.CPU, h.Memory = Headroom(nodeSummary)
+		if opts.SubtractDaemonSets {
+			// Only the DaemonSet share the node does not already carry is missing
+			ds := daemonSetByNode[nodes[i].Name]
+			missing := AllocatableResources{CPU: reserve.CPU - ds.CPU, Memory: reserve.Memory - ds.Memory}
+			h = subtractHeadroom(h, missing)
+			result.Cluster = subtractHeadroom(result.Cluster, missing)
+		}
+		headrooms = append(headrooms, h)
+
+		if CanSchedule(h, cpuReq, memReq) {
+			result.FittingNodes = append(result.FittingNodes, h.Name)
+		}
+	}
+	result.SchedulableNodes = len(headrooms)
+	sort.Strings(result.FittingNodes)
+
+	sort.Slice(headrooms, func(i, j int) bool {
+		if c := headrooms[i].CPU.Cmp(headrooms[j].CPU); c != 0 {
+			return c > 0
+		}
+		if c := headrooms[i].Memory.Cmp(headrooms[j].Memory); c != 0 {
+			return c > 0
+		}
+		return headrooms[i].Name < headrooms[j].Name
+	})
+	if len(headrooms) > 0 {
+		result.Largest = headrooms[0]
+	}
+
+	return result
+}
+
+// subtractHeadroom removes r (CPU in millicores, memory in bytes) from h, floored at zero
+func subtractHeadroom(h NodeHeadroom, r AllocatableResources) NodeHeadroom {
+	h.CPU.Sub(*resource.NewMilliQuantity(r.CPU, resource.DecimalSI))
+	h.Memory.Sub(*resource.NewQuantity(r.Memory, resource.BinarySI))
+	if h.CPU.Sign() < 0 {
+		h.CPU = *resource.NewQuantity(0, resource.DecimalSI)
+	}
+	if h.Memory.Sign() < 0 {
+		h.Memory = *resource.NewQuantity(0, resource.BinarySI)
+	}
+	return h
+}
@@ -0,0 +1,112 @@
+package capacity
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHeadroom(t *testing.T) {
+	summary := newEmptySummary()
+	summary.TotalCPUAllocatable = resource.MustParse("8")
+	summary.TotalCPURequests = resource.MustParse("2500m")
+	summary.TotalMemAllocatable = resource.MustParse("16Gi")
+	summary.TotalMemRequests = resource.MustParse("20Gi")
+
+	cpuFree, memFree := Headroom(summary)
+	if cpuFree.Cmp(resource.MustParse("5500m")) != 0 {
+		t.Errorf("expected 5500m CPU free, got %s", cpuFree.String())
+	}
+	if !memFree.IsZero() {
+		t.Errorf("expected overcommitted memory to floor at zero, got %s", memFree.String())
+	}
+}
+
+func TestCanSchedule(t *testing.T) {
+	h := NodeHeadroom{CPU: resource.MustParse("2"), Memory: resource.MustParse("4Gi")}
+
+	if !CanSchedule(h, resource.MustParse("2"), resource.MustParse("4Gi")) {
+		t.Error("expected exact fit to be schedulable")
+	}
+	if CanSchedule(h, resource.MustParse("2100m"), resource.MustParse("1Gi")) {
+		t.Error("expected too much CPU to be unschedulable")
+	}
+	if CanSchedule(h, resource.MustParse("1"), resource.MustParse("5Gi")) {
+		t.Error("expected too much memory to be unschedulable")
+	}
+}
+
+func TestComputeFit_AggregateRoomButNoSingleNode(t *testing.T) {
+	// Three nodes with 1.5 CPU free each: 4.5 CPU in aggregate, but no node can take 2
+	nodes := []corev1.Node{
+		testNode("n1", "4", "8Gi"),
+		testNode("n2", "4", "8Gi"),
+		testNode("n3", "4", "8Gi"),
+	}
+	pods := []corev1.Pod{
+		testPod("default", "a", "n1", "2500m", "1Gi", corev1.PodRunning),
+		testPod("default", "b", "n2", "2500m", "1Gi", corev1.PodRunning),
+		testPod("default", "c", "n3", "2500m", "2Gi", corev1.PodRunning),
+	}
+
+	fit := ComputeFit(nodes, pods, resource.MustParse("2"), resource.MustParse("4Gi"))
+
+	if !fit.AggregateFits() {
+		t.Errorf("expected aggregate headroom %s/%s to fit", fit.Cluster.CPU.String(), fit.Cluster.Memory.String())
+	}
+	if fit.Fits() {
+		t.Errorf("expected no single node to fit, got %v", fit.FittingNodes)
+	}
+	if fit.Largest.Name != "n1" || fit.Largest.CPU.Cmp(resource.MustParse("1500m")) != 0 {
+		t.Errorf("expected largest node n1 with 1500m free, got %s with %s", fit.Largest.Name, fit.Largest.CPU.String())
+	}
+
+	fit = ComputeFit(nodes, pods, resource.MustParse("1"), resource.MustParse("4Gi"))
+	if len(fit.FittingNodes) != 3 {
+		t.Errorf("expected a 1 CPU pod to fit on all nodes, got %v", fit.FittingNodes)
+	}
+}
+
+func TestComputeFit_SkipsCordonedNodes(t *testing.T) {
+	cordoned := testNode("big", "16", "64Gi")
+	cordoned.Spec.Unschedulable = true
+	nodes := []corev1.Node{cordoned, testNode("small", "2", "4Gi")}
+
+	fit := ComputeFit(nodes, nil, resource.MustParse("4"), resource.MustParse("1Gi"))
+	if fit.Fits() {
+		t.Errorf("expected cordoned node to be ignored, got %v", fit.FittingNodes)
+	}
+	if fit.SchedulableNodes != 1 || fit.Largest.Name != "small" {
+		t.Errorf("expected only 'small' to be considered, got %d nodes, largest %q", fit.SchedulableNodes, fit.Largest.Name)
+	}
+}
+
+func TestComputeFitWithOptions_SubtractDaemonSets(t *testing.T) {
+	// n1 already runs a 500m DaemonSet pod; n2 joined recently and does not yet
+	nodes := []corev1.Node{testNode("n1", "4", "8Gi"), testNode("n2", "4", "8Gi")}
+	ds := testPod("kube-system", "agent-n1", "n1", "500m", "256Mi", corev1.PodRunning)
+	isController := true
+	ds.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &isController}}
+	pods := []corev1.Pod{ds}
+
+	plain := ComputeFit(nodes, pods, resource.MustParse("4"), resource.MustParse("1Gi"))
+	if len(plain.FittingNodes) != 1 || plain.FittingNodes[0] != "n2" {
+		t.Fatalf("expected a 4 CPU pod to fit on the empty n2 without the option, got %v", plain.FittingNodes)
+	}
+
+	fit := ComputeFitWithOptions(nodes, pods, resource.MustParse("4"), resource.MustParse("1Gi"), FitOptions{SubtractDaemonSets: true})
+	if fit.Fits() {
+		t.Errorf("expected DaemonSet reserve to leave no room for 4 CPU, got %v", fit.FittingNodes)
+	}
+	if fit.DaemonSetReserve.CPU.Cmp(resource.MustParse("500m")) != 0 {
+		t.Errorf("expected 500m DaemonSet reserve, got %s", fit.DaemonSetReserve.CPU.String())
+	}
+	if fit.Cluster.CPU.Cmp(resource.MustParse("7")) != 0 {
+		t.Errorf("expected cluster headroom 7 CPU, got %s (was %s)", fit.Cluster.CPU.String(), plain.Cluster.CPU.String())
+	}
+	if fit.Largest.CPU.Cmp(resource.MustParse("3500m")) != 0 {
+		t.Errorf("expected largest node headroom 3500m, got %s", fit.Largest.CPU.String())
+	}
+}
@@ -0,0 +1,84 @@
+package capacity
+
+import (
+	"math"
+)
+
+// DefaultImbalanceDeviation flags a resource when its hottest node's utilization
+// exceeds the cluster mean by more than this many percentage points
+const DefaultImbalanceDeviation = 25.0
+
+// UtilizationSpread summarizes how one resource's request utilization (0-100)
+// is spread across nodes
+type UtilizationSpread struct {
+	Mean   float64
+	StdDev float64 // population standard deviation
+	Min    float64
+	Max    float64
+
+	HottestNode string
+}
+
+// Deviation returns how far the hottest node sits above the mean, in percentage points
+func (s UtilizationSpread) Deviation() float64 {
+	return s.Max - s.Mean
+}
+
+// NodeImbalance describes the spread of per-node CPU and memory utilization
+type NodeImbalance struct {
+	Nodes  int
+	CPU    UtilizationSpread
+	Memory UtilizationSpread
+}
+
+// Imbalanced reports whether the hottest node exceeds the mean by more than
+// maxDeviation percentage points for CPU or memory
+func (ni NodeImbalance) Imbalanced(maxDeviation float64) bool {
+	return ni.CPU.Deviation() > maxDeviation || ni.Memory.Deviation() > maxDeviation
+}
+
+// AnalyzeImbalance computes the spread of CPU and memory utilization over the
+// given node pressures. A high spread points at poor bin-packing or affinity
+// rules pinning workloads to a few nodes.
+func AnalyzeImbalance(nodes []NodePressure) NodeImbalance {
+	ni := NodeImbalance{Nodes: len(nodes)}
+	if len(nodes) == 0 {
+		return ni
+	}
+
+	cpu := make([]float64, len(nodes))
+	mem := make([]float64, len(nodes))
+	names := make([]string, len(nodes))
+	for i, np := range nodes {
+		cpu[i], mem[i], names[i] = np.CPUUtilization, np.MemUtilization, np.NodeName
+	}
+
+	ni.CPU = spreadOf(cpu, names)
+	ni.Memory = spreadOf(mem, names)
+	return ni
+}
+
+// spreadOf computes mean, standard deviation and range of values; names[i] labels values[i]
+func spreadOf(values []float64, names []string) UtilizationSpread {
+	s := UtilizationSpread{Min: values[0], Max: values[0], HottestNode: names[0]}
+
+	var sum float64
+	for i, v := range values {
+		sum += v
+		if v < s.Min {
+			s.Min = v
+		}
+		if v > s.Max {
+			s.Max, s.HottestNode = v, names[i]
+		}
+	}
+	s.Mean = sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - s.Mean) * (v - s.Mean)
+	}
+	s.StdDev = math.Sqrt(squares / float64(len(values)))
+
+	return s
+}
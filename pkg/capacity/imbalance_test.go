@@ -0,0 +1,66 @@
+package capacity
+
+import (
+	"math"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeImbalance_Balanced(t *testing.T) {
+	nodes := []corev1.Node{
+		testNode("node-a", "4", "8Gi"),
+		testNode("node-b", "4", "8Gi"),
+		testNode("node-c", "4", "8Gi"),
+	}
+	pods := []corev1.Pod{
+		testPod("default", "a", "node-a", "2", "4Gi", corev1.PodRunning),
+		testPod("default", "b", "node-b", "2", "4Gi", corev1.PodRunning),
+		testPod("default", "c", "node-c", "2", "4Gi", corev1.PodRunning),
+	}
+
+	pressure := ComputeClusterPressure(nodes, pods, PressureOptions{Thresholds: DefaultPressureThresholds()})
+	imbalance := AnalyzeImbalance(pressure.NodePressures)
+
+	if imbalance.Nodes != 3 {
+		t.Errorf("expected 3 nodes, got %d", imbalance.Nodes)
+	}
+	if imbalance.CPU.StdDev > 0.01 || imbalance.Memory.StdDev > 0.01 {
+		t.Errorf("expected no spread, got CPU stddev %.2f, memory stddev %.2f", imbalance.CPU.StdDev, imbalance.Memory.StdDev)
+	}
+	if imbalance.Imbalanced(DefaultImbalanceDeviation) {
+		t.Error("expected balanced cluster not to be flagged")
+	}
+}
+
+func TestAnalyzeImbalance_Imbalanced(t *testing.T) {
+	nodes := []corev1.Node{
+		testNode("node-a", "4", "8Gi"),
+		testNode("node-b", "4", "8Gi"),
+		testNode("node-c", "4", "8Gi"),
+	}
+	pods := []corev1.Pod{
+		testPod("default", "hot", "node-a", "3600m", "4Gi", corev1.PodRunning),
+		testPod("default", "cold", "node-b", "400m", "4Gi", corev1.PodRunning),
+	}
+
+	pressure := ComputeClusterPressure(nodes, pods, PressureOptions{Thresholds: DefaultPressureThresholds()})
+	imbalance := AnalyzeImbalance(pressure.NodePressures)
+
+	// CPU utilization is 90%, 10% and 0%: mean 33.3, hottest node 56.7 points above it
+	if imbalance.CPU.HottestNode != "node-a" {
+		t.Errorf("expected node-a to be the hottest node, got %s", imbalance.CPU.HottestNode)
+	}
+	if math.Abs(imbalance.CPU.Mean-33.33) > 0.01 {
+		t.Errorf("expected CPU mean ~33.33, got %.2f", imbalance.CPU.Mean)
+	}
+	if math.Abs(imbalance.CPU.StdDev-40.28) > 0.01 {
+		t.Errorf("expected CPU stddev ~40.28, got %.2f", imbalance.CPU.StdDev)
+	}
+	if imbalance.CPU.Max-imbalance.CPU.Min != 90 {
+		t.Errorf("expected CPU spread of 90 points, got %.2f", imbalance.CPU.Max-imbalance.CPU.Min)
+	}
+	if !imbalance.Imbalanced(DefaultImbalanceDeviation) {
+		t.Error("expected imbalanced cluster to be flagged")
+	}
+}
@@ -0,0 +1,174 @@
+package capacity
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultPoolLabel is the well-known node label used to group nodes into pools
+const DefaultPoolLabel = "node.kubernetes.io/instance-type"
+
+// ZoneLabel is the well-known node label carrying the topology zone
+const ZoneLabel = "topology.kubernetes.io/zone"
+
+// NoPoolValue is the pool name for nodes without the grouping label
+const NoPoolValue = "<none>"
+
+// ZoneImbalanceFactor flags a pool whose share of allocatable exceeds this
+// multiple of an even split (e.g. above 75% with two zones)
+const ZoneImbalanceFactor = 1.5
+
+// NodePool aggregates capacity, requests and pressure for nodes sharing a label value
+type NodePool struct {
+	Label string
+	Value string
+	Nodes []string
+
+	CPUCapacity    resource.Quantity
+	CPUAllocatable resource.Quantity
+	MemCapacity    resource.Quantity
+	MemAllocatable resource.Quantity
+
+	CPURequests resource.Quantity
+	MemRequests resource.Quantity
+
+	CPUUtilization float64
+	MemUtilization float64
+	Pressure       PressureLevel
+}
+
+// GroupByLabel groups nodes by the value of labelKey and sums their capacity
+// and allocatable resources. Nodes without the label fall under NoPoolValue.
+// Pools are sorted by value, node names within a pool by name.
+func GroupByLabel(nodes []corev1.Node, labelKey string) []NodePool {
+	byValue := make(map[string]*NodePool)
+	for i := range nodes {
+		node := &nodes[i]
+		value, ok := node.Labels[labelKey]
+		if !ok || value == "" {
+			value = NoPoolValue
+		}
+
+		pool, ok := byValue[value]
+		if !ok {
+			pool = &NodePool{Label: labelKey, Value: value}
+			byValue[value] = pool
+		}
+
+		pool.Nodes = append(pool.Nodes, node.Name)
+		pool.CPUCapacity.Add(*node.Status.Capacity.Cpu())
+		pool.CPUAllocatable.Add(*node.Status.Allocatable.Cpu())
+		pool.MemCapacity.Add(*node.Status.Capacity.Memory())
+		pool.MemAllocatable.Add(*node.Status.Allocatable.Memory())
+	}
+
+	pools := make([]NodePool, 0, len(byValue))
+	for _, pool := range byValue {
+		sort.Strings(pool.Nodes)
+		pools = append(pools, *pool)
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Value < pools[j].Value
+	})
+
+	return pools
+}
+
+// AnalyzePools groups the cluster's nodes by labelKey and computes per-pool
+// requests, utilization and pressure from the pods scheduled on them.
+func AnalyzePools(ctx context.Context, client kubernetes.Interface, labelKey string, opts PressureOptions) ([]NodePool, error) {
+	nodes, pods, err := fetchClusterResources(ctx, client, "", opts.FieldSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.IncludeTerminated {
+		pods = activePods(pods)
+	}
+
+	pools := GroupByLabel(nodes, labelKey)
+	computePoolPressure(pools, pods, opts.Thresholds)
+
+	return pools, nil
+}
+
+// computePoolPressure sums pod requests per pool and derives utilization and pressure
+func computePoolPressure(pools []NodePool, pods []corev1.Pod, thresholds PressureThresholds) {
+	poolByNode := make(map[string]int)
+	for i := range pools {
+		for _, name := range pools[i].Nodes {
+			poolByNode[name] = i
+		}
+	}
+
+	cpuRequests := make([]int64, len(pools))
+	memRequests := make([]int64, len(pools))
+	for i := range pods {
+		idx, ok := poolByNode[pods[i].Spec.NodeName]
+		if !ok {
+			continue
+		}
+		addPodResourcesForNode(&cpuRequests[idx], &memRequests[idx], &pods[i])
+	}
+
+	for i := range pools {
+		p := &pools[i]
+		p.CPURequests = *resource.NewMilliQuantity(cpuRequests[i], resource.DecimalSI)
+		p.MemRequests = *resource.NewQuantity(memRequests[i], resource.BinarySI)
+
+		if alloc := p.CPUAllocatable.MilliValue(); alloc > 0 {
+			p.CPUUtilization = float64(cpuRequests[i]) / float64(alloc) * 100
+		}
+		if alloc := p.MemAllocatable.Value(); alloc > 0 {
+			p.MemUtilization = float64(memRequests[i]) / float64(alloc) * 100
+		}
+
+		p.Pressure = combinePressureLevels(
+			getPressureLevel(p.CPUUtilization, thresholds),
+			getPressureLevel(p.MemUtilization, thresholds),
+		)
+	}
+}
+
+// PoolShare is a pool's fraction of the total allocatable CPU and memory
+type PoolShare struct {
+	Value    string
+	CPUShare float64
+	MemShare float64
+}
+
+// DisproportionatePools returns pools whose share of allocatable CPU or memory
+// exceeds factor times an even split. A single pool is never flagged.
+func DisproportionatePools(pools []NodePool, factor float64) []PoolShare {
+	if len(pools) < 2 {
+		return nil
+	}
+
+	var totalCPU, totalMem int64
+	for i := range pools {
+		totalCPU += pools[i].CPUAllocatable.MilliValue()
+		totalMem += pools[i].MemAllocatable.Value()
+	}
+
+	limit := factor / float64(len(pools))
+	var flagged []PoolShare
+	for i := range pools {
+		share := PoolShare{Value: pools[i].Value}
+		if totalCPU > 0 {
+			share.CPUShare = float64(pools[i].CPUAllocatable.MilliValue()) / float64(totalCPU)
+		}
+		if totalMem > 0 {
+			share.MemShare = float64(pools[i].MemAllocatable.Value()) / float64(totalMem)
+		}
+		if share.CPUShare > limit || share.MemShare > limit {
+			flagged = append(flagged, share)
+		}
+	}
+
+	return flagged
+}
@@ -0,0 +1,129 @@
+package capacity
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func poolNode(name, instanceType, cpu, mem string) corev1.Node {
+	node := testNode(name, cpu, mem)
+	node.Status.Capacity = node.Status.Allocatable.DeepCopy()
+	if instanceType != "" {
+		node.Labels = map[string]string{DefaultPoolLabel: instanceType}
+	}
+	return node
+}
+
+func TestGroupByLabel_TwoInstanceTypes(t *testing.T) {
+	nodes := []corev1.Node{
+		poolNode("large-1", "m5.2xlarge", "8", "32Gi"),
+		poolNode("small-1", "m5.large", "2", "8Gi"),
+		poolNode("large-2", "m5.2xlarge", "8", "32Gi"),
+	}
+
+	pools := GroupByLabel(nodes, DefaultPoolLabel)
+	if len(pools) != 2 {
+		t.Fatalf("expected 2 pools, got %d", len(pools))
+	}
+
+	large := pools[0]
+	if large.Value != "m5.2xlarge" {
+		t.Fatalf("expected first pool m5.2xlarge, got %s", large.Value)
+	}
+	if len(large.Nodes) != 2 || large.Nodes[0] != "large-1" || large.Nodes[1] != "large-2" {
+		t.Errorf("expected nodes [large-1 large-2], got %v", large.Nodes)
+	}
+	if large.CPUCapacity.Cmp(resource.MustParse("16")) != 0 {
+		t.Errorf("expected summed CPU capacity 16, got %s", large.CPUCapacity.String())
+	}
+	if large.MemAllocatable.Cmp(resource.MustParse("64Gi")) != 0 {
+		t.Errorf("expected summed memory allocatable 64Gi, got %s", large.MemAllocatable.String())
+	}
+
+	if pools[1].Value != "m5.large" || pools[1].CPUCapacity.Cmp(resource.MustParse("2")) != 0 {
+		t.Errorf("unexpected second pool: %s with CPU %s", pools[1].Value, pools[1].CPUCapacity.String())
+	}
+}
+
+func TestGroupByLabel_UnlabeledNodes(t *testing.T) {
+	nodes := []corev1.Node{poolNode("bare", "", "1", "1Gi")}
+
+	pools := GroupByLabel(nodes, DefaultPoolLabel)
+	if len(pools) != 1 || pools[0].Value != NoPoolValue {
+		t.Errorf("expected a single %s pool, got %+v", NoPoolValue, pools)
+	}
+}
+
+func TestAnalyzePools_Pressure(t *testing.T) {
+	large := poolNode("large-1", "m5.2xlarge", "8", "32Gi")
+	small := poolNode("small-1", "m5.large", "2", "8Gi")
+	pod := testPod("default", "app", "small-1", "1900m", "1Gi", corev1.PodRunning)
+
+	client := fake.NewSimpleClientset(&large, &small, &pod)
+	pools, err := AnalyzePools(context.Background(), client, DefaultPoolLabel, PressureOptions{Thresholds: DefaultPressureThresholds()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, p := range pools {
+		switch p.Value {
+		case "m5.large":
+			if p.Pressure != PressureHigh {
+				t.Errorf("expected m5.large pool HIGH pressure, got %s (cpu %.1f%%)", p.Pressure, p.CPUUtilization)
+			}
+			if p.CPURequests.Cmp(resource.MustParse("1900m")) != 0 {
+				t.Errorf("expected CPU requests 1900m, got %s", p.CPURequests.String())
+			}
+		case "m5.2xlarge":
+			if p.Pressure != PressureLow || p.CPUUtilization != 0 {
+				t.Errorf("expected idle m5.2xlarge pool, got %s (cpu %.1f%%)", p.Pressure, p.CPUUtilization)
+			}
+		default:
+			t.Errorf("unexpected pool %s", p.Value)
+		}
+	}
+}
+
+func TestGroupByLabel_Zones(t *testing.T) {
+	zoned := func(name, zone, cpu, mem string) corev1.Node {
+		node := poolNode(name, "", cpu, mem)
+		node.Labels = map[string]string{ZoneLabel: zone}
+		return node
+	}
+
+	nodes := []corev1.Node{
+		zoned("a-1", "eu-west-1a", "8", "32Gi"),
+		zoned("a-2", "eu-west-1a", "8", "32Gi"),
+		zoned("a-3", "eu-west-1a", "8", "32Gi"),
+		zoned("a-4", "eu-west-1a", "8", "32Gi"),
+		zoned("b-1", "eu-west-1b", "4", "16Gi"),
+	}
+
+	pools := GroupByLabel(nodes, ZoneLabel)
+	if len(pools) != 2 {
+		t.Fatalf("expected 2 zones, got %d", len(pools))
+	}
+	if pools[0].Value != "eu-west-1a" || pools[0].CPUAllocatable.Cmp(resource.MustParse("32")) != 0 {
+		t.Errorf("unexpected first zone: %s with CPU %s", pools[0].Value, pools[0].CPUAllocatable.String())
+	}
+	if pools[1].Value != "eu-west-1b" || pools[1].MemAllocatable.Cmp(resource.MustParse("16Gi")) != 0 {
+		t.Errorf("unexpected second zone: %s with memory %s", pools[1].Value, pools[1].MemAllocatable.String())
+	}
+
+	flagged := DisproportionatePools(pools, ZoneImbalanceFactor)
+	if len(flagged) != 1 || flagged[0].Value != "eu-west-1a" {
+		t.Fatalf("expected eu-west-1a to be flagged, got %+v", flagged)
+	}
+	if flagged[0].CPUShare < 0.88 || flagged[0].CPUShare > 0.89 {
+		t.Errorf("expected CPU share ~0.89, got %f", flagged[0].CPUShare)
+	}
+
+	balanced := GroupByLabel(nodes[3:], ZoneLabel)
+	if got := DisproportionatePools(balanced, ZoneImbalanceFactor); len(got) != 0 {
+		t.Errorf("expected no imbalance, got %+v", got)
+	}
+}
@@ -2,8 +2,12 @@ package capacity
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/marcgeld/cobrak/pkg/logging"
+	"github.com/marcgeld/cobrak/pkg/resources"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -17,8 +21,30 @@ const (
 	PressureMedium    PressureLevel = "MEDIUM"
 	PressureHigh      PressureLevel = "HIGH"
 	PressureSaturated PressureLevel = "SATURATED"
+	PressureUnknown   PressureLevel = "UNKNOWN"
 )
 
+// Code maps the level to a stable integer for scripts: LOW=0, MEDIUM=1,
+// HIGH=2, SATURATED=3. Unknown levels map to -1.
+func (l PressureLevel) Code() int {
+	switch l {
+	case PressureLow:
+		return 0
+	case PressureMedium:
+		return 1
+	case PressureHigh:
+		return 2
+	case PressureSaturated:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// ReservationWarnRatio is the share of capacity held back from allocatable
+// (system and kubelet reservation) above which a node is flagged.
+const ReservationWarnRatio = 0.25
+
 // NodePressure holds pressure information for a single node
 type NodePressure struct {
 	NodeName       string
@@ -26,6 +52,21 @@ type NodePressure struct {
 	CPUUtilization float64
 	MemPressure    PressureLevel
 	MemUtilization float64
+
+	// Reservation ratios: 1 - allocatable/capacity
+	CPUReservationRatio float64
+	MemReservationRatio float64
+
+	// Requests on the node split by owner: DaemonSet pods run on every node
+	// regardless of workload, so they are reported separately
+	DaemonSetRequest AllocatableResources
+	WorkloadRequest  AllocatableResources
+}
+
+// HighReservation reports whether the node reserves more than ReservationWarnRatio
+// of its CPU or memory capacity
+func (np NodePressure) HighReservation() bool {
+	return np.CPUReservationRatio > ReservationWarnRatio || np.MemReservationRatio > ReservationWarnRatio
 }
 
 // NamespacePressure holds pressure information for a namespace
@@ -39,11 +80,19 @@ type NamespacePressure struct {
 
 // ClusterPressure holds overall cluster pressure
 type ClusterPressure struct {
-	Overall            PressureLevel
+	Overall PressureLevel
+	// OverallCPU and OverallMem are the worst node CPU and memory pressure;
+	// Overall is the worse of the two
+	OverallCPU PressureLevel
+	OverallMem PressureLevel
+
 	CPUUtilization     float64
 	MemUtilization     float64
 	NodePressures      []NodePressure
 	NamespacePressures []NamespacePressure
+
+	// Warnings lists data that could not be gathered in partial mode
+	Warnings []string
 }
 
 // PressureThresholds defines the pressure level thresholds
@@ -64,86 +113,243 @@ func DefaultPressureThresholds() PressureThresholds {
 	}
 }
 
+// Level returns the pressure level of utilization under these thresholds
+func (t PressureThresholds) Level(utilization float64) PressureLevel {
+	return getPressureLevel(utilization, t)
+}
+
 // CalculatePressure analyzes cluster resources and returns pressure status using default thresholds
 func CalculatePressure(ctx context.Context, client kubernetes.Interface, namespace string) (*ClusterPressure, error) {
 	return CalculatePressureWithThresholds(ctx, client, namespace, DefaultPressureThresholds())
 }
 
+// PressureOptions controls how cluster pressure is calculated
+type PressureOptions struct {
+	Thresholds PressureThresholds
+	// IncludeTerminated counts pods in a terminal phase (Succeeded/Failed),
+	// which are skipped by default since they no longer hold node resources
+	IncludeTerminated bool
+	// NamespaceThresholds overrides Thresholds for namespace status, keyed by namespace
+	NamespaceThresholds map[string]PressureThresholds
+	// FieldSelector filters the pod list server-side (e.g. "spec.nodeName=worker-1").
+	// Nodes are never filtered.
+	FieldSelector string
+	// AllowPartial keeps going with node capacity alone when nodes can be
+	// listed but pods cannot (e.g. RBAC denies pods), recording a warning
+	AllowPartial bool
+	// Against selects the denominator of utilization; empty means allocatable
+	Against PressureBasis
+}
+
+// PressureBasis is the node resource that requests are measured against
+type PressureBasis string
+
+const (
+	// BasisAllocatable measures requests against what the scheduler can hand out
+	BasisAllocatable PressureBasis = "allocatable"
+	// BasisCapacity measures requests against raw node capacity, including the
+	// share reserved for the system and kubelet
+	BasisCapacity PressureBasis = "capacity"
+)
+
+// ParsePressureBasis parses "allocatable" or "capacity"
+func ParsePressureBasis(s string) (PressureBasis, error) {
+	switch PressureBasis(s) {
+	case BasisAllocatable, BasisCapacity:
+		return PressureBasis(s), nil
+	default:
+		return "", fmt.Errorf("unknown basis %q: must be %s or %s", s, BasisAllocatable, BasisCapacity)
+	}
+}
+
+// resources returns the node's CPU and memory under this basis
+func (b PressureBasis) resources(node *corev1.Node) corev1.ResourceList {
+	if b == BasisCapacity {
+		return node.Status.Capacity
+	}
+	return node.Status.Allocatable
+}
+
 // CalculatePressureWithThresholds analyzes cluster resources with custom thresholds
 func CalculatePressureWithThresholds(ctx context.Context, client kubernetes.Interface, namespace string, thresholds PressureThresholds) (*ClusterPressure, error) {
+	return CalculatePressureWithOptions(ctx, client, namespace, PressureOptions{Thresholds: thresholds})
+}
+
+// CalculatePressureWithOptions analyzes cluster resources with the given options
+func CalculatePressureWithOptions(ctx context.Context, client kubernetes.Interface, namespace string, opts PressureOptions) (*ClusterPressure, error) {
+	nodes, pods, err := fetchClusterResources(ctx, client, namespace, opts.FieldSelector)
+	warnings, err := partialResult(err, opts.AllowPartial)
+	if err != nil {
+		return nil, err
+	}
+
+	// Namespace threshold annotations are optional; without permission to list
+	// namespaces every namespace simply uses the global thresholds
+	if opts.NamespaceThresholds == nil {
+		namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			logging.FromContext(ctx).Debugf("namespace threshold annotations unavailable, using global thresholds: %v", err)
+		} else {
+			opts.NamespaceThresholds = NamespaceThresholds(namespaces.Items, opts.Thresholds)
+		}
+	}
+
+	pressure := ComputeClusterPressure(nodes, pods, opts)
+	pressure.Warnings = warnings
+	return pressure, nil
+}
+
+// ComputeClusterPressure calculates node, namespace and cluster pressure from
+// already-fetched nodes and pods. It performs no API calls.
+func ComputeClusterPressure(nodes []corev1.Node, pods []corev1.Pod, opts PressureOptions) *ClusterPressure {
 	pressure := &ClusterPressure{
 		NodePressures:      []NodePressure{},
 		NamespacePressures: []NamespacePressure{},
 	}
 
-	// Fetch cluster resources
-	nodes, pods, err := fetchClusterResources(ctx, client, namespace)
-	if err != nil {
-		return nil, err
+	if !opts.IncludeTerminated {
+		pods = activePods(pods)
 	}
 
 	// Calculate per-node and per-namespace pressure
-	calculateNodePressures(pressure, nodes, pods, thresholds)
-	calculateNamespacePressures(pressure, nodes, pods, thresholds)
-	calculateClusterPressure(pressure, nodes, pods)
+	calculateNodePressures(pressure, nodes, pods, opts.Thresholds, opts.Against)
+	calculateNamespacePressures(pressure, nodes, pods, opts.Thresholds, opts.NamespaceThresholds, opts.Against)
+	calculateClusterPressure(pressure, nodes, pods, opts.Against)
 
-	return pressure, nil
+	return pressure
 }
 
-// fetchClusterResources retrieves nodes and pods from the cluster
-func fetchClusterResources(ctx context.Context, client kubernetes.Interface, namespace string) ([]corev1.Node, []corev1.Pod, error) {
+// fetchClusterResources retrieves nodes and pods from the cluster, applying
+// fieldSelector (if any) to the pod list only
+func fetchClusterResources(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string) ([]corev1.Node, []corev1.Pod, error) {
 	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, nil, fmt.Errorf("listing nodes: %w", err)
 	}
 
-	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: fieldSelector})
 	if err != nil {
-		return nil, nil, fmt.Errorf("listing pods: %w", err)
+		return nodes.Items, nil, &podListError{err: err}
 	}
 
 	return nodes.Items, pods.Items, nil
 }
 
-// calculateNodePressures computes pressure for all nodes
-func calculateNodePressures(pressure *ClusterPressure, nodes []corev1.Node, pods []corev1.Pod, thresholds PressureThresholds) {
+// podListError reports a failed pod list after nodes were listed successfully,
+// so callers that allow partial results can still use node capacity
+type podListError struct {
+	err error
+}
+
+func (e *podListError) Error() string {
+	return fmt.Sprintf("listing pods: %v", e.err)
+}
+
+func (e *podListError) Unwrap() error {
+	return e.err
+}
+
+// partialResult turns a pod list failure into a warning when allowPartial is
+// set; any other error is returned unchanged
+func partialResult(err error, allowPartial bool) ([]string, error) {
+	if err == nil {
+		return nil, nil
+	}
+	var podErr *podListError
+	if !allowPartial || !errors.As(err, &podErr) {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("%v; showing node capacity only, pod requests are not counted", err)}, nil
+}
+
+// activePods drops pods in a terminal phase (Succeeded/Failed)
+func activePods(pods []corev1.Pod) []corev1.Pod {
+	active := make([]corev1.Pod, 0, len(pods))
+	for i := range pods {
+		if resources.IsTerminated(&pods[i]) {
+			continue
+		}
+		active = append(active, pods[i])
+	}
+	return active
+}
+
+// calculateNodePressures computes pressure for all nodes, ordered by node name
+func calculateNodePressures(pressure *ClusterPressure, nodes []corev1.Node, pods []corev1.Pod, thresholds PressureThresholds, basis PressureBasis) {
 	for i := range nodes {
-		nodePressure := computeNodePressure(&nodes[i], pods, thresholds)
+		nodePressure := computeNodePressure(&nodes[i], pods, thresholds, basis)
 		pressure.NodePressures = append(pressure.NodePressures, nodePressure)
 	}
+
+	sort.Slice(pressure.NodePressures, func(i, j int) bool {
+		return pressure.NodePressures[i].NodeName < pressure.NodePressures[j].NodeName
+	})
 }
 
-// computeNodePressure calculates pressure for a single node with custom thresholds
-func computeNodePressure(node *corev1.Node, pods []corev1.Pod, thresholds PressureThresholds) NodePressure {
+// computeNodePressure calculates pressure for a single node with custom thresholds,
+// measuring requests against the node's allocatable or capacity per basis
+func computeNodePressure(node *corev1.Node, pods []corev1.Pod, thresholds PressureThresholds, basis PressureBasis) NodePressure {
 	np := NodePressure{NodeName: node.Name}
 
 	// Get node allocatable resources
 	cpuAllocatable := node.Status.Allocatable.Cpu()
 	memAllocatable := node.Status.Allocatable.Memory()
 
-	// Sum resource requests for pods on this node
-	var nodeCPURequest, nodeMemRequest int64
+	// And the denominator of utilization, allocatable unless basis says capacity
+	total := basis.resources(node)
+	cpuTotal, memTotal := total.Cpu(), total.Memory()
+
+	// Sum resource requests for pods on this node, keeping DaemonSet pods apart
 	for i := range pods {
-		if pods[i].Spec.NodeName == node.Name {
-			addPodResourcesForNode(&nodeCPURequest, &nodeMemRequest, &pods[i])
+		if pods[i].Spec.NodeName != node.Name {
+			continue
+		}
+		bucket := &np.WorkloadRequest
+		if isDaemonSetPod(&pods[i]) {
+			bucket = &np.DaemonSetRequest
 		}
+		addPodResourcesForNode(&bucket.CPU, &bucket.Memory, &pods[i])
 	}
+	nodeCPURequest := np.DaemonSetRequest.CPU + np.WorkloadRequest.CPU
+	nodeMemRequest := np.DaemonSetRequest.Memory + np.WorkloadRequest.Memory
 
 	// Calculate CPU pressure
-	if cpuAllocatable != nil && cpuAllocatable.MilliValue() > 0 {
-		np.CPUUtilization = (float64(nodeCPURequest) / float64(cpuAllocatable.MilliValue())) * 100
+	if cpuTotal.MilliValue() > 0 {
+		np.CPUUtilization = (float64(nodeCPURequest) / float64(cpuTotal.MilliValue())) * 100
 		np.CPUPressure = getPressureLevel(np.CPUUtilization, thresholds)
 	}
 
 	// Calculate Memory pressure
-	if memAllocatable != nil && memAllocatable.Value() > 0 {
-		np.MemUtilization = (float64(nodeMemRequest) / float64(memAllocatable.Value())) * 100
+	if memTotal.Value() > 0 {
+		np.MemUtilization = (float64(nodeMemRequest) / float64(memTotal.Value())) * 100
 		np.MemPressure = getPressureLevel(np.MemUtilization, thresholds)
 	}
 
+	// Calculate how much of the raw capacity is held back from allocatable
+	np.CPUReservationRatio = reservationRatio(cpuAllocatable.MilliValue(), node.Status.Capacity.Cpu().MilliValue())
+	np.MemReservationRatio = reservationRatio(memAllocatable.Value(), node.Status.Capacity.Memory().Value())
+
 	return np
 }
 
+// reservationRatio returns 1 - allocatable/capacity, or 0 when capacity is unknown
+func reservationRatio(allocatable, capacity int64) float64 {
+	if capacity <= 0 || allocatable >= capacity {
+		return 0
+	}
+	return 1 - float64(allocatable)/float64(capacity)
+}
+
+// isDaemonSetPod reports whether the pod is controlled by a DaemonSet
+func isDaemonSetPod(pod *corev1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" && ref.Controller != nil && *ref.Controller {
+			return true
+		}
+	}
+	return false
+}
+
 // addPodResourcesForNode adds a pod's resource requests to node totals
 func addPodResourcesForNode(cpuRequest, memRequest *int64, pod *corev1.Pod) {
 	for i := range pod.Spec.Containers {
@@ -159,16 +365,22 @@ func addPodResourcesForNode(cpuRequest, memRequest *int64, pod *corev1.Pod) {
 	}
 }
 
-// calculateNamespacePressures computes pressure for all namespaces
-func calculateNamespacePressures(pressure *ClusterPressure, nodes []corev1.Node, pods []corev1.Pod, thresholds PressureThresholds) {
+// calculateNamespacePressures computes pressure for all namespaces, ordered by namespace name.
+// Namespaces present in overrides use their own thresholds for status strings.
+func calculateNamespacePressures(pressure *ClusterPressure, nodes []corev1.Node, pods []corev1.Pod, defaults PressureThresholds, overrides map[string]PressureThresholds, basis PressureBasis) {
 	// Aggregate resources per namespace
 	nsMap := aggregateNamespaceResources(pods)
 
-	// Get total allocatable to calculate percentages
-	totalAllocatable := getTotalAllocatable(nodes)
+	// Get total allocatable (or capacity) to calculate percentages
+	totalAllocatable := getTotalResources(nodes, basis)
 
 	// Convert to percentages and set status
 	for ns := range nsMap {
+		thresholds := defaults
+		if t, ok := overrides[ns]; ok {
+			thresholds = t
+		}
+
 		if totalAllocatable.CPU > 0 {
 			nsMap[ns].CPUPercent = (nsMap[ns].CPUPercent / float64(totalAllocatable.CPU)) * 100
 		}
@@ -186,6 +398,11 @@ func calculateNamespacePressures(pressure *ClusterPressure, nodes []corev1.Node,
 
 		pressure.NamespacePressures = append(pressure.NamespacePressures, *nsMap[ns])
 	}
+
+	// Map iteration order is random; sort so output is reproducible
+	sort.Slice(pressure.NamespacePressures, func(i, j int) bool {
+		return pressure.NamespacePressures[i].Namespace < pressure.NamespacePressures[j].Namespace
+	})
 }
 
 // aggregateNamespaceResources sums resource requests by namespace
@@ -224,15 +441,16 @@ type AllocatableResources struct {
 	Memory int64
 }
 
-// getTotalAllocatable sums allocatable resources across all nodes
-func getTotalAllocatable(nodes []corev1.Node) AllocatableResources {
+// getTotalResources sums allocatable (or, per basis, capacity) resources across all nodes
+func getTotalResources(nodes []corev1.Node, basis PressureBasis) AllocatableResources {
 	var total AllocatableResources
 
 	for i := range nodes {
-		if cpu := nodes[i].Status.Allocatable.Cpu(); cpu != nil {
+		list := basis.resources(&nodes[i])
+		if cpu := list.Cpu(); cpu != nil {
 			total.CPU += cpu.MilliValue()
 		}
-		if mem := nodes[i].Status.Allocatable.Memory(); mem != nil {
+		if mem := list.Memory(); mem != nil {
 			total.Memory += mem.Value()
 		}
 	}
@@ -241,13 +459,14 @@ func getTotalAllocatable(nodes []corev1.Node) AllocatableResources {
 }
 
 // calculateClusterPressure computes overall cluster pressure
-func calculateClusterPressure(pressure *ClusterPressure, nodes []corev1.Node, pods []corev1.Pod) {
+func calculateClusterPressure(pressure *ClusterPressure, nodes []corev1.Node, pods []corev1.Pod, basis PressureBasis) {
 	// Find maximum pressure across all nodes
 	maxCPUPressure, maxMemPressure := findMaxNodePressures(pressure.NodePressures)
+	pressure.OverallCPU, pressure.OverallMem = maxCPUPressure, maxMemPressure
 	pressure.Overall = combinePressureLevels(maxCPUPressure, maxMemPressure)
 
 	// Calculate cluster utilization percentages
-	totalAllocatable := getTotalAllocatable(nodes)
+	totalAllocatable := getTotalResources(nodes, basis)
 	totalRequested := getTotalRequested(pods)
 
 	if totalAllocatable.CPU > 0 {
@@ -309,14 +528,7 @@ func getPressureLevel(utilization float64, thresholds PressureThresholds) Pressu
 
 // combinePressureLevels returns the worse of two pressure levels
 func combinePressureLevels(a, b PressureLevel) PressureLevel {
-	pressureOrder := map[PressureLevel]int{
-		PressureLow:       0,
-		PressureMedium:    1,
-		PressureHigh:      2,
-		PressureSaturated: 3,
-	}
-
-	if pressureOrder[a] >= pressureOrder[b] {
+	if a.Code() >= b.Code() {
 		return a
 	}
 	return b
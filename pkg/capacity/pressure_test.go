@@ -0,0 +1,239 @@
+package capacity
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testNode(name, cpu, mem string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(mem),
+			},
+		},
+	}
+}
+
+func testPod(ns, name, node, cpu, mem string, phase corev1.PodPhase) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse(mem),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestPressureLevelCode(t *testing.T) {
+	tests := []struct {
+		level PressureLevel
+		want  int
+	}{
+		{PressureLow, 0},
+		{PressureMedium, 1},
+		{PressureHigh, 2},
+		{PressureSaturated, 3},
+		{PressureUnknown, -1},
+		{PressureLevel("bogus"), -1},
+	}
+	for _, tt := range tests {
+		if got := tt.level.Code(); got != tt.want {
+			t.Errorf("%s.Code() = %d, want %d", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestComputeClusterPressure(t *testing.T) {
+	tests := []struct {
+		name        string
+		nodes       []corev1.Node
+		pods        []corev1.Pod
+		opts        PressureOptions
+		wantOverall PressureLevel
+		wantCPU     float64
+		wantMem     float64
+	}{
+		{
+			name:        "empty cluster",
+			opts:        PressureOptions{Thresholds: DefaultPressureThresholds()},
+			wantOverall: PressureLow,
+		},
+		{
+			name:  "half used is low",
+			nodes: []corev1.Node{testNode("n1", "4", "8Gi")},
+			pods: []corev1.Pod{
+				testPod("default", "a", "n1", "2", "2Gi", corev1.PodRunning),
+			},
+			opts:        PressureOptions{Thresholds: DefaultPressureThresholds()},
+			wantOverall: PressureLow,
+			wantCPU:     50,
+			wantMem:     25,
+		},
+		{
+			name:  "memory saturated dominates",
+			nodes: []corev1.Node{testNode("n1", "4", "4Gi")},
+			pods: []corev1.Pod{
+				testPod("default", "a", "n1", "1", "4Gi", corev1.PodRunning),
+			},
+			opts:        PressureOptions{Thresholds: DefaultPressureThresholds()},
+			wantOverall: PressureSaturated,
+			wantCPU:     25,
+			wantMem:     100,
+		},
+		{
+			name:  "custom thresholds raise level",
+			nodes: []corev1.Node{testNode("n1", "4", "8Gi")},
+			pods: []corev1.Pod{
+				testPod("default", "a", "n1", "2", "1Gi", corev1.PodRunning),
+			},
+			opts: PressureOptions{Thresholds: PressureThresholds{
+				Low: 10, Medium: 20, High: 40, Saturated: 80,
+			}},
+			wantOverall: PressureHigh,
+			wantCPU:     50,
+			wantMem:     12.5,
+		},
+		{
+			name:  "terminated pods skipped by default",
+			nodes: []corev1.Node{testNode("n1", "4", "8Gi")},
+			pods: []corev1.Pod{
+				testPod("default", "job", "n1", "4", "8Gi", corev1.PodSucceeded),
+			},
+			opts:        PressureOptions{Thresholds: DefaultPressureThresholds()},
+			wantOverall: PressureLow,
+		},
+		{
+			name:  "terminated pods included on request",
+			nodes: []corev1.Node{testNode("n1", "4", "8Gi")},
+			pods: []corev1.Pod{
+				testPod("default", "job", "n1", "4", "8Gi", corev1.PodFailed),
+			},
+			opts:        PressureOptions{Thresholds: DefaultPressureThresholds(), IncludeTerminated: true},
+			wantOverall: PressureSaturated,
+			wantCPU:     100,
+			wantMem:     100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeClusterPressure(tt.nodes, tt.pods, tt.opts)
+			if got.Overall != tt.wantOverall {
+				t.Errorf("Overall = %s, want %s", got.Overall, tt.wantOverall)
+			}
+			if got.CPUUtilization != tt.wantCPU {
+				t.Errorf("CPUUtilization = %.2f, want %.2f", got.CPUUtilization, tt.wantCPU)
+			}
+			if got.MemUtilization != tt.wantMem {
+				t.Errorf("MemUtilization = %.2f, want %.2f", got.MemUtilization, tt.wantMem)
+			}
+			if len(got.NodePressures) != len(tt.nodes) {
+				t.Errorf("expected %d node pressures, got %d", len(tt.nodes), len(got.NodePressures))
+			}
+		})
+	}
+}
+
+func TestComputeClusterPressure_NamespaceStatus(t *testing.T) {
+	nodes := []corev1.Node{testNode("n1", "2", "4Gi")}
+	pods := []corev1.Pod{
+		testPod("busy", "a", "n1", "1900m", "512Mi", corev1.PodRunning),
+		testPod("quiet", "b", "n1", "100m", "128Mi", corev1.PodRunning),
+	}
+
+	got := ComputeClusterPressure(nodes, pods, PressureOptions{Thresholds: DefaultPressureThresholds()})
+	if len(got.NamespacePressures) != 2 {
+		t.Fatalf("expected 2 namespace pressures, got %d", len(got.NamespacePressures))
+	}
+
+	busy, quiet := got.NamespacePressures[0], got.NamespacePressures[1]
+	if busy.Namespace != "busy" || busy.CPUStatus == "" {
+		t.Errorf("expected 'busy' to carry a CPU status, got %+v", busy)
+	}
+	if quiet.Namespace != "quiet" || quiet.CPUStatus != "" || quiet.MemStatus != "" {
+		t.Errorf("expected 'quiet' to have no status, got %+v", quiet)
+	}
+}
+
+func TestComputeClusterPressure_DaemonSetOverhead(t *testing.T) {
+	controller := true
+	daemon := testPod("kube-system", "node-exporter", "n1", "250m", "128Mi", corev1.PodRunning)
+	daemon.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "node-exporter", Controller: &controller}}
+	app := testPod("default", "web", "n1", "1", "1Gi", corev1.PodRunning)
+	app.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d8f", Controller: &controller}}
+
+	pressure := ComputeClusterPressure(
+		[]corev1.Node{testNode("n1", "4", "8Gi")},
+		[]corev1.Pod{daemon, app},
+		PressureOptions{Thresholds: DefaultPressureThresholds()},
+	)
+
+	if len(pressure.NodePressures) != 1 {
+		t.Fatalf("expected 1 node, got %d", len(pressure.NodePressures))
+	}
+	np := pressure.NodePressures[0]
+
+	if np.DaemonSetRequest.CPU != 250 || np.DaemonSetRequest.Memory != 128*1024*1024 {
+		t.Errorf("DaemonSet bucket: got %+v", np.DaemonSetRequest)
+	}
+	if np.WorkloadRequest.CPU != 1000 || np.WorkloadRequest.Memory != 1024*1024*1024 {
+		t.Errorf("workload bucket: got %+v", np.WorkloadRequest)
+	}
+	// The split must not change the node's total utilization
+	if np.CPUUtilization != 31.25 {
+		t.Errorf("CPU utilization: got %.2f, want 31.25", np.CPUUtilization)
+	}
+}
+
+func TestComputeClusterPressure_AgainstCapacity(t *testing.T) {
+	node := testNode("n1", "8", "8Gi")
+	node.Status.Capacity = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("10"),
+		corev1.ResourceMemory: resource.MustParse("10Gi"),
+	}
+	pods := []corev1.Pod{testPod("default", "a", "n1", "6", "6Gi", corev1.PodRunning)}
+
+	allocatable := ComputeClusterPressure([]corev1.Node{node}, pods, PressureOptions{Thresholds: DefaultPressureThresholds()})
+	capacity := ComputeClusterPressure([]corev1.Node{node}, pods, PressureOptions{Thresholds: DefaultPressureThresholds(), Against: BasisCapacity})
+
+	if allocatable.CPUUtilization != 75 || allocatable.NodePressures[0].MemUtilization != 75 {
+		t.Errorf("expected 75%% of allocatable, got cluster CPU %.1f%%, node memory %.1f%%",
+			allocatable.CPUUtilization, allocatable.NodePressures[0].MemUtilization)
+	}
+	if capacity.CPUUtilization != 60 || capacity.NodePressures[0].MemUtilization != 60 {
+		t.Errorf("expected 60%% of capacity, got cluster CPU %.1f%%, node memory %.1f%%",
+			capacity.CPUUtilization, capacity.NodePressures[0].MemUtilization)
+	}
+	if capacity.NamespacePressures[0].CPUPercent != 60 {
+		t.Errorf("expected namespace share of capacity 60%%, got %.1f%%", capacity.NamespacePressures[0].CPUPercent)
+	}
+	if allocatable.Overall != PressureMedium || capacity.Overall != PressureLow {
+		t.Errorf("expected MEDIUM against allocatable and LOW against capacity, got %s and %s", allocatable.Overall, capacity.Overall)
+	}
+}
+
+func TestParsePressureBasis(t *testing.T) {
+	for _, s := range []string{"allocatable", "capacity"} {
+		if b, err := ParsePressureBasis(s); err != nil || string(b) != s {
+			t.Errorf("ParsePressureBasis(%q) = %q, %v", s, b, err)
+		}
+	}
+	if _, err := ParsePressureBasis("requests"); err == nil {
+		t.Error("expected an error for an unknown basis")
+	}
+}
@@ -0,0 +1,100 @@
+package capacity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ThresholdsAnnotation lets a namespace declare its own pressure thresholds,
+// e.g. cobrak.io/thresholds: "low=40,medium=60,high=75,saturated=90".
+// Omitted keys keep the global value.
+const ThresholdsAnnotation = "cobrak.io/thresholds"
+
+// ParseThresholds applies "key=value" pairs from spec on top of base and
+// checks that the result stays ordered (low < medium < high < saturated).
+func ParseThresholds(spec string, base PressureThresholds) (PressureThresholds, error) {
+	result := base
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return base, fmt.Errorf("invalid threshold %q: expected key=value", pair)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return base, fmt.Errorf("invalid threshold %q: %w", pair, err)
+		}
+		if value < 0 || value > 100 {
+			return base, fmt.Errorf("invalid threshold %q: must be between 0 and 100", pair)
+		}
+
+		switch strings.TrimSpace(key) {
+		case "low":
+			result.Low = value
+		case "medium":
+			result.Medium = value
+		case "high":
+			result.High = value
+		case "saturated":
+			result.Saturated = value
+		default:
+			return base, fmt.Errorf("unknown threshold %q (valid: low, medium, high, saturated)", key)
+		}
+	}
+
+	if result.Low >= result.Medium || result.Medium >= result.High || result.High >= result.Saturated {
+		return base, fmt.Errorf("thresholds must satisfy low < medium < high < saturated, got %.0f/%.0f/%.0f/%.0f",
+			result.Low, result.Medium, result.High, result.Saturated)
+	}
+
+	return result, nil
+}
+
+// Threshold preset names accepted by PresetThresholds
+const (
+	PresetConservative = "conservative"
+	PresetBalanced     = "balanced"
+	PresetAggressive   = "aggressive"
+)
+
+// PresetThresholds returns the thresholds of a named preset. Conservative
+// reports pressure early, balanced matches DefaultPressureThresholds and
+// aggressive tolerates densely packed nodes.
+func PresetThresholds(name string) (PressureThresholds, error) {
+	switch name {
+	case PresetConservative:
+		return PressureThresholds{Low: 40, Medium: 60, High: 75, Saturated: 90}, nil
+	case PresetBalanced:
+		return DefaultPressureThresholds(), nil
+	case PresetAggressive:
+		return PressureThresholds{Low: 65, Medium: 85, High: 95, Saturated: 100}, nil
+	default:
+		return PressureThresholds{}, fmt.Errorf("unknown preset %q (valid: %s, %s, %s)", name, PresetConservative, PresetBalanced, PresetAggressive)
+	}
+}
+
+// NamespaceThresholds returns per-namespace thresholds for namespaces carrying a
+// valid ThresholdsAnnotation. Invalid annotations are skipped so the namespace
+// falls back to the global thresholds.
+func NamespaceThresholds(namespaces []corev1.Namespace, base PressureThresholds) map[string]PressureThresholds {
+	overrides := make(map[string]PressureThresholds)
+	for i := range namespaces {
+		spec, ok := namespaces[i].Annotations[ThresholdsAnnotation]
+		if !ok {
+			continue
+		}
+		thresholds, err := ParseThresholds(spec, base)
+		if err != nil {
+			continue
+		}
+		overrides[namespaces[i].Name] = thresholds
+	}
+	return overrides
+}
@@ -0,0 +1,117 @@
+package capacity
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseThresholds(t *testing.T) {
+	base := DefaultPressureThresholds()
+
+	tests := []struct {
+		name    string
+		spec    string
+		want    PressureThresholds
+		wantErr bool
+	}{
+		{name: "full", spec: "low=40,medium=60,high=75,saturated=90", want: PressureThresholds{40, 60, 75, 90}},
+		{name: "partial keeps base", spec: "high=80", want: PressureThresholds{50, 75, 80, 100}},
+		{name: "spaces", spec: " low = 30 , medium=45 ", want: PressureThresholds{30, 45, 90, 100}},
+		{name: "unordered", spec: "high=60", wantErr: true},
+		{name: "unknown key", spec: "extreme=99", wantErr: true},
+		{name: "not a number", spec: "low=abc", wantErr: true},
+		{name: "out of range", spec: "saturated=120", wantErr: true},
+		{name: "missing value", spec: "low", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseThresholds(tt.spec, base)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceThresholds_AnnotationReachesHighEarlier(t *testing.T) {
+	node := testNode("n1", "10", "10Gi")
+	strict := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "strict",
+			Annotations: map[string]string{ThresholdsAnnotation: "low=40,medium=60,high=75,saturated=90"},
+		},
+	}
+	relaxed := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "relaxed"}}
+	broken := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "broken",
+			Annotations: map[string]string{ThresholdsAnnotation: "high=nope"},
+		},
+	}
+
+	// Each namespace requests 80% of cluster CPU
+	strictPod := testPod("strict", "a", "n1", "8", "1Gi", corev1.PodRunning)
+	relaxedPod := testPod("relaxed", "b", "n1", "8", "1Gi", corev1.PodRunning)
+	brokenPod := testPod("broken", "c", "n1", "8", "1Gi", corev1.PodRunning)
+
+	client := fake.NewSimpleClientset(&node, strict, relaxed, broken, &strictPod, &relaxedPod, &brokenPod)
+	pressure, err := CalculatePressure(context.Background(), client, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	statuses := make(map[string]string)
+	for _, nsp := range pressure.NamespacePressures {
+		statuses[nsp.Namespace] = nsp.CPUStatus
+	}
+
+	if statuses["strict"] == "" {
+		t.Error("expected annotated namespace to report HIGH CPU at 80% with high=75")
+	}
+	if statuses["relaxed"] != "" {
+		t.Errorf("expected namespace without annotation to use global high=90, got %q", statuses["relaxed"])
+	}
+	if statuses["broken"] != "" {
+		t.Errorf("expected invalid annotation to fall back to global thresholds, got %q", statuses["broken"])
+	}
+}
+
+func TestPresetThresholds(t *testing.T) {
+	tests := []struct {
+		name string
+		want PressureThresholds
+	}{
+		{PresetConservative, PressureThresholds{Low: 40, Medium: 60, High: 75, Saturated: 90}},
+		{PresetBalanced, DefaultPressureThresholds()},
+		{PresetAggressive, PressureThresholds{Low: 65, Medium: 85, High: 95, Saturated: 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PresetThresholds(tt.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := PresetThresholds("reckless"); err == nil {
+		t.Error("expected error for unknown preset")
+	}
+}
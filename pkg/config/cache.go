@@ -0,0 +1,25 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// contextHashLen is the number of hex characters of the context hash used as directory name
+const contextHashLen = 12
+
+// ContextCacheDir returns the per-context data directory (~/.cobrak/<context-hash>),
+// so history and snapshots of different clusters don't clobber each other.
+// The context name is hashed to keep it filesystem-safe; pass the resolved
+// context name rather than an empty string. The directory is not created.
+func ContextCacheDir(context string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("determining home directory: %w", err)
+	}
+	sum := sha256.Sum256([]byte(context))
+	return filepath.Join(home, ".cobrak", hex.EncodeToString(sum[:])[:contextHashLen]), nil
+}
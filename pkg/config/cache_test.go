@@ -0,0 +1,40 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/config"
+)
+
+func TestContextCacheDir_DistinctPerContext(t *testing.T) {
+	tempDir := t.TempDir()
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tempDir)
+
+	prod, err := config.ContextCacheDir("prod-cluster")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dev, err := config.ContextCacheDir("dev-cluster")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if prod == dev {
+		t.Errorf("expected distinct directories, both were %s", prod)
+	}
+	for _, dir := range []string{prod, dev} {
+		if filepath.Dir(dir) != filepath.Join(tempDir, ".cobrak") {
+			t.Errorf("expected %s to live directly under ~/.cobrak", dir)
+		}
+	}
+
+	again, _ := config.ContextCacheDir("prod-cluster")
+	if again != prod {
+		t.Errorf("expected a stable directory for the same context, got %s and %s", prod, again)
+	}
+}
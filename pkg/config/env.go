@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Environment variables that override settings from the config file.
+const (
+	EnvOutput             = "COBRAK_OUTPUT"
+	EnvNamespace          = "COBRAK_NAMESPACE"
+	EnvTop                = "COBRAK_TOP"
+	EnvColor              = "COBRAK_COLOR"
+	EnvThresholdLow       = "COBRAK_THRESHOLD_LOW"
+	EnvThresholdMedium    = "COBRAK_THRESHOLD_MEDIUM"
+	EnvThresholdHigh      = "COBRAK_THRESHOLD_HIGH"
+	EnvThresholdSaturated = "COBRAK_THRESHOLD_SATURATED"
+)
+
+// ApplyEnv overrides settings from COBRAK_* environment variables and re-validates
+// the pressure thresholds. Unset or empty variables leave a setting unchanged.
+// Precedence is flags > env > file > defaults, so call it before Merge.
+func (s *Settings) ApplyEnv() error {
+	if v := os.Getenv(EnvOutput); v != "" {
+		s.Output = v
+	}
+	if v := os.Getenv(EnvNamespace); v != "" {
+		if err := ValidateNamespace(v); err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvNamespace, err)
+		}
+		s.Namespace = v
+	}
+	if v := os.Getenv(EnvTop); v != "" {
+		top, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: must be a number", EnvTop, v)
+		}
+		s.Top = top
+	}
+	if v := os.Getenv(EnvColor); v != "" {
+		color, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: must be true or false", EnvColor, v)
+		}
+		s.Color = color
+	}
+
+	thresholds := []struct {
+		env    string
+		target *float64
+	}{
+		{EnvThresholdLow, &s.PressureThresholds.Low},
+		{EnvThresholdMedium, &s.PressureThresholds.Medium},
+		{EnvThresholdHigh, &s.PressureThresholds.High},
+		{EnvThresholdSaturated, &s.PressureThresholds.Saturated},
+	}
+	for _, t := range thresholds {
+		v := os.Getenv(t.env)
+		if v == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: must be a number", t.env, v)
+		}
+		*t.target = f
+	}
+
+	if err := s.PressureThresholds.Validate(); err != nil {
+		return fmt.Errorf("invalid pressure thresholds from environment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyEnv_OverridesFileSettings(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "settings.toml")
+	content := `output = "json"
+namespace = "from-file"
+top = 5
+
+[pressure_thresholds]
+low = 40.0
+medium = 60.0
+high = 80.0
+saturated = 95.0
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	t.Setenv(EnvNamespace, "from-env")
+	t.Setenv(EnvTop, "50")
+	t.Setenv(EnvColor, "false")
+	t.Setenv(EnvThresholdHigh, "85")
+
+	settings, err := LoadSettingsAt(configPath)
+	if err != nil {
+		t.Fatalf("LoadSettingsAt failed: %v", err)
+	}
+	if err := settings.ApplyEnv(); err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+
+	if settings.Output != "json" {
+		t.Errorf("expected output from file 'json', got %q", settings.Output)
+	}
+	if settings.Namespace != "from-env" {
+		t.Errorf("expected namespace from env, got %q", settings.Namespace)
+	}
+	if settings.Top != 50 {
+		t.Errorf("expected top 50, got %d", settings.Top)
+	}
+	if settings.Color {
+		t.Error("expected color disabled by env")
+	}
+	if settings.PressureThresholds.High != 85.0 {
+		t.Errorf("expected high 85.0 from env, got %.1f", settings.PressureThresholds.High)
+	}
+	if settings.PressureThresholds.Medium != 60.0 {
+		t.Errorf("expected medium 60.0 from file, got %.1f", settings.PressureThresholds.Medium)
+	}
+
+	// Flags still win over env
+	flagNamespace := "from-flag"
+	settings.Merge(FlagOverrides{Namespace: &flagNamespace})
+	if settings.Namespace != "from-flag" {
+		t.Errorf("expected namespace from flag, got %q", settings.Namespace)
+	}
+}
+
+func TestApplyEnv_Invalid(t *testing.T) {
+	tests := []struct {
+		name, env, value string
+	}{
+		{"non-numeric top", EnvTop, "many"},
+		{"non-boolean color", EnvColor, "maybe"},
+		{"non-numeric threshold", EnvThresholdLow, "low"},
+		{"out of order threshold", EnvThresholdHigh, "60"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+			if err := DefaultSettings().ApplyEnv(); err == nil {
+				t.Errorf("expected error for %s=%q", tt.env, tt.value)
+			}
+		})
+	}
+}
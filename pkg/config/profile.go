@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrProfileNotFound is returned when a named profile has no file under ~/.cobrak/profiles.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// ErrInvalidProfileName is returned when a profile name is empty or contains path elements.
+var ErrInvalidProfileName = errors.New("profile name must be a plain name without path separators")
+
+const profileExt = ".toml"
+
+// ProfileFromEnv returns the profile selected via the COBRAK_PROFILE environment variable
+func ProfileFromEnv() string {
+	return os.Getenv("COBRAK_PROFILE")
+}
+
+// ProfilesDir returns the directory holding named profiles (~/.cobrak/profiles)
+func ProfilesDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("determining home directory: %w", err)
+	}
+	return filepath.Join(home, ".cobrak", "profiles"), nil
+}
+
+// ResolveProfilePath returns the path of the named profile (~/.cobrak/profiles/<name>.toml).
+// The file is not required to exist.
+func ResolveProfilePath(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", ErrInvalidProfileName
+	}
+
+	dir, err := ProfilesDir()
+	if err != nil {
+		return "", err
+	}
+
+	return scopedConfigPath(dir, name+profileExt)
+}
+
+// LoadProfile loads settings from the named profile.
+// Unlike LoadSettingsAt, a missing profile file is an error.
+func LoadProfile(name string) (*Settings, error) {
+	path, err := ResolveProfilePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s (expected %s)", ErrProfileNotFound, name, path)
+	}
+
+	return LoadSettingsAt(path)
+}
+
+// ListProfiles returns the names of all profiles in ~/.cobrak/profiles, sorted.
+// A missing profiles directory yields an empty list.
+func ListProfiles() ([]string, error) {
+	dir, err := ProfilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading profiles directory: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != profileExt {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), profileExt))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
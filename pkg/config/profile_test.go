@@ -0,0 +1,94 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/config"
+)
+
+func writeProfile(t *testing.T, home, name, content string) {
+	t.Helper()
+	dir := filepath.Join(home, ".cobrak", "profiles")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatalf("creating profiles dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".toml"), []byte(content), 0600); err != nil {
+		t.Fatalf("writing profile: %v", err)
+	}
+}
+
+func TestLoadProfile_DistinctThresholds(t *testing.T) {
+	tempDir := t.TempDir()
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tempDir)
+
+	writeProfile(t, tempDir, "prod", `context = "prod-cluster"
+
+[pressure_thresholds]
+low = 30.0
+medium = 50.0
+high = 70.0
+saturated = 90.0
+`)
+	writeProfile(t, tempDir, "dev", `context = "dev-cluster"
+
+[pressure_thresholds]
+low = 60.0
+medium = 80.0
+high = 95.0
+saturated = 100.0
+`)
+
+	prod, err := config.LoadProfile("prod")
+	if err != nil {
+		t.Fatalf("LoadProfile(prod) failed: %v", err)
+	}
+	dev, err := config.LoadProfile("dev")
+	if err != nil {
+		t.Fatalf("LoadProfile(dev) failed: %v", err)
+	}
+
+	if prod.Context != "prod-cluster" {
+		t.Errorf("expected prod context 'prod-cluster', got %q", prod.Context)
+	}
+	if prod.PressureThresholds.High != 70.0 {
+		t.Errorf("expected prod High 70.0, got %.1f", prod.PressureThresholds.High)
+	}
+	if dev.PressureThresholds.High != 95.0 {
+		t.Errorf("expected dev High 95.0, got %.1f", dev.PressureThresholds.High)
+	}
+
+	names, err := config.ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles failed: %v", err)
+	}
+	if len(names) != 2 || names[0] != "dev" || names[1] != "prod" {
+		t.Errorf("expected [dev prod], got %v", names)
+	}
+}
+
+func TestLoadProfile_Missing(t *testing.T) {
+	tempDir := t.TempDir()
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tempDir)
+
+	_, err := config.LoadProfile("nope")
+	if !errors.Is(err, config.ErrProfileNotFound) {
+		t.Errorf("expected ErrProfileNotFound, got %v", err)
+	}
+}
+
+func TestResolveProfilePath_RejectsPaths(t *testing.T) {
+	for _, name := range []string{"", "..", "../prod", "team/prod"} {
+		if _, err := config.ResolveProfilePath(name); !errors.Is(err, config.ErrInvalidProfileName) {
+			t.Errorf("ResolveProfilePath(%q): expected ErrInvalidProfileName, got %v", name, err)
+		}
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/BurntSushi/toml"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // PressureThresholds defines the pressure level thresholds
@@ -16,30 +18,86 @@ type PressureThresholds struct {
 	Saturated float64 `toml:"saturated"`
 }
 
+// PressureLabels renames the pressure levels in text output (e.g. OK/WATCH/WARN/CRIT).
+// An empty label keeps the built-in name.
+type PressureLabels struct {
+	Low       string `toml:"low"`
+	Medium    string `toml:"medium"`
+	High      string `toml:"high"`
+	Saturated string `toml:"saturated"`
+}
+
+// PressureColors chooses the text color of each pressure level, one of
+// PressureColorNames. An empty color keeps the built-in color.
+type PressureColors struct {
+	Low       string `toml:"low"`
+	Medium    string `toml:"medium"`
+	High      string `toml:"high"`
+	Saturated string `toml:"saturated"`
+}
+
+// DisplaySettings chooses which request and limit columns the pod and
+// namespace tables show
+type DisplaySettings struct {
+	ShowRequests bool `toml:"show_requests"`
+	ShowLimits   bool `toml:"show_limits"`
+}
+
+// PressureColorNames are the colors accepted in PressureColors
+var PressureColorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// ThemeNames are the accepted values of the theme setting; the output package
+// holds the palettes
+var ThemeNames = []string{"default", "dark", "light", "mono"}
+
+// Namespace scopes used by DefaultScope when no namespace is given
+const (
+	// ScopeAll inspects all namespaces (default)
+	ScopeAll = "all"
+	// ScopeCurrent inspects the namespace of the current kubeconfig context
+	ScopeCurrent = "current"
+)
+
 // Settings represents the cobrak configuration
 type Settings struct {
 	Output             string             `toml:"output"`
 	Namespace          string             `toml:"namespace"`
+	DefaultScope       string             `toml:"default_scope"`
 	Context            string             `toml:"context"`
 	Top                int                `toml:"top"`
 	Color              bool               `toml:"color"`
+	Theme              string             `toml:"theme"`
 	PressureThresholds PressureThresholds `toml:"pressure_thresholds"`
+	PressureLabels     PressureLabels     `toml:"pressure_labels"`
+	PressureColors     PressureColors     `toml:"pressure_colors"`
+	Display            DisplaySettings    `toml:"display"`
+
+	// NamespaceDisplayThreshold is the requested percentage from which the
+	// simple pressure view lists a namespace
+	NamespaceDisplayThreshold float64 `toml:"namespace_display_threshold"`
 }
 
 // DefaultSettings returns the default configuration
 func DefaultSettings() *Settings {
 	return &Settings{
-		Output:    "text",
-		Namespace: "",
-		Context:   "",
-		Top:       20,
-		Color:     true,
+		Output:       "text",
+		Namespace:    "",
+		DefaultScope: ScopeAll,
+		Context:      "",
+		Top:          20,
+		Color:        true,
+		Theme:        "default",
 		PressureThresholds: PressureThresholds{
 			Low:       50.0,
 			Medium:    75.0,
 			High:      90.0,
 			Saturated: 100.0,
 		},
+		Display: DisplaySettings{
+			ShowRequests: true,
+			ShowLimits:   true,
+		},
+		NamespaceDisplayThreshold: 80.0,
 	}
 }
 
@@ -72,6 +130,90 @@ func (pt *PressureThresholds) Validate() error {
 	return nil
 }
 
+// Validate checks that every pressure color is empty or one of PressureColorNames
+func (pc *PressureColors) Validate() error {
+	colors := []struct{ level, color string }{
+		{"low", pc.Low},
+		{"medium", pc.Medium},
+		{"high", pc.High},
+		{"saturated", pc.Saturated},
+	}
+	for _, c := range colors {
+		if err := ValidatePressureColor(c.color); err != nil {
+			return fmt.Errorf("pressure color '%s': %w", c.level, err)
+		}
+	}
+	return nil
+}
+
+// ValidatePressureColor checks that color is empty or one of PressureColorNames
+func ValidatePressureColor(color string) error {
+	if color == "" {
+		return nil
+	}
+	for _, name := range PressureColorNames {
+		if color == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s, got %q", strings.Join(PressureColorNames, ", "), color)
+}
+
+// ValidateTheme checks that theme is one of ThemeNames.
+// An empty theme is treated as "default".
+func ValidateTheme(theme string) error {
+	if theme == "" {
+		return nil
+	}
+	for _, name := range ThemeNames {
+		if theme == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("theme must be one of %s, got %q", strings.Join(ThemeNames, ", "), theme)
+}
+
+// ValidateScope checks that scope is a supported default_scope value.
+// An empty scope is treated as ScopeAll.
+func ValidateScope(scope string) error {
+	switch scope {
+	case "", ScopeAll, ScopeCurrent:
+		return nil
+	default:
+		return fmt.Errorf("default_scope must be %q or %q, got %q", ScopeAll, ScopeCurrent, scope)
+	}
+}
+
+// ValidateNamespace checks that namespace is a valid Kubernetes namespace name
+// (an RFC 1123 label). An empty namespace means all namespaces.
+func ValidateNamespace(namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("namespace %q is not a valid Kubernetes name: %s", namespace, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ValidateNamespaceDisplayThreshold checks that the namespace display
+// threshold is a percentage between 0 and 100
+func ValidateNamespaceDisplayThreshold(threshold float64) error {
+	if threshold < 0 || threshold > 100 {
+		return fmt.Errorf("namespace_display_threshold must be between 0 and 100, got %.1f", threshold)
+	}
+	return nil
+}
+
+// ValidateContext checks that context is usable as a kubeconfig context name.
+// Context names are freeform, but a line break cannot be part of one.
+func ValidateContext(context string) error {
+	if strings.ContainsAny(context, "\r\n") {
+		return fmt.Errorf("context %q must not contain line breaks", context)
+	}
+	return nil
+}
+
 // LoadSettingsAt loads configuration from the given absolute path.
 // If the file does not exist, default settings are returned.
 func LoadSettingsAt(configPath string) (*Settings, error) {
@@ -93,19 +235,50 @@ func LoadSettingsAt(configPath string) (*Settings, error) {
 		return nil, fmt.Errorf("invalid pressure thresholds in config: %w", err)
 	}
 
+	if err := ValidateScope(settings.DefaultScope); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := ValidateNamespace(settings.Namespace); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := ValidateContext(settings.Context); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := ValidateNamespaceDisplayThreshold(settings.NamespaceDisplayThreshold); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := settings.PressureColors.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := ValidateTheme(settings.Theme); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	return settings, nil
 }
 
-// LoadSettings loads configuration using the resolved config path.
+// LoadSettings loads configuration using the resolved config path and applies
+// COBRAK_* environment overrides (see ApplyEnv).
 // Path precedence: --config flag > COBRAK_CONFIG env > ~/.cobrak/settings.toml.
-// Use LoadSettingsAt to specify an explicit resolved path.
+// Use LoadSettingsAt to specify an explicit resolved path without env overrides.
 func LoadSettings() (*Settings, error) {
-	configPath, err := ResolveConfigPath("")
-	if err != nil {
-		// If we can't determine the path, fall back to defaults
-		return DefaultSettings(), nil
+	// If we can't determine the path, fall back to defaults
+	settings := DefaultSettings()
+	if configPath, err := ResolveConfigPath(""); err == nil {
+		settings, err = LoadSettingsAt(configPath)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return LoadSettingsAt(configPath)
+	if err := settings.ApplyEnv(); err != nil {
+		return nil, err
+	}
+	return settings, nil
 }
 
 // SaveSettingsAt saves configuration to the given absolute path.
@@ -114,6 +287,24 @@ func SaveSettingsAt(configPath string, settings *Settings) error {
 	if err := settings.PressureThresholds.Validate(); err != nil {
 		return err
 	}
+	if err := ValidateScope(settings.DefaultScope); err != nil {
+		return err
+	}
+	if err := ValidateNamespace(settings.Namespace); err != nil {
+		return err
+	}
+	if err := ValidateContext(settings.Context); err != nil {
+		return err
+	}
+	if err := ValidateNamespaceDisplayThreshold(settings.NamespaceDisplayThreshold); err != nil {
+		return err
+	}
+	if err := settings.PressureColors.Validate(); err != nil {
+		return err
+	}
+	if err := ValidateTheme(settings.Theme); err != nil {
+		return err
+	}
 
 	// Create directory if it doesn't exist (private to the user)
 	configDir := filepath.Dir(configPath)
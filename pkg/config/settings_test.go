@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -720,3 +721,120 @@ saturated = 100.0
 		t.Errorf("expected Color true when omitted from TOML (default), got false")
 	}
 }
+
+func TestDefaultScopeValidation(t *testing.T) {
+	if got := DefaultSettings().DefaultScope; got != ScopeAll {
+		t.Errorf("expected default scope %q, got %q", ScopeAll, got)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "settings.toml")
+
+	settings := DefaultSettings()
+	settings.DefaultScope = ScopeCurrent
+	if err := SaveSettingsAt(configPath, settings); err != nil {
+		t.Fatalf("SaveSettingsAt failed: %v", err)
+	}
+	loaded, err := LoadSettingsAt(configPath)
+	if err != nil {
+		t.Fatalf("LoadSettingsAt failed: %v", err)
+	}
+	if loaded.DefaultScope != ScopeCurrent {
+		t.Errorf("expected default scope %q after round trip, got %q", ScopeCurrent, loaded.DefaultScope)
+	}
+
+	settings.DefaultScope = "team"
+	if err := SaveSettingsAt(configPath, settings); err == nil {
+		t.Error("expected error saving an invalid default scope")
+	}
+	if err := os.WriteFile(configPath, []byte(`default_scope = "team"`+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write test TOML file: %v", err)
+	}
+	if _, err := LoadSettingsAt(configPath); err == nil {
+		t.Error("expected error loading an invalid default scope")
+	}
+}
+
+func TestNamespaceAndContextValidation(t *testing.T) {
+	for _, ns := range []string{"", "default", "kube-system", "team-a1"} {
+		if err := ValidateNamespace(ns); err != nil {
+			t.Errorf("expected namespace %q to be valid, got %v", ns, err)
+		}
+	}
+	for _, ns := range []string{"UPPER", "bad ns!", "with space", " leading", "-dash", strings.Repeat("a", 64)} {
+		if err := ValidateNamespace(ns); err == nil {
+			t.Errorf("expected namespace %q to be rejected", ns)
+		}
+	}
+
+	if err := ValidateContext("arn:aws:eks:eu-west-1:123:cluster/Prod Cluster"); err != nil {
+		t.Errorf("expected freeform context to be valid, got %v", err)
+	}
+	if err := ValidateContext("prod\nstaging"); err == nil {
+		t.Error("expected context with a newline to be rejected")
+	}
+
+	configPath := filepath.Join(t.TempDir(), "settings.toml")
+	settings := DefaultSettings()
+	settings.Namespace = "UPPER"
+	if err := SaveSettingsAt(configPath, settings); err == nil {
+		t.Error("expected error saving an invalid namespace")
+	}
+	if err := os.WriteFile(configPath, []byte(`namespace = "bad ns"`+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write test TOML file: %v", err)
+	}
+	if _, err := LoadSettingsAt(configPath); err == nil {
+		t.Error("expected error loading an invalid namespace")
+	}
+}
+
+func TestPressureLabelsAndColors(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "settings.toml")
+	content := `[pressure_labels]
+low = "OK"
+saturated = "CRIT"
+
+[pressure_colors]
+medium = "blue"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write test TOML file: %v", err)
+	}
+	loaded, err := LoadSettingsAt(configPath)
+	if err != nil {
+		t.Fatalf("LoadSettingsAt failed: %v", err)
+	}
+	if loaded.PressureLabels.Low != "OK" || loaded.PressureLabels.Saturated != "CRIT" || loaded.PressureLabels.High != "" {
+		t.Errorf("unexpected labels: %+v", loaded.PressureLabels)
+	}
+	if loaded.PressureColors.Medium != "blue" {
+		t.Errorf("expected medium color blue, got %q", loaded.PressureColors.Medium)
+	}
+
+	loaded.PressureColors.High = "purple"
+	if err := SaveSettingsAt(configPath, loaded); err == nil {
+		t.Error("expected error saving an unknown pressure color")
+	}
+}
+
+func TestThemeValidation(t *testing.T) {
+	if got := DefaultSettings().Theme; got != "default" {
+		t.Errorf("expected default theme, got %q", got)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "settings.toml")
+	if err := os.WriteFile(configPath, []byte(`theme = "light"`+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write test TOML file: %v", err)
+	}
+	loaded, err := LoadSettingsAt(configPath)
+	if err != nil {
+		t.Fatalf("LoadSettingsAt failed: %v", err)
+	}
+	if loaded.Theme != "light" {
+		t.Errorf("expected theme light, got %q", loaded.Theme)
+	}
+
+	loaded.Theme = "neon"
+	if err := SaveSettingsAt(configPath, loaded); err == nil {
+		t.Error("expected error saving an unknown theme")
+	}
+}
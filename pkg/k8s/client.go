@@ -4,42 +4,54 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/marcgeld/cobrak/pkg/kubeconfig"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-// ResolveKubeconfig determines the kubeconfig path from explicit flag, KUBECONFIG env, or default ~/.kube/config
-func ResolveKubeconfig(explicit string) string {
-	if explicit != "" {
-		return explicit
+// loadingRules returns the kubeconfig files to load: the explicit --kubeconfig
+// path if it exists, else every entry of KUBECONFIG (merged as kubectl does),
+// else ~/.kube/config. Fallback files are not checked; see newClientConfig.
+func loadingRules(explicit string) *clientcmd.ClientConfigLoadingRules {
+	if explicit != "" && kubeconfig.NewDefaultResolver().FileExists(explicit) {
+		return &clientcmd.ClientConfigLoadingRules{ExplicitPath: explicit}
 	}
 
 	if env := os.Getenv("KUBECONFIG"); env != "" {
-		return env
+		return &clientcmd.ClientConfigLoadingRules{Precedence: filepath.SplitList(env)}
 	}
 
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return ""
+		return &clientcmd.ClientConfigLoadingRules{}
 	}
 
-	return filepath.Join(home, ".kube", "config")
+	return &clientcmd.ClientConfigLoadingRules{Precedence: []string{filepath.Join(home, ".kube", "config")}}
 }
 
-// NewRestConfig builds a REST config from kubeconfig path and context
-func NewRestConfig(kubeconfigPath, context string) (*rest.Config, error) {
-	resolvedPath := ResolveKubeconfig(kubeconfigPath)
-	if resolvedPath == "" {
-		return nil, fmt.Errorf("could not resolve kubeconfig path")
+// kubeconfigKey identifies the files loaded by loadingRules(explicit)
+func kubeconfigKey(explicit string) string {
+	rules := loadingRules(explicit)
+	if rules.ExplicitPath != "" {
+		return rules.ExplicitPath
 	}
+	return strings.Join(rules.Precedence, string(os.PathListSeparator))
+}
 
-	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: resolvedPath},
-		&clientcmd.ConfigOverrides{CurrentContext: context},
-	).ClientConfig()
+// NewRestConfig builds a REST config from kubeconfig path and context.
+// An existing explicit path is used; otherwise the KUBECONFIG entries are
+// merged, falling back to ~/.kube/config. When no file exists the error wraps
+// kubeconfig.ErrKubeconfigNotFound.
+func NewRestConfig(kubeconfigPath, context string) (*rest.Config, error) {
+	clientConfig, err := newClientConfig(kubeconfigPath, context)
+	if err != nil {
+		return nil, err
+	}
 
+	cfg, err := clientConfig.ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("building rest config: %w", err)
 	}
@@ -47,6 +59,44 @@ func NewRestConfig(kubeconfigPath, context string) (*rest.Config, error) {
 	return cfg, nil
 }
 
+// ContextNamespace returns the namespace of the given (or current) kubeconfig
+// context, or "default" when the context does not set one.
+func ContextNamespace(kubeconfigPath, context string) (string, error) {
+	clientConfig, err := newClientConfig(kubeconfigPath, context)
+	if err != nil {
+		return "", err
+	}
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return "", fmt.Errorf("reading context namespace: %w", err)
+	}
+
+	return namespace, nil
+}
+
+// newClientConfig checks that a kubeconfig exists and loads it for the given context
+func newClientConfig(kubeconfigPath, context string) (clientcmd.ClientConfig, error) {
+	rules, err := checkedLoadingRules(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		rules,
+		&clientcmd.ConfigOverrides{CurrentContext: context},
+	), nil
+}
+
+// checkedLoadingRules is loadingRules after checking with kubeconfig.DefaultResolver
+// that the explicit path or at least one fallback file exists
+func checkedLoadingRules(kubeconfigPath string) (*clientcmd.ClientConfigLoadingRules, error) {
+	if _, err := kubeconfig.NewDefaultResolver().Resolve(kubeconfigPath); err != nil {
+		return nil, fmt.Errorf("%w (use --kubeconfig, set KUBECONFIG, or create ~/.kube/config)", err)
+	}
+	return loadingRules(kubeconfigPath), nil
+}
+
 // NewClientFromConfig builds a Kubernetes client from a REST config
 func NewClientFromConfig(cfg *rest.Config) (kubernetes.Interface, error) {
 	client, err := kubernetes.NewForConfig(cfg)
@@ -60,15 +110,9 @@ func NewClientFromConfig(cfg *rest.Config) (kubernetes.Interface, error) {
 // NewClient builds a Kubernetes client from the given kubeconfig file path.
 // It returns a kubernetes.Interface so callers can substitute a fake in tests.
 func NewClient(kubeconfigPath string) (kubernetes.Interface, error) {
-	cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	cfg, err := NewRestConfig(kubeconfigPath, "")
 	if err != nil {
-		return nil, fmt.Errorf("building kubeconfig: %w", err)
+		return nil, err
 	}
-
-	client, err := kubernetes.NewForConfig(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("creating kubernetes client: %w", err)
-	}
-
-	return client, nil
+	return NewClientFromConfig(cfg)
 }
@@ -1,39 +1,165 @@
 package k8s
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/kubeconfig"
 )
 
-func TestResolveKubeconfig_Explicit(t *testing.T) {
-	got := ResolveKubeconfig("/explicit/path")
-	if got != "/explicit/path" {
-		t.Errorf("expected /explicit/path, got %s", got)
+func TestKubeconfigKey_Explicit(t *testing.T) {
+	t.Setenv("KUBECONFIG", "/env/path")
+	explicit := writeKubeconfig(t, t.TempDir(), "config", "explicit")
+
+	got := kubeconfigKey(explicit)
+	if got != explicit {
+		t.Errorf("expected %s, got %s", explicit, got)
 	}
 }
 
-func TestResolveKubeconfig_EnvFallback(t *testing.T) {
-	os.Setenv("KUBECONFIG", "/env/path")
-	defer os.Unsetenv("KUBECONFIG")
+func TestKubeconfigKey_MissingExplicitFallsBackToEnv(t *testing.T) {
+	t.Setenv("KUBECONFIG", "/env/path")
 
-	got := ResolveKubeconfig("")
+	got := kubeconfigKey("/explicit/missing")
 	if got != "/env/path" {
 		t.Errorf("expected /env/path, got %s", got)
 	}
 }
 
-func TestResolveKubeconfig_HomeFallback(t *testing.T) {
-	os.Unsetenv("KUBECONFIG")
+func TestKubeconfigKey_EnvFallback(t *testing.T) {
+	env := "/env/a" + string(os.PathListSeparator) + "/env/b"
+	t.Setenv("KUBECONFIG", env)
+
+	got := kubeconfigKey("")
+	if got != env {
+		t.Errorf("expected %s, got %s", env, got)
+	}
+}
+
+func TestKubeconfigKey_HomeFallback(t *testing.T) {
+	t.Setenv("KUBECONFIG", "")
 
 	home, err := os.UserHomeDir()
 	if err != nil {
 		t.Skip("cannot get home dir")
 	}
 
-	got := ResolveKubeconfig("")
+	got := kubeconfigKey("")
 	expected := filepath.Join(home, ".kube", "config")
 	if got != expected {
 		t.Errorf("expected %s, got %s", expected, got)
 	}
 }
+
+// writeKubeconfig writes a kubeconfig with a single context to dir/name
+func writeKubeconfig(t *testing.T, dir, name, context string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	kubeconfigYAML := `apiVersion: v1
+kind: Config
+current-context: ` + context + `
+clusters:
+- name: ` + context + `
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: ` + context + `
+  user:
+    token: secret
+contexts:
+- name: ` + context + `
+  context:
+    cluster: ` + context + `
+    user: ` + context + `
+`
+	if err := os.WriteFile(path, []byte(kubeconfigYAML), 0600); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestNewRestConfig_MissingExplicitKubeconfigFallsBack(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("KUBECONFIG", writeKubeconfig(t, dir, "config", "other-cluster"))
+
+	cfg, err := NewRestConfig(filepath.Join(dir, "missing"), "")
+	if err != nil {
+		t.Fatalf("expected fallback to KUBECONFIG, got %v", err)
+	}
+	if cfg.Host != "https://127.0.0.1:6443" {
+		t.Errorf("expected host from KUBECONFIG, got %s", cfg.Host)
+	}
+}
+
+func TestContextNames_MergesKubeconfigList(t *testing.T) {
+	dir := t.TempDir()
+	prod := writeKubeconfig(t, dir, "prod", "prod")
+	staging := writeKubeconfig(t, dir, "staging", "staging")
+	t.Setenv("KUBECONFIG", prod+string(os.PathListSeparator)+staging)
+
+	names, err := ContextNames("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 2 || names[0] != "prod" || names[1] != "staging" {
+		t.Errorf("expected contexts from both files, got %v", names)
+	}
+}
+
+func TestNewRestConfig_MissingKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("KUBECONFIG", filepath.Join(dir, "absent-a")+string(os.PathListSeparator)+filepath.Join(dir, "absent-b"))
+
+	_, err := NewRestConfig(filepath.Join(dir, "missing"), "")
+	if !errors.Is(err, kubeconfig.ErrKubeconfigNotFound) {
+		t.Fatalf("expected ErrKubeconfigNotFound, got %v", err)
+	}
+}
+
+func TestContextNamespace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	kubeconfigYAML := `apiVersion: v1
+kind: Config
+current-context: team
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: test
+  user:
+    token: secret
+contexts:
+- name: team
+  context:
+    cluster: test
+    user: test
+    namespace: payments
+- name: bare
+  context:
+    cluster: test
+    user: test
+`
+	if err := os.WriteFile(path, []byte(kubeconfigYAML), 0600); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+
+	ns, err := ContextNamespace(path, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ns != "payments" {
+		t.Errorf("expected current context namespace payments, got %q", ns)
+	}
+
+	ns, err = ContextNamespace(path, "bare")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ns != "default" {
+		t.Errorf("expected default for a context without namespace, got %q", ns)
+	}
+}
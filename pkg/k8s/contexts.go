@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// ContextClient is a Kubernetes client together with the kubeconfig context it targets
+type ContextClient struct {
+	Context string
+	Client  kubernetes.Interface
+}
+
+// ContextNames returns the names of all contexts in the kubeconfig, sorted.
+// Files are resolved as by NewRestConfig, so every KUBECONFIG entry contributes.
+func ContextNames(kubeconfigPath string) ([]string, error) {
+	rules, err := checkedLoadingRules(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rules.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+
+	names := make([]string, 0, len(raw.Contexts))
+	for name := range raw.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Clients builds (or reuses) one client per context, in the given order.
+// The first context that fails stops the build and is named in the error.
+func (f *ClientFactory) Clients(kubeconfigPath string, contexts []string) ([]ContextClient, error) {
+	clients := make([]ContextClient, 0, len(contexts))
+	for _, name := range contexts {
+		_, client, err := f.Client(kubeconfigPath, name)
+		if err != nil {
+			return nil, fmt.Errorf("context %q: %w", name, err)
+		}
+		clients = append(clients, ContextClient{Context: name, Client: client})
+	}
+	return clients, nil
+}
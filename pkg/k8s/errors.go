@@ -0,0 +1,31 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ExplainError returns actionable guidance for API errors whose raw message is
+// hard to act on (currently RBAC denials), or "" when there is nothing to add.
+// cobrak only reads cluster state, so the verbs it needs are always get/list.
+func ExplainError(err error) string {
+	if !apierrors.IsForbidden(err) {
+		return ""
+	}
+
+	resource := "the requested resource"
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		if details := status.Status().Details; details != nil && details.Kind != "" {
+			resource = details.Kind
+			if details.Group != "" {
+				resource += "." + details.Group
+			}
+		}
+	}
+
+	return fmt.Sprintf("permission denied: your user needs get/list %s (try `kubectl auth can-i list %s`, or ask a cluster admin for a role that grants it)",
+		resource, resource)
+}
@@ -0,0 +1,40 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestExplainError_ForbiddenNodesList(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, "", errors.New(`User "dev" cannot list resource "nodes"`))
+	err := fmt.Errorf("listing nodes: %w", forbidden)
+
+	got := ExplainError(err)
+	if !strings.Contains(got, "get/list nodes") {
+		t.Errorf("expected guidance mentioning get/list nodes, got %q", got)
+	}
+	if !strings.Contains(got, "kubectl auth can-i list nodes") {
+		t.Errorf("expected a kubectl auth can-i hint, got %q", got)
+	}
+}
+
+func TestExplainError_GroupedResource(t *testing.T) {
+	err := apierrors.NewForbidden(schema.GroupResource{Group: "autoscaling", Resource: "horizontalpodautoscalers"}, "", errors.New("denied"))
+	if got := ExplainError(err); !strings.Contains(got, "get/list horizontalpodautoscalers.autoscaling") {
+		t.Errorf("expected group-qualified resource, got %q", got)
+	}
+}
+
+func TestExplainError_OtherErrors(t *testing.T) {
+	if got := ExplainError(errors.New("connection refused")); got != "" {
+		t.Errorf("expected no guidance for a plain error, got %q", got)
+	}
+	if got := ExplainError(nil); got != "" {
+		t.Errorf("expected no guidance for nil, got %q", got)
+	}
+}
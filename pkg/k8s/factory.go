@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	"sync"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ClientFactory builds Kubernetes clients and caches them per kubeconfig path and
+// context, so repeated refreshes within one process reuse the same client.
+// The cache lives on the factory rather than in package state; create one per run.
+type ClientFactory struct {
+	mu      sync.Mutex
+	entries map[clientKey]clientEntry
+	build   func(kubeconfigPath, context string) (*rest.Config, kubernetes.Interface, error)
+}
+
+type clientKey struct {
+	kubeconfig string
+	context    string
+}
+
+type clientEntry struct {
+	cfg    *rest.Config
+	client kubernetes.Interface
+}
+
+// NewClientFactory returns a factory building clients with NewRestConfig and NewClientFromConfig
+func NewClientFactory() *ClientFactory {
+	return &ClientFactory{
+		entries: make(map[clientKey]clientEntry),
+		build:   buildRestConfigAndClient,
+	}
+}
+
+// Client returns the REST config and client for kubeconfigPath and context,
+// building them on first use. Failed builds are not cached.
+func (f *ClientFactory) Client(kubeconfigPath, context string) (*rest.Config, kubernetes.Interface, error) {
+	key := clientKey{kubeconfig: kubeconfigKey(kubeconfigPath), context: context}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if e, ok := f.entries[key]; ok {
+		return e.cfg, e.client, nil
+	}
+
+	cfg, client, err := f.build(kubeconfigPath, context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f.entries[key] = clientEntry{cfg: cfg, client: client}
+	return cfg, client, nil
+}
+
+// buildRestConfigAndClient is the default ClientFactory builder
+func buildRestConfigAndClient(kubeconfigPath, context string) (*rest.Config, kubernetes.Interface, error) {
+	cfg, err := NewRestConfig(kubeconfigPath, context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := NewClientFromConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, client, nil
+}
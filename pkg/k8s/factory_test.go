@@ -0,0 +1,96 @@
+package k8s
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func newCountingFactory(builds *int, err error) *ClientFactory {
+	f := NewClientFactory()
+	f.build = func(_, _ string) (*rest.Config, kubernetes.Interface, error) {
+		*builds++
+		if err != nil {
+			return nil, nil, err
+		}
+		return &rest.Config{}, fake.NewSimpleClientset(), nil
+	}
+	return f
+}
+
+func TestClientFactory_CachesPerParams(t *testing.T) {
+	builds := 0
+	f := newCountingFactory(&builds, nil)
+
+	_, first, err := f.Client("/tmp/kubeconfig", "prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, second, err := f.Client("/tmp/kubeconfig", "prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first != second {
+		t.Error("expected the same client instance for identical params")
+	}
+	if builds != 1 {
+		t.Errorf("expected 1 build, got %d", builds)
+	}
+
+	_, other, err := f.Client("/tmp/kubeconfig", "dev")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == first {
+		t.Error("expected a different client for a different context")
+	}
+	if builds != 2 {
+		t.Errorf("expected 2 builds, got %d", builds)
+	}
+}
+
+func TestClientFactory_DoesNotCacheErrors(t *testing.T) {
+	builds := 0
+	f := newCountingFactory(&builds, errors.New("boom"))
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := f.Client("/tmp/kubeconfig", "prod"); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	if builds != 2 {
+		t.Errorf("expected failed builds to be retried, got %d builds", builds)
+	}
+}
+
+func TestClientFactory_SeparateInstances(t *testing.T) {
+	builds := 0
+	a := newCountingFactory(&builds, nil)
+	b := newCountingFactory(&builds, nil)
+
+	_, ca, _ := a.Client("/tmp/kubeconfig", "prod")
+	_, cb, _ := b.Client("/tmp/kubeconfig", "prod")
+	if ca == cb {
+		t.Error("expected factories not to share a cache")
+	}
+}
+
+func TestClientFactory_ClientsPerContext(t *testing.T) {
+	builds := 0
+	f := newCountingFactory(&builds, nil)
+
+	clients, err := f.Clients("/tmp/kubeconfig", []string{"prod", "staging"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clients) != 2 || clients[0].Context != "prod" || clients[1].Context != "staging" {
+		t.Fatalf("expected clients for prod and staging in order, got %+v", clients)
+	}
+	if clients[0].Client == clients[1].Client {
+		t.Error("expected a distinct client per context")
+	}
+}
@@ -0,0 +1,72 @@
+// Package logging provides a small leveled logger for diagnostics on stderr.
+// Data goes to stdout; anything written here is meant for humans debugging a run.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Verbosity levels selected with -v / -vv
+const (
+	LevelInfo  = 1
+	LevelDebug = 2
+)
+
+// Logger writes messages at or below its verbosity level
+type Logger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level int
+}
+
+// New returns a logger writing to w that emits messages up to level
+func New(w io.Writer, level int) *Logger {
+	return &Logger{w: w, level: level}
+}
+
+// Discard returns a logger that drops every message
+func Discard() *Logger {
+	return New(io.Discard, 0)
+}
+
+// Enabled reports whether messages at level are emitted
+func (l *Logger) Enabled(level int) bool {
+	return l != nil && l.level >= level
+}
+
+// Infof logs at LevelInfo (-v), e.g. skipped objects and fallbacks
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.logf(LevelInfo, format, args...)
+}
+
+// Debugf logs at LevelDebug (-vv), e.g. optional lookups that failed
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.logf(LevelDebug, format, args...)
+}
+
+func (l *Logger) logf(level int, format string, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.w, format+"\n", args...)
+}
+
+type contextKey struct{}
+
+// WithLogger returns a copy of ctx carrying l
+func WithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, or a discarding logger
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(contextKey{}).(*Logger); ok && l != nil {
+		return l
+	}
+	return Discard()
+}
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLogger_Levels(t *testing.T) {
+	tests := []struct {
+		level     int
+		wantInfo  bool
+		wantDebug bool
+	}{
+		{level: 0},
+		{level: LevelInfo, wantInfo: true},
+		{level: LevelDebug, wantInfo: true, wantDebug: true},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		l := New(&buf, tt.level)
+		l.Infof("info %d", 1)
+		l.Debugf("debug %d", 2)
+
+		out := buf.String()
+		if got := strings.Contains(out, "info 1\n"); got != tt.wantInfo {
+			t.Errorf("level %d: info logged = %v, want %v (output %q)", tt.level, got, tt.wantInfo, out)
+		}
+		if got := strings.Contains(out, "debug 2\n"); got != tt.wantDebug {
+			t.Errorf("level %d: debug logged = %v, want %v (output %q)", tt.level, got, tt.wantDebug, out)
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if l := FromContext(context.Background()); l == nil || l.Enabled(LevelInfo) {
+		t.Error("expected a discarding logger when none is set")
+	}
+
+	var buf bytes.Buffer
+	ctx := WithLogger(context.Background(), New(&buf, LevelInfo))
+	FromContext(ctx).Infof("hello")
+	if buf.String() != "hello\n" {
+		t.Errorf("expected message from context logger, got %q", buf.String())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,6 +18,11 @@ func AnalyzeNode(ctx context.Context, client kubernetes.Interface, nodeName stri
 		return nil, fmt.Errorf("getting node: %w", err)
 	}
 
+	return AnalyzeNodeFromObject(node), nil
+}
+
+// AnalyzeNodeFromObject builds node information from an already-fetched node object
+func AnalyzeNodeFromObject(node *corev1.Node) *NodeInfo {
 	info := &NodeInfo{
 		NodeName:       node.Name,
 		OS:             node.Status.NodeInfo.OperatingSystem,
@@ -43,27 +49,52 @@ func AnalyzeNode(ctx context.Context, client kubernetes.Interface, nodeName stri
 	// Initialize filesystem latency
 	info.FilesystemLatency = analyzeFilesystemLatency(node)
 
-	return info, nil
+	// Keep every reported condition, not just memory pressure
+	info.Conditions = extractConditions(node)
+	info.Health = NodeHealthFromObject(node).Status
+
+	return info
 }
 
-// AnalyzeAllNodes analyzes all nodes in the cluster
+// extractConditions copies the node's status conditions in reported order
+func extractConditions(node *corev1.Node) []NodeConditionSummary {
+	if len(node.Status.Conditions) == 0 {
+		return nil
+	}
+	conditions := make([]NodeConditionSummary, 0, len(node.Status.Conditions))
+	for _, c := range node.Status.Conditions {
+		conditions = append(conditions, NodeConditionSummary{
+			Type:    string(c.Type),
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+	}
+	return conditions
+}
+
+// AnalyzeAllNodes analyzes all nodes in the cluster using a single node list call
 func AnalyzeAllNodes(ctx context.Context, client kubernetes.Interface) ([]NodeInfo, error) {
+	nodeInfos, _, err := AnalyzeAllNodesWithHealth(ctx, client)
+	return nodeInfos, err
+}
+
+// AnalyzeAllNodesWithHealth is AnalyzeAllNodes that also evaluates the health
+// of every node from the same list call, keyed by node name
+func AnalyzeAllNodesWithHealth(ctx context.Context, client kubernetes.Interface) ([]NodeInfo, map[string]*NodeHealthStatus, error) {
 	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("listing nodes: %w", err)
+		return nil, nil, fmt.Errorf("listing nodes: %w", err)
 	}
 
-	var nodeInfos []NodeInfo
-	for _, node := range nodes.Items {
-		info, err := AnalyzeNode(ctx, client, node.Name)
-		if err != nil {
-			// Log but continue with other nodes
-			continue
-		}
-		nodeInfos = append(nodeInfos, *info)
+	nodeInfos := make([]NodeInfo, 0, len(nodes.Items))
+	health := make(map[string]*NodeHealthStatus, len(nodes.Items))
+	for i := range nodes.Items {
+		nodeInfos = append(nodeInfos, *AnalyzeNodeFromObject(&nodes.Items[i]))
+		health[nodes.Items[i].Name] = NodeHealthFromObject(&nodes.Items[i])
 	}
 
-	return nodeInfos, nil
+	return nodeInfos, health, nil
 }
 
 // extractCPUInfo extracts CPU information from node
@@ -83,6 +114,9 @@ func extractCPUInfo(node *corev1.Node) CPUInfo {
 		cpuInfo.Count = int(cpu.Value())
 		cpuInfo.Capacity = cpu.MilliValue()
 	}
+	if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+		cpuInfo.Allocatable = cpu.MilliValue()
+	}
 
 	return cpuInfo
 }
@@ -219,8 +253,9 @@ func analyzeMemoryPressure(node *corev1.Node) MemoryPressure {
 	// Check for memory pressure conditions
 	memPressure.Pressure = getMemoryPressureStatus(node)
 
-	// Calculate memory utilization ratio
-	memPressure.UtilizationRatio = calculateMemoryUtilization(node, memPressure.Total)
+	// Live utilization needs node metrics (see ApplyNodeMetrics); the node
+	// object only tells how much of the capacity is reserved
+	memPressure.ReservedRatio = calculateMemoryReservation(node)
 
 	return memPressure
 }
@@ -247,9 +282,11 @@ func getMemoryPressureStatus(node *corev1.Node) string {
 	return "LOW"
 }
 
-// calculateMemoryUtilization calculates memory utilization ratio
-func calculateMemoryUtilization(node *corev1.Node, totalMemory int64) float64 {
-	if totalMemory <= 0 || node.Status.Allocatable == nil {
+// calculateMemoryReservation returns the share of memory capacity that is
+// not allocatable to pods
+func calculateMemoryReservation(node *corev1.Node) float64 {
+	capacity, ok := node.Status.Capacity[corev1.ResourceMemory]
+	if !ok || capacity.Value() <= 0 {
 		return 0.0
 	}
 
@@ -258,12 +295,12 @@ func calculateMemoryUtilization(node *corev1.Node, totalMemory int64) float64 {
 		return 0.0
 	}
 
-	allocated := totalMemory - allocatable.Value()
-	if allocated <= 0 {
+	reserved := capacity.Value() - allocatable.Value()
+	if reserved <= 0 {
 		return 0.0
 	}
 
-	return float64(allocated) / float64(totalMemory)
+	return float64(reserved) / float64(capacity.Value())
 }
 
 // analyzeFilesystemLatency analyzes filesystem information
@@ -293,10 +330,17 @@ func GetNodeHealthStatus(ctx context.Context, client kubernetes.Interface, nodeN
 		return nil, fmt.Errorf("getting node: %w", err)
 	}
 
+	return NodeHealthFromObject(node), nil
+}
+
+// NodeHealthFromObject evaluates health from an already-fetched node object
+func NodeHealthFromObject(node *corev1.Node) *NodeHealthStatus {
+	now := time.Now()
 	status := &NodeHealthStatus{
-		NodeName: node.Name,
-		Status:   "HEALTHY",
-		Issues:   []string{},
+		NodeName:  node.Name,
+		Status:    "HEALTHY",
+		Issues:    []string{},
+		Timestamp: now.Unix(),
 	}
 
 	// Check node conditions
@@ -305,26 +349,52 @@ func GetNodeHealthStatus(ctx context.Context, client kubernetes.Interface, nodeN
 			continue
 		}
 
+		var issue string
 		switch condition.Type {
 		case corev1.NodeReady:
 			if condition.Status != corev1.ConditionTrue {
 				status.Status = "CRITICAL"
-				status.Issues = append(status.Issues, "Node not ready")
+				issue = "Node not ready"
 			}
 		case corev1.NodeMemoryPressure:
 			status.Status = "WARNING"
-			status.Issues = append(status.Issues, "Memory pressure detected")
+			issue = "Memory pressure detected"
 		case corev1.NodeDiskPressure:
 			status.Status = "WARNING"
-			status.Issues = append(status.Issues, "Disk pressure detected")
+			issue = "Disk pressure detected"
 		case corev1.NodePIDPressure:
 			status.Status = "WARNING"
-			status.Issues = append(status.Issues, "PID pressure detected")
+			issue = "PID pressure detected"
 		case corev1.NodeNetworkUnavailable:
 			status.Status = "WARNING"
-			status.Issues = append(status.Issues, "Network unavailable")
+			issue = "Network unavailable"
+		}
+		if issue == "" {
+			continue
 		}
+
+		// Say how long the condition has held, when the API reports it
+		if !condition.LastTransitionTime.IsZero() {
+			issue = fmt.Sprintf("%s (for %s)", issue, HumanizeDuration(now.Sub(condition.LastTransitionTime.Time)))
+		}
+		status.Issues = append(status.Issues, issue)
 	}
 
-	return status, nil
+	return status
+}
+
+// HumanizeDuration renders d in its largest whole unit, e.g. "45s", "12m", "3h" or "2d"
+func HumanizeDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d > 0:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	default:
+		return "0s"
+	}
 }
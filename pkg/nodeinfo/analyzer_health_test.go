@@ -1,7 +1,9 @@
 package nodeinfo
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -195,3 +197,50 @@ func TestMultipleNodeAnalysis(t *testing.T) {
 		}
 	}
 }
+
+// TestNodeHealthFromObject_ConditionAge tests that failing conditions report how long they have held
+func TestNodeHealthFromObject_ConditionAge(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "pressured"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:               corev1.NodeMemoryPressure,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+			},
+		},
+	}
+
+	health := NodeHealthFromObject(node)
+	if health.Timestamp == 0 {
+		t.Error("expected analysis timestamp to be set")
+	}
+	if len(health.Issues) != 1 {
+		t.Fatalf("expected 1 issue, got %v", health.Issues)
+	}
+	if !strings.Contains(health.Issues[0], "(for 1h)") {
+		t.Errorf("expected condition age '1h' in issue, got %q", health.Issues[0])
+	}
+}
+
+// TestHumanizeDuration tests duration rendering in the largest whole unit
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{45 * time.Second, "45s"},
+		{90 * time.Second, "1m"},
+		{time.Hour + 5*time.Minute, "1h"},
+		{50 * time.Hour, "2d"},
+	}
+
+	for _, tt := range tests {
+		if got := HumanizeDuration(tt.d); got != tt.want {
+			t.Errorf("HumanizeDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -7,7 +7,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestAnalyzeNode(t *testing.T) {
@@ -83,6 +85,39 @@ func TestAnalyzeNode(t *testing.T) {
 	}
 }
 
+func TestAnalyzeAllNodes_NoExtraGets(t *testing.T) {
+	var objs []runtime.Object
+	for _, name := range []string{"node-a", "node-b", "node-c"} {
+		objs = append(objs, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	client := fake.NewSimpleClientset(objs...)
+
+	var gets, lists int
+	client.PrependReactor("get", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+	client.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		lists++
+		return false, nil, nil
+	})
+
+	infos, err := AnalyzeAllNodes(context.Background(), client)
+	if err != nil {
+		t.Fatalf("AnalyzeAllNodes failed: %v", err)
+	}
+
+	if len(infos) != 3 {
+		t.Errorf("Expected 3 node infos, got %d", len(infos))
+	}
+	if lists != 1 {
+		t.Errorf("Expected exactly 1 node list call, got %d", lists)
+	}
+	if gets != 0 {
+		t.Errorf("Expected no node get calls, got %d", gets)
+	}
+}
+
 func TestGetNodeHealthStatus(t *testing.T) {
 	client := fake.NewSimpleClientset()
 
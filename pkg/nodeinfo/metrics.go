@@ -0,0 +1,100 @@
+package nodeinfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// NodeUsage is the live resource usage of a node as reported by metrics-server
+type NodeUsage struct {
+	NodeName  string
+	CPUUsage  resource.Quantity
+	MemUsage  resource.Quantity // working set
+	Timestamp time.Time
+}
+
+// NodeMetricsReader is the interface for fetching node metrics.
+type NodeMetricsReader interface {
+	NodeMetrics(ctx context.Context) ([]NodeUsage, error)
+	IsAvailable(ctx context.Context) (bool, error)
+}
+
+// nodeMetricsReaderImpl is the production implementation of NodeMetricsReader.
+type nodeMetricsReaderImpl struct {
+	client metricsclient.Interface
+}
+
+// NewNodeMetricsReaderFromConfig creates a NodeMetricsReader from a rest.Config.
+func NewNodeMetricsReaderFromConfig(cfg *rest.Config) (NodeMetricsReader, error) {
+	mc, err := metricsclient.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating metrics client: %w", err)
+	}
+	return &nodeMetricsReaderImpl{client: mc}, nil
+}
+
+// IsAvailable checks if node metrics are served by the metrics.k8s.io API.
+func (m *nodeMetricsReaderImpl) IsAvailable(ctx context.Context) (bool, error) {
+	_, err := m.client.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// NodeMetrics fetches actual CPU/memory usage for every node.
+func (m *nodeMetricsReaderImpl) NodeMetrics(ctx context.Context) ([]NodeUsage, error) {
+	nodeMetrics, err := m.client.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing node metrics: %w", err)
+	}
+
+	usages := make([]NodeUsage, 0, len(nodeMetrics.Items))
+	for _, nm := range nodeMetrics.Items {
+		usages = append(usages, NodeUsage{
+			NodeName:  nm.Name,
+			CPUUsage:  nm.Usage.Cpu().DeepCopy(),
+			MemUsage:  nm.Usage[corev1.ResourceMemory].DeepCopy(),
+			Timestamp: nm.Timestamp.Time,
+		})
+	}
+	return usages, nil
+}
+
+// ApplyNodeMetrics fills the live memory usage of infos from reader. Nodes
+// without a metrics sample are left as they are.
+func ApplyNodeMetrics(ctx context.Context, reader NodeMetricsReader, infos []NodeInfo) error {
+	usages, err := reader.NodeMetrics(ctx)
+	if err != nil {
+		return err
+	}
+
+	byNode := make(map[string]NodeUsage, len(usages))
+	for _, u := range usages {
+		byNode[u.NodeName] = u
+	}
+
+	for i := range infos {
+		if u, ok := byNode[infos[i].NodeName]; ok {
+			applyMemoryUsage(&infos[i].MemoryPressure, u.MemUsage.Value())
+		}
+	}
+	return nil
+}
+
+// applyMemoryUsage derives used, available and utilization from the measured working set
+func applyMemoryUsage(mp *MemoryPressure, used int64) {
+	mp.Used = used
+	mp.Available = max(mp.Total-used, 0)
+	if mp.Total > 0 {
+		mp.UtilizationRatio = float64(used) / float64(mp.Total)
+	}
+	mp.HasUsage = true
+}
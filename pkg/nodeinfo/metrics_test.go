@@ -0,0 +1,78 @@
+package nodeinfo
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeNodeMetricsReader is a test double for NodeMetricsReader.
+type fakeNodeMetricsReader struct {
+	usages []NodeUsage
+}
+
+func (f *fakeNodeMetricsReader) IsAvailable(_ context.Context) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeNodeMetricsReader) NodeMetrics(_ context.Context) ([]NodeUsage, error) {
+	return f.usages, nil
+}
+
+func memoryNode(name, capacity, allocatable string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Capacity:    corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(capacity)},
+			Allocatable: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(allocatable)},
+		},
+	}
+}
+
+func TestApplyNodeMetrics_UtilizationFromUsage(t *testing.T) {
+	infos := []NodeInfo{
+		*AnalyzeNodeFromObject(memoryNode("busy", "16Gi", "15Gi")),
+		*AnalyzeNodeFromObject(memoryNode("unreported", "16Gi", "15Gi")),
+	}
+
+	// The node object alone only describes reservation, not live usage
+	if infos[0].MemoryPressure.HasUsage || infos[0].MemoryPressure.UtilizationRatio != 0 {
+		t.Fatalf("expected no utilization before metrics, got %+v", infos[0].MemoryPressure)
+	}
+	if got := infos[0].MemoryPressure.ReservedRatio; math.Abs(got-1.0/16) > 1e-9 {
+		t.Errorf("expected reserved ratio 1/16, got %f", got)
+	}
+
+	reader := &fakeNodeMetricsReader{usages: []NodeUsage{
+		{NodeName: "busy", MemUsage: resource.MustParse("6Gi")},
+	}}
+	if err := ApplyNodeMetrics(context.Background(), reader, infos); err != nil {
+		t.Fatalf("ApplyNodeMetrics failed: %v", err)
+	}
+
+	busy := infos[0].MemoryPressure
+	if !busy.HasUsage {
+		t.Fatal("expected usage to be applied to the busy node")
+	}
+	if math.Abs(busy.UtilizationRatio-6.0/15) > 1e-9 {
+		t.Errorf("expected utilization 6/15 of allocatable, got %f", busy.UtilizationRatio)
+	}
+	if want := int64(9 << 30); busy.Available != want {
+		t.Errorf("expected 9Gi available, got %d", busy.Available)
+	}
+	if infos[1].MemoryPressure.HasUsage {
+		t.Error("expected a node without a metrics sample to stay without usage")
+	}
+
+	if got := RenderNodeInfo(&infos[0]); !strings.Contains(got, "Utilization: 40.0%") {
+		t.Errorf("expected measured utilization in output, got:\n%s", got)
+	}
+	if got := RenderNodeInfo(&infos[1]); !strings.Contains(got, "Utilization: n/a") {
+		t.Errorf("expected n/a utilization without metrics, got:\n%s", got)
+	}
+}
@@ -3,6 +3,8 @@ package nodeinfo
 import (
 	"fmt"
 	"strings"
+	"text/tabwriter"
+	"time"
 )
 
 // RenderNodeInfo renders detailed node information
@@ -36,7 +38,15 @@ func RenderNodeInfo(info *NodeInfo) string {
 	// Memory Pressure
 	sb.WriteString("  Memory Pressure:\n")
 	sb.WriteString(fmt.Sprintf("    Total: %.2f GB\n", float64(info.MemoryPressure.Total)/(1024*1024*1024)))
-	sb.WriteString(fmt.Sprintf("    Utilization: %.1f%%\n", info.MemoryPressure.UtilizationRatio*100))
+	if info.MemoryPressure.HasUsage {
+		sb.WriteString(fmt.Sprintf("    Utilization: %.1f%% (%.2f GB used)\n",
+			info.MemoryPressure.UtilizationRatio*100, float64(info.MemoryPressure.Used)/(1024*1024*1024)))
+	} else {
+		sb.WriteString("    Utilization: n/a (requires metrics-server)\n")
+	}
+	if info.MemoryPressure.ReservedRatio > 0 {
+		sb.WriteString(fmt.Sprintf("    Reserved: %.1f%% of capacity\n", info.MemoryPressure.ReservedRatio*100))
+	}
 	sb.WriteString(fmt.Sprintf("    Pressure: %s\n", info.MemoryPressure.Pressure))
 	if info.MemoryPressure.PageCacheRatio > 0 {
 		sb.WriteString(fmt.Sprintf("    Page Cache Ratio: %.1f%%\n", info.MemoryPressure.PageCacheRatio*100))
@@ -58,9 +68,52 @@ func RenderNodeInfo(info *NodeInfo) string {
 	sb.WriteString("  Virtualization:\n")
 	sb.WriteString(fmt.Sprintf("    Type: %s\n", info.VirtualizationType))
 
+	// Conditions
+	if len(info.Conditions) > 0 {
+		sb.WriteString("\n  Conditions:\n")
+		w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "    TYPE\tSTATUS\tREASON\tMESSAGE")
+		for _, c := range info.Conditions {
+			fmt.Fprintf(w, "    %s\t%s\t%s\t%s\n", c.Type, c.Status, valueOrDash(c.Reason), valueOrDash(c.Message))
+		}
+		w.Flush()
+	}
+
 	return strings.TrimRight(sb.String(), "\n")
 }
 
+// RenderNodeInfoSummary renders the key facts of a node in a few lines: health,
+// allocatable CPU and memory, GPUs and container runtime
+func RenderNodeInfoSummary(info *NodeInfo) string {
+	var sb strings.Builder
+
+	gpuStatus := "No"
+	if info.GPU.Available {
+		gpuStatus = fmt.Sprintf("Yes (%d)", len(info.GPU.GPUs))
+	}
+
+	sb.WriteString(fmt.Sprintf("Node: %s [%s]\n", info.NodeName, info.Health))
+	sb.WriteString(fmt.Sprintf("  Allocatable: CPU %dm, Memory %.2f GB\n",
+		info.CPU.Allocatable, float64(info.MemoryPressure.Total)/(1024*1024*1024)))
+	sb.WriteString(fmt.Sprintf("  GPU: %s | Runtime: %s %s\n", gpuStatus, info.ContainerRuntime.Name, info.ContainerRuntime.Version))
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// RenderMultipleNodeInfoSummary renders RenderNodeInfoSummary for each node,
+// separated by blank lines
+func RenderMultipleNodeInfoSummary(infos []NodeInfo) string {
+	if len(infos) == 0 {
+		return "No nodes found."
+	}
+
+	blocks := make([]string, len(infos))
+	for i := range infos {
+		blocks[i] = RenderNodeInfoSummary(&infos[i])
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
 // RenderNodeInfoCompact renders a compact version of node information
 func RenderNodeInfoCompact(info *NodeInfo) string {
 	var sb strings.Builder
@@ -95,7 +148,11 @@ func RenderNodeHealth(status *NodeHealthStatus) string {
 		statusSymbol = "✗"
 	}
 
-	sb.WriteString(fmt.Sprintf("%s Node: %s [%s]\n", statusSymbol, status.NodeName, status.Status))
+	sb.WriteString(fmt.Sprintf("%s Node: %s [%s]", statusSymbol, status.NodeName, status.Status))
+	if status.Timestamp > 0 {
+		sb.WriteString(fmt.Sprintf(" (checked %s ago)", HumanizeDuration(time.Since(time.Unix(status.Timestamp, 0)))))
+	}
+	sb.WriteString("\n")
 
 	if len(status.Issues) > 0 {
 		sb.WriteString("  Issues:\n")
@@ -139,3 +196,11 @@ func RenderMultipleNodeInfoCompact(infos []NodeInfo) string {
 
 	return strings.TrimRight(sb.String(), "\n")
 }
+
+// valueOrDash returns "-" for empty strings so table columns stay aligned
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -59,6 +61,39 @@ func TestRenderNodeInfo(t *testing.T) {
 }
 
 // TestRenderNodeHealth tests node health status rendering
+func TestRenderNodeInfoSummary(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{ContainerRuntimeVersion: "containerd://1.7.2"},
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3800m"),
+				corev1.ResourceMemory: resource.MustParse("15Gi"),
+			},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	result := RenderNodeInfoSummary(AnalyzeNodeFromObject(node))
+	for _, want := range []string{"worker-1 [WARNING]", "CPU 3800m", "Memory 15.00 GB", "containerd 1.7.2"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in summary, got:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "Filesystem") || strings.Contains(result, "Root FS") {
+		t.Errorf("expected no filesystem section in summary, got:\n%s", result)
+	}
+	if lines := strings.Count(result, "\n") + 1; lines > 4 {
+		t.Errorf("expected at most 4 lines per node, got %d:\n%s", lines, result)
+	}
+}
+
 func TestRenderNodeHealth(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -233,6 +268,20 @@ func TestRenderNodeHealth_WithMemoryPressure(t *testing.T) {
 	}
 }
 
+// TestRenderNodeHealth_CheckedAgo tests that the analysis time is rendered relative to now
+func TestRenderNodeHealth_CheckedAgo(t *testing.T) {
+	status := &NodeHealthStatus{
+		NodeName:  "worker-1",
+		Status:    "HEALTHY",
+		Timestamp: time.Now().Add(-2 * time.Minute).Unix(),
+	}
+
+	result := RenderNodeHealth(status)
+	if !strings.Contains(result, "checked 2m ago") {
+		t.Errorf("expected 'checked 2m ago' in output, got: %s", result)
+	}
+}
+
 // TestRenderMultipleNodeInfoCompact_Empty tests compact rendering with empty list
 func TestRenderMultipleNodeInfoCompact_Empty(t *testing.T) {
 	var nodes []NodeInfo
@@ -285,3 +334,36 @@ func TestRenderNodeInfo_AllArchitectures(t *testing.T) {
 		})
 	}
 }
+
+// TestRenderNodeInfo_Conditions tests that every node condition is rendered
+func TestRenderNodeInfo_Conditions(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Reason: "KubeletReady", Message: "kubelet is posting ready status"},
+				{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasDiskPressure", Message: "kubelet has disk pressure"},
+				{Type: corev1.NodePIDPressure, Status: corev1.ConditionFalse, Reason: "KubeletHasSufficientPID"},
+			},
+		},
+	}
+
+	info := AnalyzeNodeFromObject(node)
+	if len(info.Conditions) != 3 {
+		t.Fatalf("expected 3 conditions, got %d", len(info.Conditions))
+	}
+
+	result := RenderNodeInfo(info)
+	if !strings.Contains(result, "Conditions:") {
+		t.Fatalf("expected conditions section, got:\n%s", result)
+	}
+	for _, want := range []string{
+		"Ready", "KubeletReady", "kubelet is posting ready status",
+		"DiskPressure", "KubeletHasDiskPressure", "kubelet has disk pressure",
+		"PIDPressure", "KubeletHasSufficientPID",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, result)
+		}
+	}
+}
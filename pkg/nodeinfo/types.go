@@ -13,6 +13,16 @@ type NodeInfo struct {
 	VirtualizationType string
 	Architecture       string
 	KubeletVersion     string
+	Conditions         []NodeConditionSummary
+	Health             string // HEALTHY, WARNING, CRITICAL (see NodeHealthFromObject)
+}
+
+// NodeConditionSummary is a flattened node condition as reported by the kubelet
+type NodeConditionSummary struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
 }
 
 // CPUInfo contains CPU information
@@ -20,6 +30,9 @@ type CPUInfo struct {
 	Model    string
 	Count    int
 	Capacity int64 // in millicores
+
+	// Allocatable is the CPU available to pods, in millicores
+	Allocatable int64
 }
 
 // GPUInfo contains GPU information
@@ -36,12 +49,20 @@ type GPU struct {
 
 // MemoryPressure contains memory-related pressure metrics
 type MemoryPressure struct {
-	Total            int64   // Total memory in bytes
-	Available        int64   // Available memory in bytes
-	Used             int64   // Used memory in bytes
-	UtilizationRatio float64 // 0.0-1.0
+	Total            int64   // Allocatable memory in bytes
+	Available        int64   // Allocatable minus used, in bytes
+	Used             int64   // Working set reported by node metrics, in bytes
+	UtilizationRatio float64 // Used/Total, 0.0-1.0
 	Pressure         string  // LOW, MEDIUM, HIGH
 	PageCacheRatio   float64 // Ratio of pagecache to total
+
+	// HasUsage is set when Used, Available and UtilizationRatio come from node
+	// metrics; without metrics-server they stay zero
+	HasUsage bool
+
+	// ReservedRatio is the share of capacity held back from pods for the
+	// system and kubelet: (capacity - allocatable) / capacity
+	ReservedRatio float64
 }
 
 // FilesystemLatency contains filesystem performance metrics
@@ -62,5 +83,5 @@ type NodeHealthStatus struct {
 	NodeName  string
 	Status    string // HEALTHY, WARNING, CRITICAL
 	Issues    []string
-	Timestamp int64
+	Timestamp int64 // Unix seconds when the node was evaluated
 }
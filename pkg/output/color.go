@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/fatih/color"
+	"github.com/marcgeld/cobrak/pkg/capacity"
 )
 
 // Global color control
@@ -137,34 +140,155 @@ func Bold(text string, args ...interface{}) string {
 	return color.New(color.Bold).SprintfFunc()(text, args...)
 }
 
+// PressureStyle relabels and recolors pressure levels in text output.
+// Levels missing from Labels or Colors keep their built-in name and color;
+// Colors holds names such as "green" or "red".
+type PressureStyle struct {
+	Labels map[capacity.PressureLevel]string
+	Colors map[capacity.PressureLevel]string
+}
+
+// Global pressure style, set once from settings
+var globalPressureStyle PressureStyle
+
+// SetGlobalPressureStyle sets the pressure labels and colors used by all text renderers
+func SetGlobalPressureStyle(style PressureStyle) {
+	globalPressureStyle = style
+}
+
+// pressureColorAttributes maps color names to their foreground attribute
+var pressureColorAttributes = map[string]color.Attribute{
+	"black":   color.FgBlack,
+	"red":     color.FgRed,
+	"green":   color.FgGreen,
+	"yellow":  color.FgYellow,
+	"blue":    color.FgBlue,
+	"magenta": color.FgMagenta,
+	"cyan":    color.FgCyan,
+	"white":   color.FgWhite,
+}
+
+// pressureLabel returns the display name of a pressure level
+func pressureLabel(level capacity.PressureLevel) string {
+	if label := globalPressureStyle.Labels[level]; label != "" {
+		return label
+	}
+	return string(level)
+}
+
+// Theme is a palette for pressure and status colors. A level with no
+// attributes is printed uncolored.
+type Theme struct {
+	PressureLow       []color.Attribute
+	PressureMedium    []color.Attribute
+	PressureHigh      []color.Attribute
+	PressureSaturated []color.Attribute
+
+	StatusHealthy  []color.Attribute
+	StatusWarning  []color.Attribute
+	StatusCritical []color.Attribute
+}
+
+// DefaultTheme is the theme used when none is configured
+const DefaultTheme = "default"
+
+// themes is the registry of named themes
+var themes = map[string]Theme{
+	DefaultTheme: {
+		PressureLow:       []color.Attribute{color.FgGreen},
+		PressureMedium:    []color.Attribute{color.FgYellow},
+		PressureHigh:      []color.Attribute{color.FgMagenta},
+		PressureSaturated: []color.Attribute{color.FgRed},
+		StatusHealthy:     []color.Attribute{color.FgGreen},
+		StatusWarning:     []color.Attribute{color.FgYellow},
+		StatusCritical:    []color.Attribute{color.FgRed},
+	},
+	// Bright variants that stand out on dark backgrounds
+	"dark": {
+		PressureLow:       []color.Attribute{color.FgHiGreen},
+		PressureMedium:    []color.Attribute{color.FgHiYellow},
+		PressureHigh:      []color.Attribute{color.FgHiMagenta},
+		PressureSaturated: []color.Attribute{color.FgHiRed, color.Bold},
+		StatusHealthy:     []color.Attribute{color.FgHiGreen},
+		StatusWarning:     []color.Attribute{color.FgHiYellow},
+		StatusCritical:    []color.Attribute{color.FgHiRed, color.Bold},
+	},
+	// Avoids yellow and magenta, which wash out on light backgrounds
+	"light": {
+		PressureLow:       []color.Attribute{color.FgGreen},
+		PressureMedium:    []color.Attribute{color.FgBlue},
+		PressureHigh:      []color.Attribute{color.FgRed},
+		PressureSaturated: []color.Attribute{color.FgRed, color.Bold},
+		StatusHealthy:     []color.Attribute{color.FgGreen},
+		StatusWarning:     []color.Attribute{color.FgBlue},
+		StatusCritical:    []color.Attribute{color.FgRed, color.Bold},
+	},
+	"mono": {},
+}
+
+// Global theme, set once from settings
+var globalTheme = themes[DefaultTheme]
+
+// ThemeNames returns the registered theme names, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetGlobalTheme selects the named theme for pressure and status colors.
+// An empty name selects DefaultTheme.
+func SetGlobalTheme(name string) error {
+	if name == "" {
+		name = DefaultTheme
+	}
+	theme, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	globalTheme = theme
+	return nil
+}
+
+// paint applies attrs to text, leaving it unchanged when attrs is empty
+func paint(text string, attrs []color.Attribute) string {
+	if len(attrs) == 0 {
+		return text
+	}
+	return color.New(attrs...).Sprint(text)
+}
+
 // Pressure level colors
 func PressureLowColor(text string) string {
-	return color.GreenString(text)
+	return paint(text, globalTheme.PressureLow)
 }
 
 func PressureMediumColor(text string) string {
-	return color.YellowString(text)
+	return paint(text, globalTheme.PressureMedium)
 }
 
 func PressureHighColor(text string) string {
-	return color.MagentaString(text)
+	return paint(text, globalTheme.PressureHigh)
 }
 
 func PressureSaturatedColor(text string) string {
-	return color.RedString(text)
+	return paint(text, globalTheme.PressureSaturated)
 }
 
 // StatusColors for different statuses
 func StatusHealthy(text string) string {
-	return color.GreenString(text)
+	return paint(text, globalTheme.StatusHealthy)
 }
 
 func StatusWarning(text string) string {
-	return color.YellowString(text)
+	return paint(text, globalTheme.StatusWarning)
 }
 
 func StatusCritical(text string) string {
-	return color.RedString(text)
+	return paint(text, globalTheme.StatusCritical)
 }
 
 // Table colors
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"os"
 	"testing"
+
+	"github.com/fatih/color"
 )
 
 func TestNewColorProvider(t *testing.T) {
@@ -275,3 +277,40 @@ func TestColorProviderWithFormatString(t *testing.T) {
 		t.Errorf("expected 'test 42', got '%s'", output)
 	}
 }
+
+func TestThemes(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() {
+		color.NoColor = noColor
+		_ = SetGlobalTheme(DefaultTheme)
+	}()
+
+	if err := SetGlobalTheme("mono"); err != nil {
+		t.Fatalf("SetGlobalTheme(mono) failed: %v", err)
+	}
+	for _, colorize := range []func(string) string{PressureLowColor, PressureHighColor, PressureSaturatedColor, StatusCritical} {
+		if got := colorize("HIGH"); got != "HIGH" {
+			t.Errorf("expected mono theme to leave text uncolored, got %q", got)
+		}
+	}
+
+	if err := SetGlobalTheme(DefaultTheme); err != nil {
+		t.Fatalf("SetGlobalTheme(default) failed: %v", err)
+	}
+	defaultHigh := PressureHighColor("HIGH")
+	if defaultHigh == "HIGH" {
+		t.Fatalf("expected default theme to color text, got %q", defaultHigh)
+	}
+
+	if err := SetGlobalTheme("light"); err != nil {
+		t.Fatalf("SetGlobalTheme(light) failed: %v", err)
+	}
+	if lightHigh := PressureHighColor("HIGH"); lightHigh == defaultHigh {
+		t.Errorf("expected light theme to use different codes than default, both gave %q", lightHigh)
+	}
+
+	if err := SetGlobalTheme("neon"); err == nil {
+		t.Error("expected error for an unknown theme")
+	}
+}
@@ -0,0 +1,164 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marcgeld/cobrak/pkg/resources"
+)
+
+// PodColumn is a selectable column of the pod resource summary table
+type PodColumn struct {
+	Name   string
+	Header string
+	Value  func(pod resources.PodResourceSummary) string
+}
+
+// podColumns is the registry of columns available to --columns, in display order
+var podColumns = []PodColumn{
+	{Name: "namespace", Header: "NAMESPACE", Value: func(p resources.PodResourceSummary) string { return p.Namespace }},
+	{Name: "pod", Header: "POD", Value: func(p resources.PodResourceSummary) string { return truncateName(p.PodName) }},
+	{Name: "node", Header: "NODE", Value: func(p resources.PodResourceSummary) string { return nodeOrDash(p.NodeName) }},
+	{Name: "cpu_usage", Header: "CPU USAGE", Value: func(p resources.PodResourceSummary) string { return p.CPUUsage.String() }},
+	{Name: "cpu_request", Header: "CPU REQUEST", Value: func(p resources.PodResourceSummary) string { return p.CPURequest.String() }},
+	{Name: "cpu_limit", Header: "CPU LIMIT", Value: func(p resources.PodResourceSummary) string { return p.CPULimit.String() }},
+	{Name: "mem_usage", Header: "MEM USAGE", Value: func(p resources.PodResourceSummary) string { return p.MemUsage.String() }},
+	{Name: "mem_request", Header: "MEM REQUEST", Value: func(p resources.PodResourceSummary) string { return p.MemRequest.String() }},
+	{Name: "mem_limit", Header: "MEM LIMIT", Value: func(p resources.PodResourceSummary) string { return p.MemLimit.String() }},
+	{Name: "cpu_pct_alloc", Header: "CPU % ALLOC", Value: func(p resources.PodResourceSummary) string { return optionalPercent(p.CPUPercentOfAllocatable) }},
+	{Name: "mem_pct_alloc", Header: "MEM % ALLOC", Value: func(p resources.PodResourceSummary) string { return optionalPercent(p.MemPercentOfAllocatable) }},
+	{Name: "containers", Header: "CONTAINERS", Value: func(p resources.PodResourceSummary) string { return strconv.Itoa(p.ContainerCount) }},
+	{Name: "init_containers", Header: "INIT", Value: func(p resources.PodResourceSummary) string { return strconv.Itoa(p.InitContainerCount) }},
+}
+
+// DefaultPodColumns are the columns shown by RenderPodResourceSummary
+var DefaultPodColumns = []string{"namespace", "pod", "cpu_request", "cpu_limit", "mem_request", "mem_limit"}
+
+// WidePodColumns are appended to the pod table by --wide
+var WidePodColumns = []string{"cpu_pct_alloc", "mem_pct_alloc", "containers", "init_containers"}
+
+// NodePodColumn is inserted after the pod column by --show-node
+const NodePodColumn = "node"
+
+// WithNodeColumn returns columns with the node column inserted after "pod"
+// (or first when there is no pod column); columns already naming it are kept as is
+func WithNodeColumn(columns []string) []string {
+	for _, name := range columns {
+		if normalizeColumnName(name) == NodePodColumn {
+			return columns
+		}
+	}
+	result := make([]string, 0, len(columns)+1)
+	inserted := false
+	for _, name := range columns {
+		result = append(result, name)
+		if normalizeColumnName(name) == "pod" && !inserted {
+			result = append(result, NodePodColumn)
+			inserted = true
+		}
+	}
+	if !inserted {
+		result = append([]string{NodePodColumn}, result...)
+	}
+	return result
+}
+
+// nodeOrDash renders a node name, or "-" for pods not yet scheduled
+func nodeOrDash(node string) string {
+	if node == "" {
+		return "-"
+	}
+	return node
+}
+
+// optionalPercent formats a percentage, or "-" when it was not computed
+func optionalPercent(pct *float64) string {
+	if pct == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", *pct)
+}
+
+// PodColumnNames returns the names of all registered pod columns
+func PodColumnNames() []string {
+	names := make([]string, 0, len(podColumns))
+	for _, col := range podColumns {
+		names = append(names, col.Name)
+	}
+	return names
+}
+
+// LookupPodColumns resolves column names against the registry, preserving the given order.
+// Unknown names produce an error listing the valid columns.
+func LookupPodColumns(names []string) ([]PodColumn, error) {
+	columns := make([]PodColumn, 0, len(names))
+	for _, name := range names {
+		name = normalizeColumnName(name)
+		if name == "" {
+			continue
+		}
+		col, ok := findPodColumn(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (valid columns: %s)", name, strings.Join(PodColumnNames(), ", "))
+		}
+		columns = append(columns, col)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns selected (valid columns: %s)", strings.Join(PodColumnNames(), ", "))
+	}
+	return columns, nil
+}
+
+// normalizeColumnName lowercases and trims a column name as given to --columns
+func normalizeColumnName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// findPodColumn returns the registered column with the given name
+func findPodColumn(name string) (PodColumn, bool) {
+	for _, col := range podColumns {
+		if col.Name == name {
+			return col, true
+		}
+	}
+	return PodColumn{}, false
+}
+
+// RenderPodResourceSummaryColumns formats a table of pod resource summaries with the given columns.
+func RenderPodResourceSummaryColumns(pods []resources.PodResourceSummary, top int, columns []PodColumn) string {
+	return RenderPodResourceSummaryColumnsWithOptions(pods, top, columns, DisplayOptions{})
+}
+
+// RenderPodResourceSummaryColumnsWithOptions is RenderPodResourceSummaryColumns
+// without the request or limit columns hidden by opts.
+func RenderPodResourceSummaryColumnsWithOptions(pods []resources.PodResourceSummary, top int, columns []PodColumn, opts DisplayOptions) string {
+	if len(pods) == 0 {
+		return "No pods found."
+	}
+	columns = opts.FilterPodColumns(columns)
+
+	// Limit to top N if top > 0
+	if top > 0 && len(pods) > top {
+		pods = pods[:top]
+	}
+
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.Header
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	values := make([]string, len(columns))
+	for _, pod := range pods {
+		for i, col := range columns {
+			values[i] = col.Value(pod)
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
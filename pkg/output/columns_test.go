@@ -0,0 +1,142 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestRenderPodResourceSummaryColumns_Selection(t *testing.T) {
+	pods := []resources.PodResourceSummary{
+		{
+			Namespace:  "payments",
+			PodName:    "api-0",
+			CPURequest: resource.MustParse("250m"),
+			CPULimit:   resource.MustParse("1"),
+			MemRequest: resource.MustParse("128Mi"),
+			MemLimit:   resource.MustParse("512Mi"),
+		},
+	}
+
+	columns, err := LookupPodColumns([]string{"pod", "cpu_request"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := RenderPodResourceSummaryColumns(pods, 0, columns)
+	for _, want := range []string{"POD", "CPU REQUEST", "api-0", "250m"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+	for _, absent := range []string{"NAMESPACE", "payments", "CPU LIMIT", "MEM REQUEST", "128Mi", "512Mi"} {
+		if strings.Contains(out, absent) {
+			t.Errorf("did not expect %q in output, got: %s", absent, out)
+		}
+	}
+}
+
+func TestLookupPodColumns_Unknown(t *testing.T) {
+	_, err := LookupPodColumns([]string{"pod", "gpu"})
+	if err == nil {
+		t.Fatal("expected error for unknown column")
+	}
+	if !strings.Contains(err.Error(), "gpu") || !strings.Contains(err.Error(), "cpu_request") {
+		t.Errorf("expected error to name the column and list valid ones, got: %v", err)
+	}
+}
+
+func TestRenderPodResourceSummaryColumns_Wide(t *testing.T) {
+	cpuPct := 25.0
+	pods := []resources.PodResourceSummary{
+		{Namespace: "default", PodName: "web", CPURequest: resource.MustParse("1"), CPUPercentOfAllocatable: &cpuPct, ContainerCount: 3},
+	}
+
+	columns, err := LookupPodColumns(append([]string{"pod"}, WidePodColumns...))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := RenderPodResourceSummaryColumns(pods, 0, columns)
+	for _, want := range []string{"CPU % ALLOC", "MEM % ALLOC", "25.0%", "CONTAINERS", "INIT"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+	if !strings.Contains(strings.Split(out, "\n")[1], "-") {
+		t.Errorf("expected \"-\" for memory percentage that was not computed, got: %s", out)
+	}
+}
+
+func TestWithNodeColumn(t *testing.T) {
+	columns, err := LookupPodColumns(WithNodeColumn(DefaultPodColumns))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pods := []resources.PodResourceSummary{
+		{Namespace: "default", PodName: "web", NodeName: "worker-1"},
+		{Namespace: "default", PodName: "pending"},
+	}
+
+	lines := strings.Split(RenderPodResourceSummaryColumns(pods, 0, columns), "\n")
+	if fields := strings.Fields(lines[0]); len(fields) < 3 || fields[2] != "NODE" {
+		t.Fatalf("expected NODE after POD in header, got %q", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); fields[1] != "web" || fields[2] != "worker-1" {
+		t.Errorf("expected web on worker-1, got %q", lines[1])
+	}
+	if fields := strings.Fields(lines[2]); fields[1] != "pending" || fields[2] != "-" {
+		t.Errorf("expected unscheduled pod shown with -, got %q", lines[2])
+	}
+
+	// An explicit node column is not duplicated
+	if got := WithNodeColumn([]string{"pod", "node"}); len(got) != 2 {
+		t.Errorf("expected node column kept once, got %v", got)
+	}
+}
+
+func TestDisplayOptions_HideLimits(t *testing.T) {
+	pods := []resources.PodResourceSummary{
+		{
+			Namespace:  "payments",
+			PodName:    "api-0",
+			CPURequest: resource.MustParse("250m"),
+			CPULimit:   resource.MustParse("1500m"),
+			MemRequest: resource.MustParse("128Mi"),
+			MemLimit:   resource.MustParse("512Mi"),
+		},
+	}
+	inventories := []resources.NamespaceInventory{
+		{
+			Namespace:        "payments",
+			ContainersTotal:  1,
+			CPURequestsTotal: resource.MustParse("250m"),
+			CPULimitsTotal:   resource.MustParse("1500m"),
+			MemRequestsTotal: resource.MustParse("128Mi"),
+			MemLimitsTotal:   resource.MustParse("512Mi"),
+		},
+	}
+	columns, err := LookupPodColumns(DefaultPodColumns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := DisplayOptions{HideLimits: true}
+
+	outputs := map[string]string{
+		"pods":       RenderPodResourceSummaryColumnsWithOptions(pods, 0, columns, opts),
+		"namespaces": RenderNamespaceInventoryTableWithOptions(inventories, opts),
+		"totals":     RenderPodResourceSummaryTotalsWithOptions(pods, opts),
+	}
+	for name, out := range outputs {
+		for _, absent := range []string{"LIMIT", "LIM", "Limits", "1500m", "512Mi"} {
+			if strings.Contains(out, absent) {
+				t.Errorf("%s: did not expect %q with limits hidden, got:\n%s", name, absent, out)
+			}
+		}
+		if !strings.Contains(out, "250m") || !strings.Contains(out, "128Mi") {
+			t.Errorf("%s: expected requests to remain, got:\n%s", name, out)
+		}
+	}
+}
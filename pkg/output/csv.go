@@ -0,0 +1,41 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+)
+
+// nodeCapacityCSVHeader is the header row of RenderNodeCapacityCSV
+var nodeCapacityCSVHeader = []string{
+	"node", "cpu_capacity", "cpu_allocatable", "mem_capacity", "mem_allocatable", "zone", "instance_type",
+}
+
+// RenderNodeCapacityCSV renders one CSV row per node with its capacity,
+// allocatable, zone and instance type, for tracking node pools in spreadsheets.
+// Missing zone or instance type labels leave the cell empty.
+func RenderNodeCapacityCSV(nodes []capacity.NodeCapacity) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(nodeCapacityCSVHeader); err != nil {
+		return "", fmt.Errorf("writing CSV header: %w", err)
+	}
+	for _, n := range nodes {
+		err := w.Write([]string{
+			n.Name,
+			n.CPUCapacity.String(), n.CPUAllocatable.String(),
+			n.MemCapacity.String(), n.MemAllocatable.String(),
+			n.Labels[capacity.ZoneLabel], n.Labels[capacity.DefaultPoolLabel],
+		})
+		if err != nil {
+			return "", fmt.Errorf("writing CSV row for node %s: %w", n.Name, err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("writing CSV: %w", err)
+	}
+	return buf.String(), nil
+}
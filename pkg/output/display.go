@@ -0,0 +1,26 @@
+package output
+
+import "strings"
+
+// DisplayOptions hides the request or limit columns of the pod and namespace
+// tables. The zero value shows both.
+type DisplayOptions struct {
+	HideRequests bool
+	HideLimits   bool
+}
+
+// FilterPodColumns drops the request or limit columns hidden by opts,
+// preserving the order of the remaining columns
+func (o DisplayOptions) FilterPodColumns(columns []PodColumn) []PodColumn {
+	result := make([]PodColumn, 0, len(columns))
+	for _, col := range columns {
+		if o.HideRequests && strings.HasSuffix(col.Name, "_request") {
+			continue
+		}
+		if o.HideLimits && strings.HasSuffix(col.Name, "_limit") {
+			continue
+		}
+		result = append(result, col)
+	}
+	return result
+}
@@ -3,7 +3,9 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 
+	"github.com/marcgeld/cobrak/pkg/capacity"
 	"gopkg.in/yaml.v3"
 )
 
@@ -13,20 +15,43 @@ type OutputFormat string
 const (
 	FormatText OutputFormat = "text"
 	FormatJSON OutputFormat = "json"
-	FormatYAML OutputFormat = "yaml"
+	// FormatJSONCompact is single-line JSON, for jq -c pipelines and log shipping
+	FormatJSONCompact OutputFormat = "json-compact"
+	// FormatJSONL is one JSON object per line; commands that support it stream
+	// objects as they are produced instead of buffering a whole document
+	FormatJSONL OutputFormat = "jsonl"
+	FormatYAML  OutputFormat = "yaml"
+	// FormatHTML is a standalone HTML page; only the resources report supports it
+	FormatHTML OutputFormat = "html"
+	// FormatMarkdown is GitHub-flavored Markdown for wikis and PRs; only the
+	// resources report supports it
+	FormatMarkdown OutputFormat = "markdown"
+	// FormatCSV is comma-separated values for spreadsheets; only the node
+	// capacity listing supports it
+	FormatCSV OutputFormat = "csv"
 )
 
 // ParseOutputFormat parses a string to OutputFormat
 func ParseOutputFormat(format string) (OutputFormat, error) {
 	switch format {
-	case "text":
+	case "text", "table":
 		return FormatText, nil
 	case "json":
 		return FormatJSON, nil
+	case "json-compact":
+		return FormatJSONCompact, nil
+	case "jsonl":
+		return FormatJSONL, nil
 	case "yaml":
 		return FormatYAML, nil
+	case "html":
+		return FormatHTML, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "csv":
+		return FormatCSV, nil
 	default:
-		return FormatText, fmt.Errorf("unsupported format: %s (supported: text, json, yaml)", format)
+		return FormatText, fmt.Errorf("unsupported format: %s (supported: text/table, json, json-compact, jsonl, yaml, html, markdown, csv)", format)
 	}
 }
 
@@ -46,12 +71,37 @@ func RenderOutput(data interface{}, format OutputFormat) (string, error) {
 			return "", fmt.Errorf("JSON marshaling error: %w", err)
 		}
 		return string(jsonBytes), nil
+	case FormatJSONCompact, FormatJSONL:
+		// A single document is one JSON Lines record
+		jsonBytes, err := json.Marshal(data)
+		if err != nil {
+			return "", fmt.Errorf("JSON marshaling error: %w", err)
+		}
+		return string(jsonBytes), nil
 	case FormatYAML:
 		yamlBytes, err := yaml.Marshal(data)
 		if err != nil {
 			return "", fmt.Errorf("YAML marshaling error: %w", err)
 		}
 		return string(yamlBytes), nil
+	case FormatHTML:
+		summary, ok := data.(*ResourcesSummary)
+		if !ok {
+			return "", fmt.Errorf("HTML output is not supported for %T", data)
+		}
+		return RenderHTML(summary)
+	case FormatMarkdown:
+		summary, ok := data.(*ResourcesSummary)
+		if !ok {
+			return "", fmt.Errorf("Markdown output is not supported for %T", data)
+		}
+		return RenderMarkdown(summary), nil
+	case FormatCSV:
+		nodes, ok := data.([]capacity.NodeCapacity)
+		if !ok {
+			return "", fmt.Errorf("CSV output is not supported for %T", data)
+		}
+		return RenderNodeCapacityCSV(nodes)
 	case FormatText:
 		// For text format, data should implement Renderer interface
 		if renderer, ok := data.(Renderer); ok {
@@ -64,6 +114,24 @@ func RenderOutput(data interface{}, format OutputFormat) (string, error) {
 	}
 }
 
+// JSONLWriter writes values as JSON Lines, one compact object per line
+type JSONLWriter struct {
+	enc *json.Encoder
+}
+
+// NewJSONLWriter returns a JSONLWriter writing to w
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	return &JSONLWriter{enc: json.NewEncoder(w)}
+}
+
+// Write encodes v as a single line
+func (jw *JSONLWriter) Write(v interface{}) error {
+	if err := jw.enc.Encode(v); err != nil {
+		return fmt.Errorf("JSON marshaling error: %w", err)
+	}
+	return nil
+}
+
 // MetaOutput provides a unified output structure for different formats
 type MetaOutput struct {
 	Format OutputFormat
@@ -88,8 +156,65 @@ type ResourcesSummary struct {
 	ClusterCapacity    *ClusterCapacitySummary `json:"cluster_capacity" yaml:"clusterCapacity"`
 	PodDetails         []PodDetail             `json:"pod_details" yaml:"podDetails"`
 	Totals             *ResourceTotals         `json:"totals" yaml:"totals"`
+	PodCounts          *PodCountSummary        `json:"pod_counts" yaml:"podCounts"`
+	NamespaceInventory []NamespaceSummary      `json:"namespace_inventory" yaml:"namespaceInventory"`
+	Groups             []GroupSummary          `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Policies           []PolicyInventory       `json:"policies,omitempty" yaml:"policies,omitempty"`
+	ContainerDetails   []ContainerRow          `json:"container_details,omitempty" yaml:"containerDetails,omitempty"`
+	MetricsAvailable   bool                    `json:"metrics_available" yaml:"metricsAvailable"`
+	Issues             []Issue                 `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// FullReport bundles capacity, pod, inventory, policy and (when metrics are
+// available) usage and diff data into a single document
+type FullReport struct {
+	ClusterCapacity    *ClusterCapacitySummary `json:"cluster_capacity" yaml:"clusterCapacity"`
+	PodDetails         []PodDetail             `json:"pod_details" yaml:"podDetails"`
 	NamespaceInventory []NamespaceSummary      `json:"namespace_inventory" yaml:"namespaceInventory"`
+	Policies           []PolicyInventory       `json:"policies" yaml:"policies"`
 	MetricsAvailable   bool                    `json:"metrics_available" yaml:"metricsAvailable"`
+	Usage              []ContainerUsageDetail  `json:"usage,omitempty" yaml:"usage,omitempty"`
+	Diff               []ContainerDiffDetail   `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// UsageReport is the structured output of `resources usage`: one row per
+// container plus totals over those rows
+type UsageReport struct {
+	Containers []ContainerUsageDetail `json:"containers" yaml:"containers"`
+	Totals     UsageTotals            `json:"totals" yaml:"totals"`
+}
+
+// UsageTotals sums the rows of a UsageReport
+type UsageTotals struct {
+	Containers int    `json:"containers" yaml:"containers"`
+	CPUUsage   string `json:"cpu_usage" yaml:"cpuUsage"`
+	MemUsage   string `json:"mem_usage" yaml:"memUsage"`
+}
+
+// ContainerUsageDetail represents measured usage for a single container
+type ContainerUsageDetail struct {
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Pod       string `json:"pod" yaml:"pod"`
+	Container string `json:"container" yaml:"container"`
+	CPUUsage  string `json:"cpu_usage" yaml:"cpuUsage"`
+	MemUsage  string `json:"mem_usage" yaml:"memUsage"`
+	Window    string `json:"window,omitempty" yaml:"window,omitempty"`
+	Node      string `json:"node,omitempty" yaml:"node,omitempty"`
+}
+
+// ContainerDiffDetail represents usage compared with requests/limits for a single container
+type ContainerDiffDetail struct {
+	Namespace         string  `json:"namespace" yaml:"namespace"`
+	Pod               string  `json:"pod" yaml:"pod"`
+	Container         string  `json:"container" yaml:"container"`
+	CPUUsage          string  `json:"cpu_usage" yaml:"cpuUsage"`
+	CPURequest        string  `json:"cpu_request" yaml:"cpuRequest"`
+	CPULimit          string  `json:"cpu_limit" yaml:"cpuLimit"`
+	MemUsage          string  `json:"mem_usage" yaml:"memUsage"`
+	MemRequest        string  `json:"mem_request" yaml:"memRequest"`
+	MemLimit          string  `json:"mem_limit" yaml:"memLimit"`
+	CPUUsageToRequest float64 `json:"cpu_usage_to_request" yaml:"cpuUsageToRequest"`
+	MemUsageToRequest float64 `json:"mem_usage_to_request" yaml:"memUsageToRequest"`
 }
 
 // ClusterCapacitySummary represents cluster capacity data
@@ -102,6 +227,15 @@ type ClusterCapacitySummary struct {
 	MemAllocatable string `json:"mem_allocatable" yaml:"memAllocatable"`
 	MemRequests    string `json:"mem_requests" yaml:"memRequests"`
 	MemLimits      string `json:"mem_limits" yaml:"memLimits"`
+
+	HugePages []HugePagesSummary `json:"hugepages,omitempty" yaml:"hugepages,omitempty"`
+}
+
+// HugePagesSummary represents allocatable and requested hugepages of one page size
+type HugePagesSummary struct {
+	Resource    string `json:"resource" yaml:"resource"`
+	Allocatable string `json:"allocatable" yaml:"allocatable"`
+	Requests    string `json:"requests" yaml:"requests"`
 }
 
 // PodDetail represents a single pod's resource details
@@ -112,6 +246,9 @@ type PodDetail struct {
 	CPULimit   string `json:"cpu_limit" yaml:"cpuLimit"`
 	MemRequest string `json:"mem_request" yaml:"memRequest"`
 	MemLimit   string `json:"mem_limit" yaml:"memLimit"`
+
+	CPUPercentOfAllocatable *float64 `json:"cpu_percent_of_allocatable,omitempty" yaml:"cpuPercentOfAllocatable,omitempty"`
+	MemPercentOfAllocatable *float64 `json:"mem_percent_of_allocatable,omitempty" yaml:"memPercentOfAllocatable,omitempty"`
 }
 
 // ResourceTotals represents total resources
@@ -122,21 +259,82 @@ type ResourceTotals struct {
 	TotalMemLimits   string `json:"total_mem_limits" yaml:"totalMemLimits"`
 }
 
+// PodCountSummary counts the pods and containers behind a report.
+// Containers includes init containers.
+type PodCountSummary struct {
+	Pods           int `json:"pods" yaml:"pods"`
+	Scheduled      int `json:"scheduled" yaml:"scheduled"`
+	Pending        int `json:"pending" yaml:"pending"`
+	Containers     int `json:"containers" yaml:"containers"`
+	InitContainers int `json:"init_containers" yaml:"initContainers"`
+}
+
 // NamespaceSummary represents namespace resource summary
 type NamespaceSummary struct {
+	Namespace       string            `json:"namespace" yaml:"namespace"`
+	Labels          map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	ContainersTotal int               `json:"containers_total" yaml:"containersTotal"`
+	MissingRequests int               `json:"missing_requests" yaml:"missingRequests"`
+	MissingLimits   int               `json:"missing_limits" yaml:"missingLimits"`
+	// Per-resource gaps behind MissingRequests and MissingLimits
+	MissingCPURequests int    `json:"missing_cpu_requests" yaml:"missingCpuRequests"`
+	MissingMemRequests int    `json:"missing_mem_requests" yaml:"missingMemRequests"`
+	MissingCPULimits   int    `json:"missing_cpu_limits" yaml:"missingCpuLimits"`
+	MissingMemLimits   int    `json:"missing_mem_limits" yaml:"missingMemLimits"`
+	CPUOnlyRequests    int    `json:"cpu_only_requests" yaml:"cpuOnlyRequests"`
+	MemOnlyRequests    int    `json:"mem_only_requests" yaml:"memOnlyRequests"`
+	Guaranteed         int    `json:"guaranteed" yaml:"guaranteed"`
+	CPURequests        string `json:"cpu_requests" yaml:"cpuRequests"`
+	CPULimits          string `json:"cpu_limits" yaml:"cpuLimits"`
+	MemRequests        string `json:"mem_requests" yaml:"memRequests"`
+	MemLimits          string `json:"mem_limits" yaml:"memLimits"`
+}
+
+// NamespaceTree nests a namespace's pods and their containers under its inventory summary
+type NamespaceTree struct {
+	NamespaceSummary `yaml:",inline"`
+	Pods             []PodTree `json:"pods" yaml:"pods"`
+}
+
+// PodTree represents a pod's resource details with its containers
+type PodTree struct {
+	PodDetail  `yaml:",inline"`
+	Containers []ContainerDetail `json:"containers" yaml:"containers"`
+}
+
+// ContainerDetail represents a single container's requests and limits
+type ContainerDetail struct {
+	Container  string `json:"container" yaml:"container"`
+	Init       bool   `json:"init,omitempty" yaml:"init,omitempty"`
+	CPURequest string `json:"cpu_request" yaml:"cpuRequest"`
+	CPULimit   string `json:"cpu_limit" yaml:"cpuLimit"`
+	MemRequest string `json:"mem_request" yaml:"memRequest"`
+	MemLimit   string `json:"mem_limit" yaml:"memLimit"`
+}
+
+// ContainerRow is a container's requests/limits together with its namespace and pod
+type ContainerRow struct {
 	Namespace       string `json:"namespace" yaml:"namespace"`
-	ContainersTotal int    `json:"containers_total" yaml:"containersTotal"`
-	MissingRequests int    `json:"missing_requests" yaml:"missingRequests"`
-	MissingLimits   int    `json:"missing_limits" yaml:"missingLimits"`
-	CPURequests     string `json:"cpu_requests" yaml:"cpuRequests"`
-	CPULimits       string `json:"cpu_limits" yaml:"cpuLimits"`
-	MemRequests     string `json:"mem_requests" yaml:"memRequests"`
-	MemLimits       string `json:"mem_limits" yaml:"memLimits"`
+	Pod             string `json:"pod" yaml:"pod"`
+	ContainerDetail `yaml:",inline"`
+}
+
+// GroupSummary represents resource totals for pods sharing an annotation value
+type GroupSummary struct {
+	Key        string `json:"key" yaml:"key"`
+	Value      string `json:"value" yaml:"value"`
+	Pods       int    `json:"pods" yaml:"pods"`
+	CPURequest string `json:"cpu_request" yaml:"cpuRequest"`
+	CPULimit   string `json:"cpu_limit" yaml:"cpuLimit"`
+	MemRequest string `json:"mem_request" yaml:"memRequest"`
+	MemLimit   string `json:"mem_limit" yaml:"memLimit"`
 }
 
 // PressureSummary represents cluster pressure data
 type PressureSummary struct {
 	ClusterPressure    string         `json:"cluster_pressure" yaml:"clusterPressure"`
+	CPUPressure        string         `json:"cpu_pressure,omitempty" yaml:"cpuPressure,omitempty"`
+	MemPressure        string         `json:"mem_pressure,omitempty" yaml:"memPressure,omitempty"`
 	CPUUtilization     float64        `json:"cpu_utilization" yaml:"cpuUtilization"`
 	MemUtilization     float64        `json:"mem_utilization" yaml:"memUtilization"`
 	NodePressures      []NodePressure `json:"node_pressures" yaml:"nodePressures"`
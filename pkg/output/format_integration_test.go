@@ -25,6 +25,18 @@ func TestParseOutputFormat(t *testing.T) {
 			expected:  FormatJSON,
 			shouldErr: false,
 		},
+		{
+			name:      "table alias",
+			input:     "table",
+			expected:  FormatText,
+			shouldErr: false,
+		},
+		{
+			name:      "compact json format",
+			input:     "json-compact",
+			expected:  FormatJSONCompact,
+			shouldErr: false,
+		},
 		{
 			name:      "yaml format",
 			input:     "yaml",
@@ -308,3 +320,30 @@ func TestFormatConsistency(t *testing.T) {
 		}
 	}
 }
+
+// TestRenderOutput_JSONCompact tests that compact JSON renders on a single line
+func TestRenderOutput_JSONCompact(t *testing.T) {
+	data := map[string]interface{}{
+		"name":  "test",
+		"items": []string{"a", "b"},
+	}
+
+	compact, err := RenderOutput(data, FormatJSONCompact)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(compact, "\n") {
+		t.Errorf("expected single-line JSON, got: %q", compact)
+	}
+	if compact != `{"items":["a","b"],"name":"test"}` {
+		t.Errorf("unexpected compact JSON: %s", compact)
+	}
+
+	indented, err := RenderOutput(data, FormatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(indented, "\n") {
+		t.Errorf("expected default JSON to stay indented, got: %q", indented)
+	}
+}
@@ -0,0 +1,125 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// htmlBadge is a pressure level shown as a colored badge
+type htmlBadge struct {
+	Label   string
+	Percent float64
+	Level   string
+	Class   string
+}
+
+// htmlPage is the data handed to resourcesHTMLTemplate
+type htmlPage struct {
+	Summary *ResourcesSummary
+	Badges  []htmlBadge
+}
+
+var resourcesHTMLTemplate = template.Must(template.New("resources").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>cobrak resources</title>
+<style>
+body { font-family: sans-serif; margin: 1.5em; color: #222; }
+h2 { margin-top: 1.5em; font-size: 1.1em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.6em; text-align: left; }
+th { background: #f0f0f0; }
+.badge { display: inline-block; padding: 0.2em 0.6em; border-radius: 0.8em; color: #fff; font-weight: bold; margin-right: 0.5em; }
+.low { background: #2e7d32; }
+.medium { background: #f9a825; }
+.high { background: #c2185b; }
+.saturated { background: #c62828; }
+</style>
+</head>
+<body>
+<h1>Cluster resources</h1>
+{{- if .Badges}}
+<p>{{range .Badges}}<span class="badge {{.Class}}">{{.Label}} {{printf "%.0f" .Percent}}% {{.Level}}</span>{{end}}</p>
+{{- end}}
+{{- with .Summary.ClusterCapacity}}
+<h2>Cluster capacity</h2>
+<table>
+<tr><th></th><th>Capacity</th><th>Allocatable</th><th>Requests</th><th>Limits</th></tr>
+<tr><th>CPU</th><td>{{.CPUCapacity}}</td><td>{{.CPUAllocatable}}</td><td>{{.CPURequests}}</td><td>{{.CPULimits}}</td></tr>
+<tr><th>Memory</th><td>{{.MemCapacity}}</td><td>{{.MemAllocatable}}</td><td>{{.MemRequests}}</td><td>{{.MemLimits}}</td></tr>
+</table>
+{{- end}}
+<h2>Pods</h2>
+<table>
+<tr><th>Namespace</th><th>Pod</th><th>CPU request</th><th>CPU limit</th><th>Mem request</th><th>Mem limit</th></tr>
+{{- range .Summary.PodDetails}}
+<tr><td>{{.Namespace}}</td><td>{{.Pod}}</td><td>{{.CPURequest}}</td><td>{{.CPULimit}}</td><td>{{.MemRequest}}</td><td>{{.MemLimit}}</td></tr>
+{{- end}}
+</table>
+<h2>Namespace inventory</h2>
+<table>
+<tr><th>Namespace</th><th>Containers</th><th>Missing requests</th><th>Missing limits</th><th>Guaranteed</th><th>CPU requests</th><th>CPU limits</th><th>Mem requests</th><th>Mem limits</th></tr>
+{{- range .Summary.NamespaceInventory}}
+<tr><td>{{.Namespace}}</td><td>{{.ContainersTotal}}</td><td>{{.MissingRequests}}</td><td>{{.MissingLimits}}</td><td>{{.Guaranteed}}</td><td>{{.CPURequests}}</td><td>{{.CPULimits}}</td><td>{{.MemRequests}}</td><td>{{.MemLimits}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// RenderHTML renders a resources summary as a standalone HTML page with inline
+// CSS, suitable for embedding in dashboards. Cluster CPU and memory requests
+// are shown as pressure badges using the default thresholds.
+func RenderHTML(summary *ResourcesSummary) (string, error) {
+	page := htmlPage{Summary: summary, Badges: pressureBadges(summary)}
+
+	var buf bytes.Buffer
+	if err := resourcesHTMLTemplate.Execute(&buf, page); err != nil {
+		return "", fmt.Errorf("HTML rendering error: %w", err)
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// pressureBadges returns CPU and memory request pressure badges for summary,
+// using the default thresholds; none when cluster capacity is missing
+func pressureBadges(summary *ResourcesSummary) []htmlBadge {
+	cc := summary.ClusterCapacity
+	if cc == nil {
+		return nil
+	}
+
+	var badges []htmlBadge
+	thresholds := capacity.DefaultPressureThresholds()
+	if pct, ok := quantityPercent(cc.CPURequests, cc.CPUAllocatable); ok {
+		badges = append(badges, newHTMLBadge("CPU", pct, thresholds))
+	}
+	if pct, ok := quantityPercent(cc.MemRequests, cc.MemAllocatable); ok {
+		badges = append(badges, newHTMLBadge("Memory", pct, thresholds))
+	}
+	return badges
+}
+
+func newHTMLBadge(label string, pct float64, thresholds capacity.PressureThresholds) htmlBadge {
+	level := thresholds.Level(pct)
+	return htmlBadge{Label: label, Percent: pct, Level: pressureLabel(level), Class: strings.ToLower(string(level))}
+}
+
+// quantityPercent returns used as a percentage of total; ok is false when
+// either quantity cannot be parsed or total is zero
+func quantityPercent(used, total string) (float64, bool) {
+	u, err := resource.ParseQuantity(used)
+	if err != nil {
+		return 0, false
+	}
+	t, err := resource.ParseQuantity(total)
+	if err != nil || t.IsZero() {
+		return 0, false
+	}
+	return float64(u.MilliValue()) / float64(t.MilliValue()) * 100, true
+}
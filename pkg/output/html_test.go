@@ -0,0 +1,41 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML_TableAndEscaping(t *testing.T) {
+	summary := &ResourcesSummary{
+		ClusterCapacity: &ClusterCapacitySummary{
+			CPUAllocatable: "4", CPURequests: "3800m",
+			MemAllocatable: "8Gi", MemRequests: "2Gi",
+		},
+		NamespaceInventory: []NamespaceSummary{
+			{Namespace: "<script>alert(1)</script>", ContainersTotal: 1},
+		},
+	}
+
+	out, err := RenderOutput(summary, FormatHTML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "<table>") {
+		t.Errorf("expected <table> in output, got: %s", out)
+	}
+	if strings.Contains(out, "<script>") {
+		t.Errorf("namespace name was not escaped: %s", out)
+	}
+	if !strings.Contains(out, "&lt;script&gt;") {
+		t.Errorf("expected escaped namespace name, got: %s", out)
+	}
+	if !strings.Contains(out, `class="badge high"`) || !strings.Contains(out, `class="badge low"`) {
+		t.Errorf("expected CPU HIGH and memory LOW badges, got: %s", out)
+	}
+}
+
+func TestRenderOutput_HTMLUnsupportedType(t *testing.T) {
+	if _, err := RenderOutput(PressureSummary{}, FormatHTML); err == nil {
+		t.Error("expected error rendering HTML for a non-resources document")
+	}
+}
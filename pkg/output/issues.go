@@ -0,0 +1,132 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Issue codes reported in ResourcesSummary.Issues
+const (
+	IssueMissingRequests = "MISSING_REQUESTS"
+	IssueOvercommit      = "OVERCOMMIT"
+	IssueUnscheduledPods = "UNSCHEDULED_PODS"
+	IssueQuotaNearFull   = "QUOTA_NEAR_FULL"
+)
+
+// Issue severities, most severe first
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+)
+
+// QuotaNearFullRatio is the share of a quota's hard limit in use above which it is reported
+const QuotaNearFullRatio = 0.9
+
+// Issue is one detected problem, for dashboards that render a unified problem list
+type Issue struct {
+	Code      string `json:"code" yaml:"code"`
+	Severity  string `json:"severity" yaml:"severity"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Message   string `json:"message" yaml:"message"`
+}
+
+// BuildIssues collects the problems found by the capacity, pod, inventory and
+// policy analyses of a resources report:
+//   - OVERCOMMIT: requests (critical) or limits (warning) of active pods above
+//     cluster allocatable
+//   - UNSCHEDULED_PODS: pods without a node
+//   - QUOTA_NEAR_FULL: ResourceQuota entries at or above QuotaNearFullRatio of hard
+//   - MISSING_REQUESTS: namespaces with containers lacking requests
+//
+// Issues are sorted by severity, code and namespace.
+func BuildIssues(
+	summary *capacity.ClusterCapacitySummary,
+	pods []resources.PodResourceSummary,
+	inventories []resources.NamespaceInventory,
+	policies []resources.PolicySummary,
+) []Issue {
+	var issues []Issue
+
+	if summary != nil {
+		overcommit := []struct {
+			resource         string
+			requests, limits resource.Quantity
+			allocatable      resource.Quantity
+		}{
+			{"CPU", summary.ActiveCPURequests, summary.ActiveCPULimits, summary.TotalCPUAllocatable},
+			{"memory", summary.ActiveMemRequests, summary.ActiveMemLimits, summary.TotalMemAllocatable},
+		}
+		for _, o := range overcommit {
+			if o.allocatable.IsZero() {
+				continue
+			}
+			switch {
+			case o.requests.Cmp(o.allocatable) > 0:
+				issues = append(issues, Issue{Code: IssueOvercommit, Severity: SeverityCritical,
+					Message: fmt.Sprintf("%s requests %s exceed allocatable %s", o.resource, o.requests.String(), o.allocatable.String())})
+			case o.limits.Cmp(o.allocatable) > 0:
+				issues = append(issues, Issue{Code: IssueOvercommit, Severity: SeverityWarning,
+					Message: fmt.Sprintf("%s limits %s exceed allocatable %s", o.resource, o.limits.String(), o.allocatable.String())})
+			}
+		}
+	}
+
+	unscheduled := make(map[string]int)
+	for _, p := range pods {
+		if p.NodeName == "" {
+			unscheduled[p.Namespace]++
+		}
+	}
+	for ns, n := range unscheduled {
+		issues = append(issues, Issue{Code: IssueUnscheduledPods, Severity: SeverityWarning, Namespace: ns,
+			Message: fmt.Sprintf("%d pod(s) not scheduled to a node", n)})
+	}
+
+	for _, ps := range policies {
+		for _, rq := range ps.ResourceQuotas {
+			for name, hard := range rq.Hard {
+				used := rq.Used[name]
+				if hard.IsZero() || float64(used.MilliValue()) < QuotaNearFullRatio*float64(hard.MilliValue()) {
+					continue
+				}
+				issues = append(issues, Issue{Code: IssueQuotaNearFull, Severity: quotaSeverity(used, hard), Namespace: ps.Namespace,
+					Message: fmt.Sprintf("ResourceQuota %s %s uses %s of %s", rq.Name, name, used.String(), hard.String())})
+			}
+		}
+	}
+
+	for _, inv := range inventories {
+		if inv.ContainersMissingAnyRequests > 0 {
+			issues = append(issues, Issue{Code: IssueMissingRequests, Severity: SeverityWarning, Namespace: inv.Namespace,
+				Message: fmt.Sprintf("%d of %d container(s) missing CPU or memory requests", inv.ContainersMissingAnyRequests, inv.ContainersTotal)})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Severity != b.Severity {
+			return a.Severity == SeverityCritical
+		}
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Message < b.Message
+	})
+
+	return issues
+}
+
+// quotaSeverity is critical once a quota entry is exhausted
+func quotaSeverity(used, hard resource.Quantity) string {
+	if used.Cmp(hard) >= 0 {
+		return SeverityCritical
+	}
+	return SeverityWarning
+}
@@ -0,0 +1,111 @@
+package output
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+	"github.com/marcgeld/cobrak/pkg/resources"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestBuildIssues_Overcommit(t *testing.T) {
+	summary := &capacity.ClusterCapacitySummary{
+		TotalCPUAllocatable: resource.MustParse("4"),
+		ActiveCPURequests:   resource.MustParse("2"),
+		ActiveCPULimits:     resource.MustParse("8"),
+		TotalMemAllocatable: resource.MustParse("8Gi"),
+		ActiveMemRequests:   resource.MustParse("10Gi"),
+		ActiveMemLimits:     resource.MustParse("10Gi"),
+	}
+
+	issues := BuildIssues(summary, nil, nil, nil)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+	for _, issue := range issues {
+		if issue.Code != IssueOvercommit {
+			t.Errorf("expected %s, got %+v", IssueOvercommit, issue)
+		}
+	}
+	// Memory requests above allocatable sort before the CPU limit warning
+	if issues[0].Severity != SeverityCritical || issues[1].Severity != SeverityWarning {
+		t.Errorf("expected critical before warning, got %+v", issues)
+	}
+}
+
+func TestBuildIssues_OvercommitIgnoresTerminatedPods(t *testing.T) {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	requests := v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("3"),
+			v1.ResourceMemory: resource.MustParse("6Gi"),
+		},
+	}
+	running := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: "worker-1", Containers: []v1.Container{{Name: "app", Resources: requests}}},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	completed := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup-28471", Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: "worker-1", Containers: []v1.Container{{Name: "job", Resources: requests}}},
+		Status:     v1.PodStatus{Phase: v1.PodSucceeded},
+	}
+
+	// Even when the summary totals include the completed Job, it holds no node resources
+	summary, err := capacity.AnalyzeSummaryWithOptions(context.Background(), fake.NewSimpleClientset(node, running, completed), "",
+		capacity.SummaryOptions{IncludeTerminated: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, issue := range BuildIssues(summary, nil, nil, nil) {
+		if issue.Code == IssueOvercommit {
+			t.Errorf("expected no %s issue from a succeeded pod, got %+v", IssueOvercommit, issue)
+		}
+	}
+}
+
+func TestBuildIssues_FromAnalyses(t *testing.T) {
+	pods := []resources.PodResourceSummary{
+		{Namespace: "batch", PodName: "pending"},
+		{Namespace: "batch", PodName: "running", NodeName: "node-1"},
+	}
+	inventories := []resources.NamespaceInventory{
+		{Namespace: "batch", ContainersTotal: 2, ContainersMissingAnyRequests: 1},
+	}
+	policies := []resources.PolicySummary{{
+		Namespace: "batch",
+		ResourceQuotas: []resources.ResourceQuotaSummary{{
+			Name: "compute",
+			Hard: map[v1.ResourceName]resource.Quantity{v1.ResourceRequestsCPU: resource.MustParse("10")},
+			Used: map[v1.ResourceName]resource.Quantity{v1.ResourceRequestsCPU: resource.MustParse("9500m")},
+		}},
+	}}
+
+	issues := BuildIssues(nil, pods, inventories, policies)
+	codes := make(map[string]bool)
+	for _, issue := range issues {
+		codes[issue.Code] = true
+		if issue.Namespace != "batch" {
+			t.Errorf("expected namespace batch, got %+v", issue)
+		}
+	}
+	for _, want := range []string{IssueUnscheduledPods, IssueMissingRequests, IssueQuotaNearFull} {
+		if !codes[want] {
+			t.Errorf("expected a %s issue, got %+v", want, issues)
+		}
+	}
+}
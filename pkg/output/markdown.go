@@ -0,0 +1,80 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RenderMarkdown renders a resources summary as GitHub-flavored Markdown for
+// pasting into wikis and pull requests. Cluster CPU and memory requests are
+// shown as a pressure line using the default thresholds.
+func RenderMarkdown(summary *ResourcesSummary) string {
+	var sb strings.Builder
+	sb.WriteString("## Cluster resources\n")
+
+	if badges := pressureBadges(summary); len(badges) > 0 {
+		parts := make([]string, len(badges))
+		for i, b := range badges {
+			parts[i] = fmt.Sprintf("**%s** %.0f%% `%s`", b.Label, b.Percent, b.Level)
+		}
+		fmt.Fprintf(&sb, "\nPressure: %s\n", strings.Join(parts, " · "))
+	}
+
+	if cc := summary.ClusterCapacity; cc != nil {
+		sb.WriteString("\n### Cluster capacity\n\n")
+		writeMarkdownTable(&sb, []string{"", "CAPACITY", "ALLOCATABLE", "REQUESTS", "LIMITS"}, [][]string{
+			{"CPU", cc.CPUCapacity, cc.CPUAllocatable, cc.CPURequests, cc.CPULimits},
+			{"Memory", cc.MemCapacity, cc.MemAllocatable, cc.MemRequests, cc.MemLimits},
+		})
+	}
+
+	sb.WriteString("\n### Pods\n\n")
+	podRows := make([][]string, 0, len(summary.PodDetails))
+	for _, p := range summary.PodDetails {
+		podRows = append(podRows, []string{p.Namespace, p.Pod, p.CPURequest, p.CPULimit, p.MemRequest, p.MemLimit})
+	}
+	writeMarkdownTable(&sb, []string{"NAMESPACE", "POD", "CPU REQUEST", "CPU LIMIT", "MEM REQUEST", "MEM LIMIT"}, podRows)
+
+	sb.WriteString("\n### Namespace inventory\n\n")
+	nsRows := make([][]string, 0, len(summary.NamespaceInventory))
+	for _, ns := range summary.NamespaceInventory {
+		nsRows = append(nsRows, []string{
+			ns.Namespace,
+			strconv.Itoa(ns.ContainersTotal),
+			strconv.Itoa(ns.MissingRequests),
+			strconv.Itoa(ns.MissingLimits),
+			strconv.Itoa(ns.Guaranteed),
+			ns.CPURequests, ns.CPULimits, ns.MemRequests, ns.MemLimits,
+		})
+	}
+	writeMarkdownTable(&sb, []string{
+		"NAMESPACE", "CONTAINERS", "MISSING REQUESTS", "MISSING LIMITS", "GUARANTEED",
+		"CPU REQUESTS", "CPU LIMITS", "MEM REQUESTS", "MEM LIMITS",
+	}, nsRows)
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// writeMarkdownTable writes a header row, a separator row and one row per entry
+func writeMarkdownTable(sb *strings.Builder, headers []string, rows [][]string) {
+	writeMarkdownRow(sb, headers)
+	separator := make([]string, len(headers))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeMarkdownRow(sb, separator)
+	for _, row := range rows {
+		writeMarkdownRow(sb, row)
+	}
+}
+
+// writeMarkdownRow writes cells as a table row, escaping pipes so they do not
+// split the cell
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+	for _, cell := range cells {
+		fmt.Fprintf(sb, " %s |", strings.ReplaceAll(cell, "|", `\|`))
+	}
+	sb.WriteString("\n")
+}
@@ -0,0 +1,44 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown_Tables(t *testing.T) {
+	summary := &ResourcesSummary{
+		ClusterCapacity: &ClusterCapacitySummary{
+			CPUAllocatable: "4", CPURequests: "3800m",
+			MemAllocatable: "8Gi", MemRequests: "2Gi",
+		},
+		PodDetails: []PodDetail{
+			{Namespace: "default", Pod: "web", CPURequest: "100m", CPULimit: "200m", MemRequest: "64Mi", MemLimit: "128Mi"},
+		},
+		NamespaceInventory: []NamespaceSummary{
+			{Namespace: "a|b", ContainersTotal: 2, MissingRequests: 1},
+		},
+	}
+
+	out, err := RenderOutput(summary, FormatMarkdown)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"| NAMESPACE |",
+		"| --- |",
+		"| default | web | 100m | 200m | 64Mi | 128Mi |",
+		`| a\|b | 2 | 1 |`,
+		"**CPU** 95% `HIGH`",
+		"**Memory** 25% `LOW`",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderOutput_MarkdownUnsupportedType(t *testing.T) {
+	if _, err := RenderOutput(PressureSummary{}, FormatMarkdown); err == nil {
+		t.Error("expected error rendering Markdown for a non-resources document")
+	}
+}
@@ -0,0 +1,108 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/marcgeld/cobrak/pkg/resources"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PolicyInventory represents LimitRange and ResourceQuota policies for a namespace.
+// Maps from the domain types are flattened into sorted slices so that JSON and
+// YAML output is byte-for-byte reproducible.
+type PolicyInventory struct {
+	Namespace      string              `json:"namespace" yaml:"namespace"`
+	LimitRanges    []LimitRangeEntry   `json:"limit_ranges,omitempty" yaml:"limitRanges,omitempty"`
+	ResourceQuotas []ResourceQuotaInfo `json:"resource_quotas,omitempty" yaml:"resourceQuotas,omitempty"`
+}
+
+// LimitRangeEntry represents a single LimitRange item
+type LimitRangeEntry struct {
+	Name                 string `json:"name" yaml:"name"`
+	Type                 string `json:"type" yaml:"type"`
+	DefaultCPU           string `json:"default_cpu,omitempty" yaml:"defaultCpu,omitempty"`
+	DefaultMemory        string `json:"default_memory,omitempty" yaml:"defaultMemory,omitempty"`
+	DefaultRequestCPU    string `json:"default_request_cpu,omitempty" yaml:"defaultRequestCpu,omitempty"`
+	DefaultRequestMemory string `json:"default_request_memory,omitempty" yaml:"defaultRequestMemory,omitempty"`
+	MaxCPU               string `json:"max_cpu,omitempty" yaml:"maxCpu,omitempty"`
+	MaxMemory            string `json:"max_memory,omitempty" yaml:"maxMemory,omitempty"`
+	MinCPU               string `json:"min_cpu,omitempty" yaml:"minCpu,omitempty"`
+	MinMemory            string `json:"min_memory,omitempty" yaml:"minMemory,omitempty"`
+}
+
+// ResourceQuotaInfo represents a ResourceQuota with its entries sorted by resource name
+type ResourceQuotaInfo struct {
+	Name    string       `json:"name" yaml:"name"`
+	Unused  bool         `json:"unused,omitempty" yaml:"unused,omitempty"`
+	Entries []QuotaEntry `json:"entries" yaml:"entries"`
+}
+
+// QuotaEntry represents the used and hard amounts for one quota resource
+type QuotaEntry struct {
+	Resource string `json:"resource" yaml:"resource"`
+	Used     string `json:"used" yaml:"used"`
+	Hard     string `json:"hard" yaml:"hard"`
+}
+
+// BuildPolicyInventory converts policy summaries to their structured output form
+func BuildPolicyInventory(policies []resources.PolicySummary) []PolicyInventory {
+	result := make([]PolicyInventory, 0, len(policies))
+	for _, ps := range policies {
+		pi := PolicyInventory{Namespace: ps.Namespace}
+
+		for _, lr := range ps.LimitRanges {
+			for _, item := range lr.Items {
+				pi.LimitRanges = append(pi.LimitRanges, LimitRangeEntry{
+					Name:                 lr.Name,
+					Type:                 item.Type,
+					DefaultCPU:           item.DefaultCPU,
+					DefaultMemory:        item.DefaultMemory,
+					DefaultRequestCPU:    item.DefaultRequestCPU,
+					DefaultRequestMemory: item.DefaultRequestMemory,
+					MaxCPU:               item.MaxCPU,
+					MaxMemory:            item.MaxMemory,
+					MinCPU:               item.MinCPU,
+					MinMemory:            item.MinMemory,
+				})
+			}
+		}
+
+		for _, rq := range ps.ResourceQuotas {
+			pi.ResourceQuotas = append(pi.ResourceQuotas, ResourceQuotaInfo{
+				Name:    rq.Name,
+				Unused:  rq.Unused,
+				Entries: sortedQuotaEntries(rq.Hard, rq.Used),
+			})
+		}
+
+		result = append(result, pi)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace < result[j].Namespace
+	})
+
+	return result
+}
+
+// sortedQuotaEntries flattens hard/used maps into entries sorted by resource name
+func sortedQuotaEntries(hard, used map[v1.ResourceName]resource.Quantity) []QuotaEntry {
+	names := make([]string, 0, len(hard))
+	for k := range hard {
+		names = append(names, string(k))
+	}
+	sort.Strings(names)
+
+	entries := make([]QuotaEntry, 0, len(names))
+	for _, name := range names {
+		h := hard[v1.ResourceName(name)]
+		u := used[v1.ResourceName(name)]
+		entries = append(entries, QuotaEntry{
+			Resource: name,
+			Used:     u.String(),
+			Hard:     h.String(),
+		})
+	}
+	return entries
+}
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/resources"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestBuildPolicyInventory_DeterministicMarshaling(t *testing.T) {
+	policies := []resources.PolicySummary{
+		{
+			Namespace: "default",
+			ResourceQuotas: []resources.ResourceQuotaSummary{
+				{
+					Name: "compute",
+					Hard: map[v1.ResourceName]resource.Quantity{
+						v1.ResourceRequestsMemory: resource.MustParse("8Gi"),
+						v1.ResourceRequestsCPU:    resource.MustParse("4"),
+						v1.ResourceLimitsCPU:      resource.MustParse("8"),
+						v1.ResourcePods:           resource.MustParse("20"),
+					},
+					Used: map[v1.ResourceName]resource.Quantity{
+						v1.ResourceRequestsCPU: resource.MustParse("1"),
+					},
+				},
+			},
+		},
+	}
+
+	inventory := BuildPolicyInventory(policies)
+
+	entries := inventory[0].ResourceQuotas[0].Entries
+	want := []string{"limits.cpu", "pods", "requests.cpu", "requests.memory"}
+	for i, name := range want {
+		if entries[i].Resource != name {
+			t.Errorf("entry %d: expected %s, got %s", i, name, entries[i].Resource)
+		}
+	}
+
+	for _, format := range []OutputFormat{FormatJSON, FormatYAML} {
+		first, err := RenderOutput(BuildPolicyInventory(policies), format)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i := 0; i < 5; i++ {
+			again, err := RenderOutput(BuildPolicyInventory(policies), format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if again != first {
+				t.Fatalf("%s output differs between runs:\n%s\n---\n%s", format, first, again)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// promLabelEscaper escapes label values for the Prometheus text exposition format
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// PrometheusOptions adds process state to RenderPrometheusWithOptions
+type PrometheusOptions struct {
+	// LastRefreshSuccess is when pressure was last calculated successfully;
+	// the zero time omits cobrak_last_refresh_success_timestamp_seconds
+	LastRefreshSuccess time.Time
+}
+
+// RenderPrometheus renders pressure as gauges in the Prometheus text exposition
+// format. Utilization is in percent; the pressure level is its code (0 LOW to 3 SATURATED).
+func RenderPrometheus(pressure *Pressure) string {
+	return RenderPrometheusWithOptions(pressure, PrometheusOptions{})
+}
+
+// RenderPrometheusWithOptions is RenderPrometheus with the given options
+func RenderPrometheusWithOptions(pressure *Pressure, opts PrometheusOptions) string {
+	var sb strings.Builder
+
+	writeGauge := func(name, help string, samples ...promSample) {
+		fmt.Fprintf(&sb, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(&sb, "# TYPE %s gauge\n", name)
+		for _, s := range samples {
+			sb.WriteString(name)
+			if s.labelName != "" {
+				fmt.Fprintf(&sb, `{%s="%s"}`, s.labelName, promLabelEscaper.Replace(s.labelValue))
+			}
+			fmt.Fprintf(&sb, " %s\n", strconv.FormatFloat(s.value, 'g', -1, 64))
+		}
+	}
+
+	if !opts.LastRefreshSuccess.IsZero() {
+		writeGauge("cobrak_last_refresh_success_timestamp_seconds", "Unix time of the last successful pressure calculation.",
+			promSample{value: float64(opts.LastRefreshSuccess.Unix())})
+	}
+	writeGauge("cobrak_cluster_pressure_level", "Overall cluster pressure level (0 LOW, 1 MEDIUM, 2 HIGH, 3 SATURATED).",
+		promSample{value: float64(pressure.Overall.Code())})
+	writeGauge("cobrak_cluster_cpu_utilization_percent", "Cluster CPU requests as a percentage of allocatable.",
+		promSample{value: pressure.CPUUtilization})
+	writeGauge("cobrak_cluster_memory_utilization_percent", "Cluster memory requests as a percentage of allocatable.",
+		promSample{value: pressure.MemUtilization})
+
+	nodeCPU := make([]promSample, len(pressure.NodePressures))
+	nodeMem := make([]promSample, len(pressure.NodePressures))
+	for i, np := range pressure.NodePressures {
+		nodeCPU[i] = promSample{labelName: "node", labelValue: np.NodeName, value: np.CPUUtilization}
+		nodeMem[i] = promSample{labelName: "node", labelValue: np.NodeName, value: np.MemUtilization}
+	}
+	writeGauge("cobrak_node_cpu_utilization_percent", "Node CPU requests as a percentage of allocatable.", nodeCPU...)
+	writeGauge("cobrak_node_memory_utilization_percent", "Node memory requests as a percentage of allocatable.", nodeMem...)
+
+	nsCPU := make([]promSample, len(pressure.NamespacePressures))
+	nsMem := make([]promSample, len(pressure.NamespacePressures))
+	for i, nsp := range pressure.NamespacePressures {
+		nsCPU[i] = promSample{labelName: "namespace", labelValue: nsp.Namespace, value: nsp.CPUPercent}
+		nsMem[i] = promSample{labelName: "namespace", labelValue: nsp.Namespace, value: nsp.MemPercent}
+	}
+	writeGauge("cobrak_namespace_cpu_requests_percent", "Namespace CPU requests as a percentage of cluster allocatable.", nsCPU...)
+	writeGauge("cobrak_namespace_memory_requests_percent", "Namespace memory requests as a percentage of cluster allocatable.", nsMem...)
+
+	return sb.String()
+}
+
+// promSample is one gauge value with an optional single label
+type promSample struct {
+	labelName  string
+	labelValue string
+	value      float64
+}
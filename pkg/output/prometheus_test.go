@@ -0,0 +1,24 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/capacity"
+)
+
+func TestRenderPrometheus_EscapesLabels(t *testing.T) {
+	out := RenderPrometheus(&Pressure{
+		Overall:       capacity.PressureHigh,
+		NodePressures: []capacity.NodePressure{{NodeName: `odd"name\`, CPUUtilization: 91.5}},
+	})
+
+	for _, line := range []string{
+		"cobrak_cluster_pressure_level 2\n",
+		`cobrak_node_cpu_utilization_percent{node="odd\"name\\"} 91.5` + "\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("expected %q in output, got:\n%s", line, out)
+		}
+	}
+}
@@ -3,10 +3,13 @@ package output
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
-	"text/tabwriter"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/marcgeld/cobrak/pkg/capacity"
 	"github.com/marcgeld/cobrak/pkg/resources"
 	v1 "k8s.io/api/core/v1"
@@ -17,26 +20,157 @@ import (
 type Pressure = capacity.ClusterPressure
 
 // RenderNamespaceInventoryTable formats a table of namespace inventories.
+// A LABELS column is added when any inventory carries namespace labels.
 func RenderNamespaceInventoryTable(inventories []resources.NamespaceInventory) string {
+	return RenderNamespaceInventoryTableWithOptions(inventories, DisplayOptions{})
+}
+
+// RenderNamespaceInventoryTableWithOptions is RenderNamespaceInventoryTable
+// without the request or limit columns hidden by opts.
+func RenderNamespaceInventoryTableWithOptions(inventories []resources.NamespaceInventory, opts DisplayOptions) string {
+	showLabels := false
+	for _, ns := range inventories {
+		if ns.Labels != nil {
+			showLabels = true
+			break
+		}
+	}
+
+	header := []string{"NAMESPACE", "CONTAINERS"}
+	if !opts.HideRequests {
+		header = append(header, "MISSING REQUESTS")
+	}
+	if !opts.HideLimits {
+		header = append(header, "MISSING LIMITS")
+	}
+	if !opts.HideRequests {
+		header = append(header, "REQ COVERAGE")
+	}
+	if !opts.HideLimits {
+		header = append(header, "LIM COVERAGE")
+	}
+	if !opts.HideRequests {
+		header = append(header, "CPU-ONLY REQ", "MEM-ONLY REQ")
+	}
+	header = append(header, "GUARANTEED%")
+	header = append(header, cpuMemColumns(opts, "CPU REQ", "CPU LIM", "MEM REQ", "MEM LIM")...)
+	if showLabels {
+		header = append(header, "LABELS")
+	}
+
 	var buf bytes.Buffer
-	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "NAMESPACE\tCONTAINERS\tMISSING REQUESTS\tMISSING LIMITS\tCPU REQ\tCPU LIM\tMEM REQ\tMEM LIM")
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
 	for _, ns := range inventories {
-		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s\n",
-			ns.Namespace,
-			ns.ContainersTotal,
-			ns.ContainersMissingAnyRequests,
-			ns.ContainersMissingAnyLimits,
-			ns.CPURequestsTotal.String(),
-			ns.CPULimitsTotal.String(),
-			ns.MemRequestsTotal.String(),
-			ns.MemLimitsTotal.String(),
-		)
+		guaranteed := "-"
+		if ns.ContainersTotal > 0 {
+			guaranteed = fmt.Sprintf("%.0f%%", float64(ns.ContainersGuaranteed)/float64(ns.ContainersTotal)*100)
+		}
+		reqCoverage, limCoverage := "-", "-"
+		if ns.ContainersTotal > 0 {
+			reqCoverage = renderMiniBar(coveragePercent(ns.ContainersTotal, ns.ContainersMissingAnyRequests), inventoryBarWidth)
+			limCoverage = renderMiniBar(coveragePercent(ns.ContainersTotal, ns.ContainersMissingAnyLimits), inventoryBarWidth)
+		}
+
+		row := []string{ns.Namespace, strconv.Itoa(ns.ContainersTotal)}
+		if !opts.HideRequests {
+			row = append(row, strconv.Itoa(ns.ContainersMissingAnyRequests))
+		}
+		if !opts.HideLimits {
+			row = append(row, strconv.Itoa(ns.ContainersMissingAnyLimits))
+		}
+		if !opts.HideRequests {
+			row = append(row, reqCoverage)
+		}
+		if !opts.HideLimits {
+			row = append(row, limCoverage)
+		}
+		if !opts.HideRequests {
+			row = append(row, strconv.Itoa(ns.ContainersCPUOnlyRequest), strconv.Itoa(ns.ContainersMemOnlyRequest))
+		}
+		row = append(row, guaranteed)
+		row = append(row, cpuMemColumns(opts,
+			ns.CPURequestsTotal.String(), ns.CPULimitsTotal.String(),
+			ns.MemRequestsTotal.String(), ns.MemLimitsTotal.String())...)
+		if showLabels {
+			row = append(row, formatLabels(ns.Labels))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
 	}
 	w.Flush()
 	return strings.TrimRight(buf.String(), "\n")
 }
 
+// cpuMemColumns returns the CPU request/limit and memory request/limit cells
+// shown by opts, in that order
+func cpuMemColumns(opts DisplayOptions, cpuReq, cpuLim, memReq, memLim string) []string {
+	var cells []string
+	if !opts.HideRequests {
+		cells = append(cells, cpuReq)
+	}
+	if !opts.HideLimits {
+		cells = append(cells, cpuLim)
+	}
+	if !opts.HideRequests {
+		cells = append(cells, memReq)
+	}
+	if !opts.HideLimits {
+		cells = append(cells, memLim)
+	}
+	return cells
+}
+
+// inventoryBarWidth is the number of cells in the coverage bars of the
+// namespace inventory table
+const inventoryBarWidth = 5
+
+// coveragePercent returns the share of containers not missing a setting
+func coveragePercent(total, missing int) float64 {
+	return float64(total-missing) / float64(total) * 100
+}
+
+// renderMiniBar renders pct as a small inline bar such as "[■■■■□] 80%".
+// The bar is green from 80%, yellow from 50% and red below; colors follow the
+// global color setting.
+func renderMiniBar(pct float64, width int) string {
+	filled := int(math.Round(pct / 100 * float64(width)))
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > width {
+		filled = width
+	}
+
+	bar := fmt.Sprintf("[%s%s] %.0f%%", strings.Repeat("■", filled), strings.Repeat("□", width-filled), pct)
+	switch {
+	case pct >= 80:
+		return StatusHealthy(bar)
+	case pct >= 50:
+		return StatusWarning(bar)
+	default:
+		return StatusCritical(bar)
+	}
+}
+
+// formatLabels renders labels as a sorted comma-separated key=value list
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + labels[k]
+	}
+	return strings.Join(pairs, ",")
+}
+
 // RenderMissingResourcesTable formats a table of containers missing requests/limits.
 func RenderMissingResourcesTable(containers []resources.ContainerResources, top int) string {
 	var missing []resources.ContainerResources
@@ -55,11 +189,15 @@ func RenderMissingResourcesTable(containers []resources.ContainerResources, top
 	}
 
 	var buf bytes.Buffer
-	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tINIT\tCPU REQ\tCPU LIM\tMEM REQ\tMEM LIM")
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tIMAGE\tINIT\tCPU REQ\tCPU LIM\tMEM REQ\tMEM LIM")
 	for _, c := range missing {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%v\t%v\t%v\t%v\n",
-			c.Namespace, c.PodName, c.ContainerName, c.IsInit,
+		image := c.Image
+		if image == "" {
+			image = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%v\t%v\t%v\t%v\t%v\n",
+			c.Namespace, truncateName(c.PodName), truncateName(c.ContainerName), image, c.IsInit,
 			c.HasCPURequest, c.HasCPULimit, c.HasMemRequest, c.HasMemLimit,
 		)
 	}
@@ -67,6 +205,118 @@ func RenderMissingResourcesTable(containers []resources.ContainerResources, top
 	return strings.TrimRight(buf.String(), "\n")
 }
 
+// RenderEffectiveResourcesTable formats containers' requests/limits after LimitRange
+// defaulting. Values taken from a LimitRange default are marked with "*".
+func RenderEffectiveResourcesTable(containers []resources.EffectiveContainerResources, top int) string {
+	if len(containers) == 0 {
+		return "No containers found."
+	}
+
+	if top > 0 && len(containers) > top {
+		containers = containers[:top]
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tINIT\tCPU REQ\tCPU LIM\tMEM REQ\tMEM LIM")
+	for _, c := range containers {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\t%s\t%s\t%s\n",
+			c.Namespace, truncateName(c.PodName), truncateName(c.ContainerName), c.IsInit,
+			effectiveQuantity(c.CPURequest, c.HasCPURequest, c.CPURequestDefaulted),
+			effectiveQuantity(c.CPULimit, c.HasCPULimit, c.CPULimitDefaulted),
+			effectiveQuantity(c.MemRequest, c.HasMemRequest, c.MemRequestDefaulted),
+			effectiveQuantity(c.MemLimit, c.HasMemLimit, c.MemLimitDefaulted),
+		)
+	}
+	w.Flush()
+	fmt.Fprint(&buf, "* = LimitRange default")
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// effectiveQuantity formats q like optionalQuantity, marking LimitRange defaults with "*"
+func effectiveQuantity(q resource.Quantity, set, defaulted bool) string {
+	if defaulted {
+		return q.String() + "*"
+	}
+	return optionalQuantity(q, set)
+}
+
+// RenderPodDetail renders a focused view of a single pod: its totals followed by
+// per-container requests and limits. When diffs is non-nil, measured usage and
+// usage/request ratios are included.
+func RenderPodDetail(summary *resources.PodResourceSummary, containers []resources.ContainerResources, diffs []resources.ContainerDiff) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Pod: %s/%s\n", summary.Namespace, summary.PodName))
+	sb.WriteString(fmt.Sprintf("  CPU:    request %s, limit %s\n", summary.CPURequest.String(), summary.CPULimit.String()))
+	sb.WriteString(fmt.Sprintf("  Memory: request %s, limit %s\n\n", summary.MemRequest.String(), summary.MemLimit.String()))
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	if diffs == nil {
+		fmt.Fprintln(w, "CONTAINER\tINIT\tCPU REQ\tCPU LIM\tMEM REQ\tMEM LIM")
+		for _, c := range containers {
+			fmt.Fprintf(w, "%s\t%v\t%s\t%s\t%s\t%s\n",
+				truncateName(c.ContainerName), c.IsInit,
+				optionalQuantity(c.CPURequest, c.HasCPURequest), optionalQuantity(c.CPULimit, c.HasCPULimit),
+				optionalQuantity(c.MemRequest, c.HasMemRequest), optionalQuantity(c.MemLimit, c.HasMemLimit),
+			)
+		}
+	} else {
+		fmt.Fprintln(w, "CONTAINER\tCPU USAGE\tCPU REQ\tCPU LIM\tCPU RATIO\tMEM USAGE\tMEM REQ\tMEM LIM\tMEM RATIO")
+		for _, d := range diffs {
+			cpuRatio := "-"
+			if d.HasCPURequest {
+				cpuRatio = fmt.Sprintf("%.2f", d.CPUUsageToRequest)
+			}
+			memRatio := "-"
+			if d.HasMemRequest {
+				memRatio = fmt.Sprintf("%.2f", d.MemUsageToRequest)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				truncateName(d.ContainerName),
+				d.CPUUsage.String(), optionalQuantity(d.CPURequest, d.HasCPURequest), optionalQuantity(d.CPULimit, d.HasCPULimit), cpuRatio,
+				d.MemUsage.String(), optionalQuantity(d.MemRequest, d.HasMemRequest), optionalQuantity(d.MemLimit, d.HasMemLimit), memRatio,
+			)
+		}
+	}
+	w.Flush()
+	sb.WriteString(buf.String())
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// RenderContainerSummary formats a table with one row per container.
+func RenderContainerSummary(containers []resources.ContainerResources, top int) string {
+	if len(containers) == 0 {
+		return "No containers found."
+	}
+
+	if top > 0 && len(containers) > top {
+		containers = containers[:top]
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tINIT\tCPU REQ\tCPU LIM\tMEM REQ\tMEM LIM")
+	for _, c := range containers {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\t%s\t%s\t%s\n",
+			c.Namespace, truncateName(c.PodName), truncateName(c.ContainerName), c.IsInit,
+			optionalQuantity(c.CPURequest, c.HasCPURequest), optionalQuantity(c.CPULimit, c.HasCPULimit),
+			optionalQuantity(c.MemRequest, c.HasMemRequest), optionalQuantity(c.MemLimit, c.HasMemLimit),
+		)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// optionalQuantity renders q, or "-" when the value is not set
+func optionalQuantity(q resource.Quantity, set bool) string {
+	if !set {
+		return "-"
+	}
+	return q.String()
+}
+
 // RenderPolicySummary formats LimitRange and ResourceQuota summaries.
 func RenderPolicySummary(policies []resources.PolicySummary) string {
 	if len(policies) == 0 {
@@ -89,6 +339,12 @@ func RenderPolicySummary(policies []resources.PolicySummary) string {
 					if item.DefaultMemory != "" {
 						sb.WriteString(fmt.Sprintf("  DefaultMemory: %s", item.DefaultMemory))
 					}
+					if item.DefaultRequestCPU != "" {
+						sb.WriteString(fmt.Sprintf("  DefaultRequestCPU: %s", item.DefaultRequestCPU))
+					}
+					if item.DefaultRequestMemory != "" {
+						sb.WriteString(fmt.Sprintf("  DefaultRequestMemory: %s", item.DefaultRequestMemory))
+					}
 					if item.MaxCPU != "" {
 						sb.WriteString(fmt.Sprintf("  MaxCPU: %s", item.MaxCPU))
 					}
@@ -103,7 +359,11 @@ func RenderPolicySummary(policies []resources.PolicySummary) string {
 		if len(ps.ResourceQuotas) > 0 {
 			sb.WriteString("  ResourceQuotas:\n")
 			for _, rq := range ps.ResourceQuotas {
-				sb.WriteString(fmt.Sprintf("    - %s\n", rq.Name))
+				if rq.Unused {
+					sb.WriteString(fmt.Sprintf("    - %s %s\n", rq.Name, Warning("(unused: hard set, nothing used)")))
+				} else {
+					sb.WriteString(fmt.Sprintf("    - %s\n", rq.Name))
+				}
 				var hardKeys []string
 				for k := range rq.Hard {
 					hardKeys = append(hardKeys, string(k))
@@ -120,35 +380,58 @@ func RenderPolicySummary(policies []resources.PolicySummary) string {
 	return strings.TrimRight(sb.String(), "\n")
 }
 
+// DefaultNamespaceDisplayThreshold is the requested percentage from which
+// RenderPressureSimple lists a namespace
+const DefaultNamespaceDisplayThreshold = 80.0
+
+// PressureSimpleOptions tunes RenderPressureSimpleWithOptions
+type PressureSimpleOptions struct {
+	// NamespaceThreshold is the CPU or memory requested percentage from which
+	// a namespace line is shown
+	NamespaceThreshold float64
+}
+
 // RenderPressureSimple renders a simple pressure summary with colors.
 func RenderPressureSimple(pressure *Pressure) string {
+	return RenderPressureSimpleWithOptions(pressure, PressureSimpleOptions{NamespaceThreshold: DefaultNamespaceDisplayThreshold})
+}
+
+// RenderPressureSimpleWithOptions is RenderPressureSimple with the given options
+func RenderPressureSimpleWithOptions(pressure *Pressure, opts PressureSimpleOptions) string {
 	var sb strings.Builder
 
 	// Cluster overall pressure with color
-	pressureText := colorizePressureLevel(string(pressure.Overall), pressure.Overall)
-	sb.WriteString(fmt.Sprintf("Cluster Pressure: %s\n", pressureText))
+	pressureText := colorizePressureLevel(pressureLabel(pressure.Overall), pressure.Overall)
+	sb.WriteString(fmt.Sprintf("Cluster Pressure: %s", pressureText))
+	if pressure.OverallCPU != "" && pressure.OverallMem != "" {
+		// Say which resource drives the overall level
+		sb.WriteString(fmt.Sprintf(" (CPU %s, memory %s)",
+			colorizePressureLevel(pressureLabel(pressure.OverallCPU), pressure.OverallCPU),
+			colorizePressureLevel(pressureLabel(pressure.OverallMem), pressure.OverallMem)))
+	}
+	sb.WriteString("\n")
 
 	// Node pressures
 	for _, np := range pressure.NodePressures {
 		if np.CPUPressure != "LOW" {
-			cpuPressure := colorizePressureLevel(string(np.CPUPressure), np.CPUPressure)
+			cpuPressure := colorizePressureLevel(pressureLabel(np.CPUPressure), np.CPUPressure)
 			nodeName := Header(np.NodeName)
 			sb.WriteString(fmt.Sprintf("Node %s: CPU %s (%.0f%%)\n", nodeName, cpuPressure, np.CPUUtilization))
 		}
 		if np.MemPressure != "LOW" {
-			memPressure := colorizePressureLevel(string(np.MemPressure), np.MemPressure)
+			memPressure := colorizePressureLevel(pressureLabel(np.MemPressure), np.MemPressure)
 			nodeName := Header(np.NodeName)
 			sb.WriteString(fmt.Sprintf("Node %s: Memory %s (%.0f%%)\n", nodeName, memPressure, np.MemUtilization))
 		}
 	}
 
-	// Namespace pressures - only show if >= 80%
+	// Namespace pressures - only show if at or above the threshold
 	for _, nsp := range pressure.NamespacePressures {
-		if nsp.CPUPercent >= 80 {
+		if nsp.CPUPercent >= opts.NamespaceThreshold {
 			nsName := Info(nsp.Namespace)
 			sb.WriteString(fmt.Sprintf("Namespace %s: CPU %.0f%% requested\n", nsName, nsp.CPUPercent))
 		}
-		if nsp.MemPercent >= 80 {
+		if nsp.MemPercent >= opts.NamespaceThreshold {
 			nsName := Info(nsp.Namespace)
 			sb.WriteString(fmt.Sprintf("Namespace %s: Memory %.0f%% requested\n", nsName, nsp.MemPercent))
 		}
@@ -157,8 +440,67 @@ func RenderPressureSimple(pressure *Pressure) string {
 	return strings.TrimRight(sb.String(), "\n")
 }
 
-// colorizePressureLevel applies appropriate color to pressure level text
+// RenderPressureOneline renders overall cluster pressure as a single line,
+// e.g. "CLUSTER: HIGH (cpu 82%, mem 71%)", for status bars and prompts.
+func RenderPressureOneline(pressure *Pressure) string {
+	level := colorizePressureLevel(pressureLabel(pressure.Overall), pressure.Overall)
+	return fmt.Sprintf("CLUSTER: %s (cpu %.0f%%, mem %.0f%%)", level, pressure.CPUUtilization, pressure.MemUtilization)
+}
+
+// RenderPressureLegend explains which share of allocatable requested maps to each pressure level.
+// Utilization below the medium threshold counts as LOW.
+func RenderPressureLegend(t capacity.PressureThresholds) string {
+	var sb strings.Builder
+	sb.WriteString("Legend (requests as % of allocatable):\n")
+	sb.WriteString(fmt.Sprintf("  %s below %.0f%%\n", colorizePressureLevel(fmt.Sprintf("%-10s", pressureLabel(capacity.PressureLow)), capacity.PressureLow), t.Medium))
+	sb.WriteString(fmt.Sprintf("  %s %.0f%% to %.0f%%\n", colorizePressureLevel(fmt.Sprintf("%-10s", pressureLabel(capacity.PressureMedium)), capacity.PressureMedium), t.Medium, t.High))
+	sb.WriteString(fmt.Sprintf("  %s %.0f%% to %.0f%%\n", colorizePressureLevel(fmt.Sprintf("%-10s", pressureLabel(capacity.PressureHigh)), capacity.PressureHigh), t.High, t.Saturated))
+	sb.WriteString(fmt.Sprintf("  %s %.0f%% and above", colorizePressureLevel(fmt.Sprintf("%-10s", pressureLabel(capacity.PressureSaturated)), capacity.PressureSaturated), t.Saturated))
+	return sb.String()
+}
+
+// RenderPressureExplain renders a per-node breakdown of utilization, pressure levels,
+// system reservation and DaemonSet requests, followed by warnings for heavily reserved nodes.
+func RenderPressureExplain(pressure *Pressure) string {
+	if len(pressure.NodePressures) == 0 {
+		return "No nodes found."
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NODE\tCPU REQ%\tCPU LEVEL\tMEM REQ%\tMEM LEVEL\tCPU RESERVED\tMEM RESERVED\tDS CPU REQ\tDS MEM REQ")
+	for _, np := range pressure.NodePressures {
+		fmt.Fprintf(w, "%s\t%.0f%%\t%s\t%.0f%%\t%s\t%.0f%%\t%.0f%%\t%s\t%s\n",
+			np.NodeName,
+			np.CPUUtilization, pressureLabel(np.CPUPressure),
+			np.MemUtilization, pressureLabel(np.MemPressure),
+			np.CPUReservationRatio*100, np.MemReservationRatio*100,
+			resource.NewMilliQuantity(np.DaemonSetRequest.CPU, resource.DecimalSI).String(),
+			resource.NewQuantity(np.DaemonSetRequest.Memory, resource.BinarySI).String(),
+		)
+	}
+	w.Flush()
+
+	var sb strings.Builder
+	sb.WriteString(buf.String())
+	for _, np := range pressure.NodePressures {
+		if !np.HighReservation() {
+			continue
+		}
+		sb.WriteString(Warning(fmt.Sprintf("⚠ Node %s reserves %.0f%% CPU / %.0f%% memory of capacity (above %.0f%%)",
+			np.NodeName, np.CPUReservationRatio*100, np.MemReservationRatio*100, capacity.ReservationWarnRatio*100)))
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// colorizePressureLevel applies appropriate color to pressure level text,
+// preferring a color configured in the global pressure style
 func colorizePressureLevel(text string, level capacity.PressureLevel) string {
+	if attr, ok := pressureColorAttributes[globalPressureStyle.Colors[level]]; ok {
+		return color.New(attr).Sprint(text)
+	}
 	switch level {
 	case capacity.PressureLow:
 		return PressureLowColor(text)
@@ -173,8 +515,254 @@ func colorizePressureLevel(text string, level capacity.PressureLevel) string {
 	}
 }
 
+// RenderNodePoolTable formats per-pool capacity, requests and pressure.
+func RenderNodePoolTable(pools []capacity.NodePool) string {
+	if len(pools) == 0 {
+		return "No nodes found."
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintf(w, "POOL (%s)\tNODES\tCPU REQ/ALLOC\tCPU%%\tMEM REQ/ALLOC\tMEM%%\tPRESSURE\n", pools[0].Label)
+	for _, p := range pools {
+		fmt.Fprintf(w, "%s\t%d\t%s/%s\t%.1f%%\t%s/%s\t%.1f%%\t%s\n",
+			p.Value, len(p.Nodes),
+			p.CPURequests.String(), p.CPUAllocatable.String(), p.CPUUtilization,
+			p.MemRequests.String(), p.MemAllocatable.String(), p.MemUtilization,
+			pressureLabel(p.Pressure),
+		)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderFleetPressure formats one row per cluster context with its utilization and
+// overall pressure, followed by a warning for each cluster that could not be analyzed.
+func RenderFleetPressure(fleet []capacity.ClusterFleetPressure) string {
+	if len(fleet) == 0 {
+		return "No contexts selected."
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "CONTEXT\tNODES\tCPU%\tMEM%\tPRESSURE")
+	for _, f := range fleet {
+		if f.Err != nil {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t%s\n", f.Context, pressureLabel(f.Overall))
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%d\t%.1f%%\t%.1f%%\t%s\n",
+			f.Context, f.Nodes, f.CPUUtilization, f.MemUtilization,
+			colorizePressureLevel(pressureLabel(f.Overall), f.Overall),
+		)
+	}
+	w.Flush()
+
+	for _, f := range fleet {
+		if f.Err != nil {
+			buf.WriteString("\n")
+			buf.WriteString(Warning(fmt.Sprintf("⚠ %s: %v", f.Context, f.Err)))
+		}
+	}
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderFit renders whether a pod of the requested size fits the cluster,
+// comparing aggregate headroom with the largest single node.
+func RenderFit(fit *capacity.FitResult) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Pod size:          cpu %s, memory %s\n", fit.CPURequest.String(), fit.MemRequest.String()))
+	if !fit.DaemonSetReserve.CPU.IsZero() || !fit.DaemonSetReserve.Memory.IsZero() {
+		sb.WriteString(fmt.Sprintf("DaemonSet reserve: cpu %s, memory %s per node\n",
+			fit.DaemonSetReserve.CPU.String(), fit.DaemonSetReserve.Memory.String()))
+	}
+	sb.WriteString(fmt.Sprintf("Cluster headroom:  cpu %s, memory %s\n", fit.Cluster.CPU.String(), fit.Cluster.Memory.String()))
+	if fit.Largest.Name != "" {
+		sb.WriteString(fmt.Sprintf("Largest node:      %s (cpu %s, memory %s free)\n",
+			fit.Largest.Name, fit.Largest.CPU.String(), fit.Largest.Memory.String()))
+	}
+	sb.WriteString(fmt.Sprintf("Fits on:           %d of %d schedulable nodes\n", len(fit.FittingNodes), fit.SchedulableNodes))
+
+	switch {
+	case fit.Fits():
+		sb.WriteString(Success(fmt.Sprintf("✓ Yes: fits on %s", strings.Join(fit.FittingNodes, ", "))))
+	case fit.AggregateFits():
+		sb.WriteString(Warning("⚠ No: the cluster has room in aggregate, but no single node is big enough"))
+	default:
+		sb.WriteString(Error("✗ No: not enough unrequested capacity in the cluster"))
+	}
+
+	return sb.String()
+}
+
+// RenderPoolImbalance renders a warning line per pool holding a disproportionate
+// share of allocatable resources.
+func RenderPoolImbalance(shares []capacity.PoolShare) string {
+	var sb strings.Builder
+	for _, share := range shares {
+		sb.WriteString(Warning(fmt.Sprintf("⚠ %s holds %.0f%% of allocatable CPU and %.0f%% of allocatable memory",
+			share.Value, share.CPUShare*100, share.MemShare*100)))
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// RenderNamespacePlacement formats the pods and requests each node carries for a
+// namespace. SHARE is the node's fraction of the namespace's pods.
+func RenderNamespacePlacement(placements []resources.NodePlacement) string {
+	total := 0
+	for _, p := range placements {
+		total += p.Pods
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NODE\tPODS\tSHARE\tCPU REQUEST\tMEM REQUEST")
+	for _, p := range placements {
+		share := 0.0
+		if total > 0 {
+			share = float64(p.Pods) / float64(total) * 100
+		}
+		fmt.Fprintf(w, "%s\t%d\t%.0f%%\t%s\t%s\n",
+			p.NodeName, p.Pods, share, p.CPURequest.String(), p.MemRequest.String())
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderWorkloadSpread formats how each workload's pods are spread over nodes
+// and zones. Workloads with every replica on one node or in one zone are marked
+// as a resilience risk.
+func RenderWorkloadSpread(spreads []resources.WorkloadSpread) string {
+	if len(spreads) == 0 {
+		return "No workload-managed pods found."
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "WORKLOAD\tPODS\tNODES\tZONES\tRISK")
+	for _, s := range spreads {
+		zones := fmt.Sprintf("%d", len(s.Zones))
+		if s.Unzoned > 0 {
+			zones = fmt.Sprintf("%s (+%d pods unzoned)", zones, s.Unzoned)
+		}
+		risk := "-"
+		switch {
+		case s.SingleNode():
+			risk = "all pods on node " + firstKey(s.Nodes)
+		case s.SingleZone():
+			risk = "all pods in zone " + firstKey(s.Zones)
+		}
+		fmt.Fprintf(w, "%s/%s\t%d\t%d\t%s\t%s\n", s.Kind, s.Name, s.Pods, len(s.Nodes), zones, risk)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// firstKey returns the smallest key of m, or "" when m is empty
+func firstKey(m map[string]int) string {
+	first := ""
+	for k := range m {
+		if first == "" || k < first {
+			first = k
+		}
+	}
+	return first
+}
+
+// RenderHPATable formats HPA utilization targets next to the per-pod requests
+// they are measured against, followed by warnings for targets without a
+// request and HPAs running hot at their replica ceiling.
+func RenderHPATable(targets []resources.HPATarget) string {
+	if len(targets) == 0 {
+		return "No HorizontalPodAutoscalers with CPU or memory utilization targets found."
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tHPA\tTARGET\tRESOURCE\tREQUEST/POD\tTARGET %\tCURRENT %\tREPLICAS")
+	for _, t := range targets {
+		request := "-"
+		if t.HasRequest {
+			request = t.PodRequest.String()
+		}
+		current := "-"
+		if t.CurrentUtilization != nil {
+			current = fmt.Sprintf("%d%%", *t.CurrentUtilization)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s/%s\t%s\t%s\t%d%%\t%s\t%d (%d-%d)\n",
+			t.Namespace, t.Name, t.TargetKind, t.TargetName, t.Resource,
+			request, t.TargetUtilization, current,
+			t.CurrentReplicas, t.MinReplicas, t.MaxReplicas,
+		)
+	}
+	w.Flush()
+
+	for _, t := range targets {
+		switch {
+		case t.TargetKind == "Deployment" && !t.HasRequest:
+			buf.WriteString("\n")
+			buf.WriteString(Warning(fmt.Sprintf("⚠ %s/%s: %s has no %s request, so its utilization target cannot be computed",
+				t.Namespace, t.Name, t.TargetName, t.Resource)))
+		case t.CurrentUtilization != nil && *t.CurrentUtilization >= t.TargetUtilization && t.AtMaxReplicas():
+			buf.WriteString("\n")
+			buf.WriteString(Warning(fmt.Sprintf("⚠ %s/%s: %s at %d%% of requests (target %d%%) with no replicas left to add",
+				t.Namespace, t.Name, t.Resource, *t.CurrentUtilization, t.TargetUtilization)))
+		}
+	}
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderNodeImbalance formats the spread of per-node CPU and memory utilization,
+// followed by a warning for each resource whose hottest node exceeds the mean
+// by more than maxDeviation percentage points.
+func RenderNodeImbalance(imbalance capacity.NodeImbalance, maxDeviation float64) string {
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "RESOURCE\tMEAN\tSTDDEV\tMIN\tMAX\tHOTTEST NODE")
+	spreads := []struct {
+		name   string
+		spread capacity.UtilizationSpread
+	}{
+		{"cpu", imbalance.CPU},
+		{"memory", imbalance.Memory},
+	}
+	for _, s := range spreads {
+		fmt.Fprintf(w, "%s\t%.1f%%\t%.1f\t%.1f%%\t%.1f%%\t%s\n",
+			s.name, s.spread.Mean, s.spread.StdDev, s.spread.Min, s.spread.Max, s.spread.HottestNode)
+	}
+	w.Flush()
+
+	buf.WriteString("\n")
+	if !imbalance.Imbalanced(maxDeviation) {
+		fmt.Fprintf(&buf, "Balanced: every node is within %.0f points of the mean utilization.", maxDeviation)
+		return buf.String()
+	}
+	for _, s := range spreads {
+		if s.spread.Deviation() > maxDeviation {
+			buf.WriteString(Warning(fmt.Sprintf("⚠ %s %s utilization is %.1f%%, %.0f points above the mean of %.1f%%",
+				s.spread.HottestNode, s.name, s.spread.Max, s.spread.Deviation(), s.spread.Mean)))
+			buf.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
+
 // RenderUsageTable formats a table of container usages.
 func RenderUsageTable(usages []resources.ContainerUsage, top int) string {
+	return RenderUsageTableWithOptions(usages, top, UsageTableOptions{})
+}
+
+// UsageTableOptions controls RenderUsageTableWithOptions
+type UsageTableOptions struct {
+	// ShowNode adds a NODE column; usages need NodeName (see resources.AttachUsageNodes)
+	ShowNode bool
+}
+
+// RenderUsageTableWithOptions is RenderUsageTable with the given options
+func RenderUsageTableWithOptions(usages []resources.ContainerUsage, top int, opts UsageTableOptions) string {
 	if len(usages) == 0 {
 		return "No usage data available."
 	}
@@ -183,19 +771,45 @@ func RenderUsageTable(usages []resources.ContainerUsage, top int) string {
 		usages = usages[:top]
 	}
 
+	nodeHeader := ""
+	if opts.ShowNode {
+		nodeHeader = "NODE\t"
+	}
+
 	var buf bytes.Buffer
-	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tCPU\tMEMORY")
+	w := newTableWriter(&buf)
+	fmt.Fprintf(w, "NAMESPACE\tPOD\t%sCONTAINER\tCPU\tMEMORY (WORKING SET)\tWINDOW\tAGE\n", nodeHeader)
 	for _, u := range usages {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-			u.Namespace, u.PodName, u.ContainerName,
+		node := ""
+		if opts.ShowNode {
+			node = nodeOrDash(u.NodeName) + "\t"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s%s\t%s\t%s\t%s\t%s\n",
+			u.Namespace, truncateName(u.PodName), node, truncateName(u.ContainerName),
 			u.CPUUsage.String(), u.MemUsage.String(),
+			formatDuration(u.Window), formatSampleAge(u.Timestamp),
 		)
 	}
 	w.Flush()
 	return strings.TrimRight(buf.String(), "\n")
 }
 
+// formatDuration renders a metrics window, or "-" when unknown
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.String()
+}
+
+// formatSampleAge renders how long ago a metrics sample was taken, or "-" when unknown
+func formatSampleAge(ts time.Time) string {
+	if ts.IsZero() {
+		return "-"
+	}
+	return time.Since(ts).Round(time.Second).String()
+}
+
 // RenderDiffTable formats a table of container diffs.
 func RenderDiffTable(diffs []resources.ContainerDiff, top int) string {
 	if len(diffs) == 0 {
@@ -207,7 +821,7 @@ func RenderDiffTable(diffs []resources.ContainerDiff, top int) string {
 	}
 
 	var buf bytes.Buffer
-	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	w := newTableWriter(&buf)
 	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tCPU USAGE\tCPU REQ\tCPU RATIO\tMEM USAGE\tMEM REQ\tMEM RATIO")
 	for _, d := range diffs {
 		cpuRatio := "-"
@@ -218,8 +832,12 @@ func RenderDiffTable(diffs []resources.ContainerDiff, top int) string {
 		if d.HasMemRequest {
 			memRatio = fmt.Sprintf("%.2f", d.MemUsageToRequest)
 		}
+		container := truncateName(d.ContainerName)
+		if d.Orphan {
+			container += " (not in spec)"
+		}
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			d.Namespace, d.PodName, d.ContainerName,
+			d.Namespace, truncateName(d.PodName), container,
 			d.CPUUsage.String(), d.CPURequest.String(), cpuRatio,
 			d.MemUsage.String(), d.MemRequest.String(), memRatio,
 		)
@@ -228,31 +846,292 @@ func RenderDiffTable(diffs []resources.ContainerDiff, top int) string {
 	return strings.TrimRight(buf.String(), "\n")
 }
 
-// RenderPodResourceSummary formats a table of pod resource summaries (requests/limits).
-func RenderPodResourceSummary(pods []resources.PodResourceSummary, top int) string {
-	if len(pods) == 0 {
-		return "No pods found."
+// RenderNamespaceDiffTable formats per-namespace usage vs requests. Rows are
+// expected most wasteful first; that namespace is highlighted and called out
+// below the table.
+func RenderNamespaceDiffTable(diffs []resources.NamespaceDiff, top int) string {
+	if len(diffs) == 0 {
+		return "No diff data available."
 	}
 
-	// Limit to top N if top > 0
-	if top > 0 && len(pods) > top {
-		pods = pods[:top]
+	if top > 0 && len(diffs) > top {
+		diffs = diffs[:top]
 	}
 
 	var buf bytes.Buffer
-	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "NAMESPACE\tPOD\tCPU REQUEST\tCPU LIMIT\tMEM REQUEST\tMEM LIMIT")
-	for _, pod := range pods {
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tCONTAINERS\tCPU USAGE\tCPU REQ\tCPU EFFICIENCY\tMEM USAGE\tMEM REQ\tMEM EFFICIENCY")
+	for i, d := range diffs {
+		cpuEff := "-"
+		if d.HasCPURequest {
+			cpuEff = fmt.Sprintf("%.0f%%", d.CPUUsageToRequest*100)
+		}
+		memEff := "-"
+		if d.HasMemRequest {
+			memEff = fmt.Sprintf("%.0f%%", d.MemUsageToRequest*100)
+		}
+		name := d.Namespace
+		if i == 0 && d.HasCPURequest {
+			name = Warning(name)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			name, d.Containers,
+			d.CPUUsage.String(), d.CPURequest.String(), cpuEff,
+			d.MemUsage.String(), d.MemRequest.String(), memEff,
+		)
+	}
+	w.Flush()
+
+	if worst := diffs[0]; worst.HasCPURequest {
+		buf.WriteString(Warning(fmt.Sprintf("⚠ Most wasteful: %s uses %.0f%% of its requested CPU (%s of %s)",
+			worst.Namespace, worst.CPUUsageToRequest*100, worst.CPUUsage.String(), worst.CPURequest.String())))
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderRecommendationTable formats suggested requests alongside current requests
+// and the percentage change between them.
+func RenderRecommendationTable(recs []resources.Recommendation, top int) string {
+	if len(recs) == 0 {
+		return "No diff data available."
+	}
+
+	if top > 0 && len(recs) > top {
+		recs = recs[:top]
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tCPU USAGE\tCPU REQ\tSUGGESTED REQ\tCHANGE\tMEM USAGE\tMEM REQ\tSUGGESTED REQ\tCHANGE")
+	for _, r := range recs {
+		cpuReq, cpuDelta := "-", "-"
+		if r.HasCPURequest {
+			cpuReq = r.CPURequest.String()
+			cpuDelta = fmt.Sprintf("%+.0f%%", r.CPURequestDelta)
+		}
+		memReq, memDelta := "-", "-"
+		if r.HasMemRequest {
+			memReq = r.MemRequest.String()
+			memDelta = fmt.Sprintf("%+.0f%%", r.MemRequestDelta)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Namespace, truncateName(r.PodName), truncateName(r.ContainerName),
+			r.CPUUsage.String(), cpuReq, r.SuggestedCPURequest.String(), cpuDelta,
+			r.MemUsage.String(), memReq, r.SuggestedMemRequest.String(), memDelta,
+		)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderGhostTable formats a table of containers running without requests.
+func RenderGhostTable(ghosts []resources.ContainerDiff, top int) string {
+	if len(ghosts) == 0 {
+		return "No requestless containers with significant usage."
+	}
+
+	if top > 0 && len(ghosts) > top {
+		ghosts = ghosts[:top]
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tCPU USAGE\tCPU REQ\tMEM USAGE\tMEM REQ")
+	for _, g := range ghosts {
+		cpuReq := "-"
+		if g.HasCPURequest {
+			cpuReq = g.CPURequest.String()
+		}
+		memReq := "-"
+		if g.HasMemRequest {
+			memReq = g.MemRequest.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			g.Namespace, truncateName(g.PodName), truncateName(g.ContainerName),
+			g.CPUUsage.String(), cpuReq,
+			g.MemUsage.String(), memReq,
+		)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderThrottleTable formats a table of containers running close to their CPU limit.
+func RenderThrottleTable(risks []resources.ThrottleRisk, top int) string {
+	if len(risks) == 0 {
+		return "No containers close to their CPU limit."
+	}
+
+	if top > 0 && len(risks) > top {
+		risks = risks[:top]
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tCPU USAGE\tCPU LIMIT\tUSAGE/LIMIT")
+	for _, r := range risks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%.0f%%\n",
+			r.Namespace, truncateName(r.PodName), truncateName(r.ContainerName),
+			r.CPUUsage.String(), r.CPULimit.String(), r.CPUUsageToLimit*100,
+		)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// ratioBarWidth is the length of the longest bar in RenderRatioHistograms
+const ratioBarWidth = 30
+
+// RenderWasteReport formats the total reclaimable CPU and memory followed by
+// the top over-provisioned containers.
+func RenderWasteReport(report resources.WasteReport, top int) string {
+	if len(report.Offenders) == 0 {
+		return "No over-provisioned containers."
+	}
+
+	offenders := report.Offenders
+	if top > 0 && len(offenders) > top {
+		offenders = offenders[:top]
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Potential savings: CPU %s, memory %s (%d containers right-sized to usage +%.0f%%)\n\n",
+		report.ReclaimableCPU.String(), report.ReclaimableMem.String(),
+		len(report.Offenders), resources.DefaultSuggestHeadroom*100)
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tCPU USAGE\tCPU REQ\tRECLAIMABLE CPU\tMEM USAGE\tMEM REQ\tRECLAIMABLE MEM")
+	for _, o := range offenders {
+		cpuReq, memReq := "-", "-"
+		if o.HasCPURequest {
+			cpuReq = o.CPURequest.String()
+		}
+		if o.HasMemRequest {
+			memReq = o.MemRequest.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			o.Namespace, truncateName(o.PodName), truncateName(o.ContainerName),
+			o.CPUUsage.String(), cpuReq, o.ReclaimableCPU.String(),
+			o.MemUsage.String(), memReq, o.ReclaimableMem.String(),
+		)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderRatioHistograms formats the CPU and memory limit/request ratio
+// histograms as text, with bars scaled to the largest bucket of each resource
+func RenderRatioHistograms(histograms ...resources.RatioHistogram) string {
+	var sb strings.Builder
+	for i, h := range histograms {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s limit/request ratio:\n", h.Resource)
+
+		largest := 0
+		for _, n := range h.Counts {
+			largest = max(largest, n)
+		}
+
+		var buf bytes.Buffer
+		w := newTableWriter(&buf)
+		fmt.Fprintln(w, "RATIO\tCONTAINERS\t")
+		for j, b := range resources.RatioBuckets {
+			bar := ""
+			if largest > 0 {
+				bar = strings.Repeat("■", int(math.Round(float64(h.Counts[j])/float64(largest)*ratioBarWidth)))
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\n", b.Label, h.Counts[j], bar)
+		}
+		w.Flush()
+		sb.Write(buf.Bytes())
+		fmt.Fprintf(&sb, "Without request or limit: %d\n", h.Unset)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// RenderLintTable formats a table of lint findings, one row per container and rule
+func RenderLintTable(findings []resources.LintFinding, top int) string {
+	if len(findings) == 0 {
+		return "No lint findings."
+	}
+
+	if top > 0 && len(findings) > top {
+		findings = findings[:top]
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tRULE\tMESSAGE")
+	for _, f := range findings {
+		container := "-" // pod-level finding
+		if f.ContainerName != "" {
+			container = truncateName(f.ContainerName)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", f.Namespace, truncateName(f.PodName), container, f.Rule, f.Message)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderQuotaDriftTable formats a table of ResourceQuotas whose Used values
+// disagree with the summed pod requests/limits.
+func RenderQuotaDriftTable(drifts []resources.QuotaDrift) string {
+	if len(drifts) == 0 {
+		return "No ResourceQuota drift detected."
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tQUOTA\tRESOURCE\tQUOTA USED\tSUMMED\tDRIFT")
+	for _, d := range drifts {
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
-			pod.Namespace, pod.PodName,
-			pod.CPURequest.String(), pod.CPULimit.String(),
-			pod.MemRequest.String(), pod.MemLimit.String(),
+			d.Namespace, d.Quota, d.Resource,
+			d.QuotaUsed.String(), d.Summed.String(),
+			fmt.Sprintf("%.0f%%", d.Ratio*100),
 		)
 	}
 	w.Flush()
 	return strings.TrimRight(buf.String(), "\n")
 }
 
+// RenderUnusedQuotas formats one warning line per ResourceQuota that has hard
+// limits but no usage. It returns an empty string when there are none.
+func RenderUnusedQuotas(unused []resources.UnusedQuota) string {
+	var sb strings.Builder
+	for _, u := range unused {
+		sb.WriteString(Warning(fmt.Sprintf("⚠ ResourceQuota %s/%s has hard limits but nothing used (leftover?)", u.Namespace, u.Quota)))
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// RenderLimitRangeViolationTable formats containers whose requests/limits fall outside LimitRange bounds.
+func RenderLimitRangeViolationTable(violations []resources.LimitRangeViolation) string {
+	if len(violations) == 0 {
+		return "No LimitRange violations detected."
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tLIMITRANGE\tRESOURCE\tVALUE\tCONSTRAINT")
+	for _, v := range violations {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s %s\t%s\t%s %s\n",
+			v.Namespace, truncateName(v.Pod), truncateName(v.Container), v.LimitRange,
+			v.Resource, v.Field, v.Value.String(),
+			v.Bound, v.Constraint.String(),
+		)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderPodResourceSummary formats a table of pod resource summaries (requests/limits).
+func RenderPodResourceSummary(pods []resources.PodResourceSummary, top int) string {
+	columns, _ := LookupPodColumns(DefaultPodColumns)
+	return RenderPodResourceSummaryColumns(pods, top, columns)
+}
+
 // RenderPodResourceSummaryWithUsage formats a table of pod resource summaries including usage data.
 func RenderPodResourceSummaryWithUsage(pods []resources.PodResourceSummary, top int) string {
 	if len(pods) == 0 {
@@ -265,21 +1144,82 @@ func RenderPodResourceSummaryWithUsage(pods []resources.PodResourceSummary, top
 	}
 
 	var buf bytes.Buffer
-	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	w := newTableWriter(&buf)
 	fmt.Fprintln(w, "NAMESPACE\tPOD\tCPU USAGE\tCPU REQUEST\tCPU LIMIT\tMEM USAGE\tMEM REQUEST\tMEM LIMIT")
 	for _, pod := range pods {
+		memUsage := pod.MemUsage.String()
+		if memoryNearLimit(pod) {
+			memUsage = StatusCritical(memUsage)
+		}
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			pod.Namespace, pod.PodName,
+			pod.Namespace, truncateName(pod.PodName),
 			pod.CPUUsage.String(), pod.CPURequest.String(), pod.CPULimit.String(),
-			pod.MemUsage.String(), pod.MemRequest.String(), pod.MemLimit.String(),
+			memUsage, pod.MemRequest.String(), pod.MemLimit.String(),
+		)
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// OOMRiskRatio is the memory usage/limit ratio above which a pod is highlighted as at risk of OOM kill
+const OOMRiskRatio = 0.9
+
+// memoryNearLimit reports whether pod's memory usage exceeds OOMRiskRatio of its limit
+func memoryNearLimit(pod resources.PodResourceSummary) bool {
+	if pod.MemLimit.IsZero() {
+		return false
+	}
+	return float64(pod.MemUsage.Value())/float64(pod.MemLimit.Value()) > OOMRiskRatio
+}
+
+// RenderGroupedSummary formats a table of request/limit totals grouped by annotation value.
+func RenderGroupedSummary(groups []resources.ResourceGroupSummary) string {
+	if len(groups) == 0 {
+		return "No pods found."
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintf(w, "%s\tPODS\tCPU REQUEST\tCPU LIMIT\tMEM REQUEST\tMEM LIMIT\n", strings.ToUpper(groups[0].Key))
+	for _, g := range groups {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			g.Value, g.Pods,
+			g.CPURequest.String(), g.CPULimit.String(),
+			g.MemRequest.String(), g.MemLimit.String(),
 		)
 	}
 	w.Flush()
 	return strings.TrimRight(buf.String(), "\n")
 }
 
+// RenderHugePagesTable formats allocatable and requested hugepages per page size.
+// It returns an empty string when the cluster has no hugepages.
+func RenderHugePagesTable(summary *capacity.ClusterCapacitySummary) string {
+	sizes := summary.HugePageSizes()
+	if len(sizes) == 0 {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	fmt.Fprintln(w, "RESOURCE\tALLOCATABLE\tREQUESTS")
+	for _, size := range sizes {
+		allocatable := summary.HugePagesAllocatable[size]
+		requests := summary.HugePagesRequests[size]
+		fmt.Fprintf(w, "%s\t%s\t%s\n", size, allocatable.String(), requests.String())
+	}
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
 // RenderPodResourceSummaryTotals renders totals for pod resource summaries.
 func RenderPodResourceSummaryTotals(pods []resources.PodResourceSummary) string {
+	return RenderPodResourceSummaryTotalsWithOptions(pods, DisplayOptions{})
+}
+
+// RenderPodResourceSummaryTotalsWithOptions is RenderPodResourceSummaryTotals
+// without the request or limit totals hidden by opts.
+func RenderPodResourceSummaryTotalsWithOptions(pods []resources.PodResourceSummary, opts DisplayOptions) string {
 	if len(pods) == 0 {
 		return ""
 	}
@@ -308,11 +1248,19 @@ func RenderPodResourceSummaryTotals(pods []resources.PodResourceSummary) string
 	var sb strings.Builder
 	sb.WriteString("=== TOTALS ===\n")
 	sb.WriteString(fmt.Sprintf("Total CPU Usage:       %s\n", totalCPUUsage.String()))
-	sb.WriteString(fmt.Sprintf("Total CPU Requests:    %s\n", totalCPURequest.String()))
-	sb.WriteString(fmt.Sprintf("Total CPU Limits:      %s\n", totalCPULimit.String()))
+	if !opts.HideRequests {
+		sb.WriteString(fmt.Sprintf("Total CPU Requests:    %s\n", totalCPURequest.String()))
+	}
+	if !opts.HideLimits {
+		sb.WriteString(fmt.Sprintf("Total CPU Limits:      %s\n", totalCPULimit.String()))
+	}
 	sb.WriteString(fmt.Sprintf("\nTotal Memory Usage:    %s\n", totalMemUsage.String()))
-	sb.WriteString(fmt.Sprintf("Total Memory Requests: %s\n", totalMemRequest.String()))
-	sb.WriteString(fmt.Sprintf("Total Memory Limits:   %s\n", totalMemLimit.String()))
+	if !opts.HideRequests {
+		sb.WriteString(fmt.Sprintf("Total Memory Requests: %s\n", totalMemRequest.String()))
+	}
+	if !opts.HideLimits {
+		sb.WriteString(fmt.Sprintf("Total Memory Limits:   %s\n", totalMemLimit.String()))
+	}
 
 	return strings.TrimRight(sb.String(), "\n")
 }
@@ -361,3 +361,23 @@ func TestRenderPodResourceSummaryTotals_Comprehensive(t *testing.T) {
 		t.Error("expected aggregated values in totals")
 	}
 }
+
+// TestRenderUsageTable_ShowNode tests the NODE column of the usage table
+func TestRenderUsageTable_ShowNode(t *testing.T) {
+	usages := []resources.ContainerUsage{
+		{Namespace: "default", PodName: "web-pod", ContainerName: "web", NodeName: "worker-2"},
+	}
+
+	result := RenderUsageTableWithOptions(usages, 0, UsageTableOptions{ShowNode: true})
+	lines := strings.Split(result, "\n")
+	if fields := strings.Fields(lines[0]); fields[2] != "NODE" {
+		t.Errorf("expected NODE column after POD, got %q", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); fields[1] != "web-pod" || fields[2] != "worker-2" {
+		t.Errorf("expected web-pod on worker-2, got %q", lines[1])
+	}
+
+	if strings.Contains(RenderUsageTable(usages, 0), "NODE") {
+		t.Error("expected no NODE column by default")
+	}
+}
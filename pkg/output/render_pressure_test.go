@@ -54,6 +54,29 @@ func TestRenderPressureSimple(t *testing.T) {
 	}
 }
 
+func TestRenderPressureOneline(t *testing.T) {
+	SetGlobalColorEnabled(false)
+	defer SetGlobalColorEnabled(true)
+
+	pressure := &capacity.ClusterPressure{
+		Overall:        capacity.PressureHigh,
+		CPUUtilization: 82.4,
+		MemUtilization: 71.0,
+		NodePressures: []capacity.NodePressure{
+			{NodeName: "node-1", CPUPressure: capacity.PressureHigh, CPUUtilization: 92.0},
+		},
+	}
+
+	result := RenderPressureOneline(pressure)
+
+	if result != "CLUSTER: HIGH (cpu 82%, mem 71%)" {
+		t.Errorf("unexpected one-liner: %q", result)
+	}
+	if strings.Contains(result, "\n") || strings.Contains(result, "node-1") {
+		t.Errorf("expected no per-node lines, got: %q", result)
+	}
+}
+
 func TestRenderPressureSimple_AllLow(t *testing.T) {
 	pressure := &capacity.ClusterPressure{
 		Overall: capacity.PressureLow,
@@ -154,3 +177,106 @@ func TestColorizePressureLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderPressureExplain_ReservationWarning(t *testing.T) {
+	pressure := &capacity.ClusterPressure{
+		Overall: capacity.PressureLow,
+		NodePressures: []capacity.NodePressure{
+			{NodeName: "reserved", CPUPressure: capacity.PressureLow, MemPressure: capacity.PressureLow, CPUReservationRatio: 0.5},
+			{NodeName: "lean", CPUPressure: capacity.PressureLow, MemPressure: capacity.PressureLow, CPUReservationRatio: 0.05},
+		},
+	}
+
+	out := RenderPressureExplain(pressure)
+	if !strings.Contains(out, "CPU RESERVED") {
+		t.Errorf("expected reservation column, got: %s", out)
+	}
+	if !strings.Contains(out, "Node reserved reserves 50% CPU") {
+		t.Errorf("expected reservation warning for node 'reserved', got: %s", out)
+	}
+	if strings.Contains(out, "Node lean reserves") {
+		t.Errorf("did not expect warning for node 'lean', got: %s", out)
+	}
+}
+
+func TestRenderPressureExplain_DaemonSetRequests(t *testing.T) {
+	pressure := &capacity.ClusterPressure{
+		NodePressures: []capacity.NodePressure{
+			{NodeName: "n1", DaemonSetRequest: capacity.AllocatableResources{CPU: 250, Memory: 128 * 1024 * 1024}},
+		},
+	}
+
+	out := RenderPressureExplain(pressure)
+	for _, want := range []string{"DS CPU REQ", "250m", "128Mi"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderPressureSimple_PerResourceOverall(t *testing.T) {
+	SetGlobalColorEnabled(false)
+	defer SetGlobalColorEnabled(true)
+
+	pressure := &Pressure{
+		Overall:    capacity.PressureHigh,
+		OverallCPU: capacity.PressureHigh,
+		OverallMem: capacity.PressureLow,
+	}
+	out := RenderPressureSimple(pressure)
+	if !strings.Contains(out, "Cluster Pressure: HIGH (CPU HIGH, memory LOW)") {
+		t.Errorf("expected per-resource breakdown, got: %s", out)
+	}
+}
+
+func TestRenderPressureSimple_CustomLabels(t *testing.T) {
+	SetGlobalPressureStyle(PressureStyle{
+		Labels: map[capacity.PressureLevel]string{
+			capacity.PressureLow:  "OK",
+			capacity.PressureHigh: "WARN",
+		},
+		Colors: map[capacity.PressureLevel]string{capacity.PressureHigh: "blue"},
+	})
+	defer SetGlobalPressureStyle(PressureStyle{})
+
+	pressure := &Pressure{
+		Overall: capacity.PressureLow,
+		NodePressures: []capacity.NodePressure{
+			{NodeName: "worker-1", CPUPressure: capacity.PressureHigh, CPUUtilization: 92, MemPressure: capacity.PressureLow},
+		},
+	}
+	out := RenderPressureSimple(pressure)
+	if !strings.Contains(out, "Cluster Pressure: OK") {
+		t.Errorf("expected custom label for overall pressure, got: %s", out)
+	}
+	if !strings.Contains(out, "CPU WARN (92%)") {
+		t.Errorf("expected custom label for node pressure, got: %s", out)
+	}
+	if strings.Contains(out, "HIGH") || strings.Contains(out, "LOW") {
+		t.Errorf("expected built-in names to be replaced, got: %s", out)
+	}
+}
+
+func TestRenderPressureSimple_NamespaceThreshold(t *testing.T) {
+	SetGlobalColorEnabled(false)
+	defer SetGlobalColorEnabled(true)
+
+	pressure := &Pressure{
+		Overall: capacity.PressureLow,
+		NamespacePressures: []capacity.NamespacePressure{
+			{Namespace: "payments", CPUPercent: 60, MemPercent: 20},
+		},
+	}
+
+	if out := RenderPressureSimple(pressure); strings.Contains(out, "payments") {
+		t.Errorf("expected namespace at 60%% to be hidden by the default cutoff, got: %s", out)
+	}
+
+	out := RenderPressureSimpleWithOptions(pressure, PressureSimpleOptions{NamespaceThreshold: 50})
+	if !strings.Contains(out, "Namespace payments: CPU 60% requested") {
+		t.Errorf("expected namespace at 60%% with a 50%% cutoff, got: %s", out)
+	}
+	if strings.Contains(out, "Memory") {
+		t.Errorf("expected memory at 20%% to stay hidden, got: %s", out)
+	}
+}
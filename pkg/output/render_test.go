@@ -3,8 +3,11 @@ package output
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/marcgeld/cobrak/pkg/capacity"
 	"github.com/marcgeld/cobrak/pkg/resources"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -29,6 +32,9 @@ func TestRenderNamespaceInventoryTable_WithData(t *testing.T) {
 		},
 	}
 	out := RenderNamespaceInventoryTable(inv)
+	if !strings.Contains(out, "GUARANTEED%") {
+		t.Errorf("expected GUARANTEED%% column in output, got: %s", out)
+	}
 	if !strings.Contains(out, "default") {
 		t.Errorf("expected 'default' in output, got: %s", out)
 	}
@@ -54,6 +60,27 @@ func TestRenderUsageTable_Empty(t *testing.T) {
 	}
 }
 
+func TestRenderUsageTable_ShowsWindow(t *testing.T) {
+	usages := []resources.ContainerUsage{
+		{
+			Namespace:     "default",
+			PodName:       "web",
+			ContainerName: "app",
+			CPUUsage:      resource.MustParse("100m"),
+			MemUsage:      resource.MustParse("64Mi"),
+			Timestamp:     time.Now().Add(-time.Minute),
+			Window:        30 * time.Second,
+		},
+		{Namespace: "default", PodName: "legacy", ContainerName: "app"},
+	}
+	out := RenderUsageTable(usages, 0)
+	for _, want := range []string{"WORKING SET", "WINDOW", "30s", "1m"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
 func TestRenderDiffTable_Empty(t *testing.T) {
 	out := RenderDiffTable(nil, 10)
 	if !strings.Contains(out, "No diff") {
@@ -61,9 +88,478 @@ func TestRenderDiffTable_Empty(t *testing.T) {
 	}
 }
 
+func TestRenderRecommendationTable(t *testing.T) {
+	diffs := []resources.ContainerDiff{
+		{
+			Namespace:         "default",
+			PodName:           "web",
+			ContainerName:     "app",
+			CPUUsage:          resource.MustParse("250m"),
+			CPURequest:        resource.MustParse("500m"),
+			HasCPURequest:     true,
+			CPUUsageToRequest: 0.5,
+			HasUsage:          true,
+		},
+	}
+	out := RenderRecommendationTable(resources.Recommend(diffs, resources.DefaultSuggestHeadroom), 10)
+	for _, want := range []string{"SUGGESTED REQ", "300m", "-40%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderRecommendationTable_SkipsUnsampled(t *testing.T) {
+	diffs := []resources.ContainerDiff{
+		{
+			Namespace:     "default",
+			PodName:       "web",
+			ContainerName: "migrate",
+			CPURequest:    resource.MustParse("500m"),
+			HasCPURequest: true,
+		},
+	}
+	out := RenderRecommendationTable(resources.Recommend(diffs, resources.DefaultSuggestHeadroom), 10)
+	if strings.Contains(out, "migrate") || strings.Contains(out, "-100%") {
+		t.Errorf("expected no suggestion for a container without a metrics sample, got: %s", out)
+	}
+	if !strings.Contains(out, "No diff data") {
+		t.Errorf("expected the empty message, got: %s", out)
+	}
+}
+
 func TestRenderPolicySummary_Empty(t *testing.T) {
 	out := RenderPolicySummary(nil)
 	if !strings.Contains(out, "No policy") {
 		t.Errorf("expected 'No policy' in output, got: %s", out)
 	}
 }
+
+func TestRenderGhostTable(t *testing.T) {
+	out := RenderGhostTable(nil, 10)
+	if !strings.Contains(out, "No requestless") {
+		t.Errorf("expected empty message, got: %s", out)
+	}
+
+	ghosts := []resources.ContainerDiff{
+		{Namespace: "default", PodName: "hungry", ContainerName: "app", CPUUsage: resource.MustParse("800m")},
+	}
+	out = RenderGhostTable(ghosts, 10)
+	if !strings.Contains(out, "hungry") || !strings.Contains(out, "800m") {
+		t.Errorf("expected ghost row in output, got: %s", out)
+	}
+}
+
+func TestRenderThrottleTable(t *testing.T) {
+	out := RenderThrottleTable(nil, 10)
+	if !strings.Contains(out, "No containers close") {
+		t.Errorf("expected empty message, got: %s", out)
+	}
+
+	risks := []resources.ThrottleRisk{{
+		ContainerDiff: resources.ContainerDiff{
+			Namespace: "default", PodName: "busy", ContainerName: "app",
+			CPUUsage: resource.MustParse("950m"), CPULimit: resource.MustParse("1"), HasCPULimit: true,
+		},
+		CPUUsageToLimit: 0.95,
+	}}
+	out = RenderThrottleTable(risks, 10)
+	if !strings.Contains(out, "busy") || !strings.Contains(out, "95%") {
+		t.Errorf("expected throttle row in output, got: %s", out)
+	}
+}
+
+func TestRenderWasteReport(t *testing.T) {
+	out := RenderWasteReport(resources.WasteReport{}, 10)
+	if !strings.Contains(out, "No over-provisioned") {
+		t.Errorf("expected empty message, got: %s", out)
+	}
+
+	report := resources.ComputeWaste([]resources.ContainerDiff{{
+		Namespace: "default", PodName: "idle", ContainerName: "app",
+		CPUUsage: resource.MustParse("100m"), CPURequest: resource.MustParse("1"), HasCPURequest: true, HasUsage: true,
+	}})
+	out = RenderWasteReport(report, 10)
+	if !strings.Contains(out, "Potential savings: CPU 880m") || !strings.Contains(out, "idle") {
+		t.Errorf("expected savings line and offender row, got: %s", out)
+	}
+}
+
+func TestRenderWorkloadSpread(t *testing.T) {
+	out := RenderWorkloadSpread([]resources.WorkloadSpread{
+		{Kind: "Deployment", Name: "api", Pods: 3, Nodes: map[string]int{"node-a": 3}, Zones: map[string]int{"zone-1": 3}},
+		{Kind: "StatefulSet", Name: "db", Pods: 2, Nodes: map[string]int{"node-a": 1, "node-b": 1}, Zones: map[string]int{"zone-1": 1, "zone-2": 1}},
+	})
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got:\n%s", out)
+	}
+	if !strings.Contains(lines[1], "Deployment/api") || !strings.Contains(lines[1], "all pods on node node-a") {
+		t.Errorf("expected api flagged on node-a, got: %s", lines[1])
+	}
+	if !strings.HasSuffix(strings.TrimSpace(lines[2]), "-") {
+		t.Errorf("expected no risk for db, got: %s", lines[2])
+	}
+}
+
+func TestRenderPodDetail(t *testing.T) {
+	summary := &resources.PodResourceSummary{
+		Namespace:  "shop",
+		PodName:    "web",
+		CPURequest: resource.MustParse("250m"),
+		MemLimit:   resource.MustParse("512Mi"),
+	}
+	containers := []resources.ContainerResources{
+		{Namespace: "shop", PodName: "web", ContainerName: "migrate", IsInit: true, CPURequest: resource.MustParse("50m"), HasCPURequest: true},
+		{
+			Namespace:     "shop",
+			PodName:       "web",
+			ContainerName: "nginx",
+			CPURequest:    resource.MustParse("200m"),
+			HasCPURequest: true,
+			MemLimit:      resource.MustParse("512Mi"),
+			HasMemLimit:   true,
+		},
+	}
+
+	out := RenderPodDetail(summary, containers, nil)
+	for _, want := range []string{"Pod: shop/web", "request 250m", "migrate", "nginx", "200m", "512Mi"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "CPU USAGE") {
+		t.Errorf("did not expect usage columns without metrics, got: %s", out)
+	}
+
+	diffs := resources.BuildDiff(containers, []resources.ContainerUsage{
+		{Namespace: "shop", PodName: "web", ContainerName: "nginx", CPUUsage: resource.MustParse("100m")},
+	})
+	out = RenderPodDetail(summary, containers, diffs)
+	if !strings.Contains(out, "CPU USAGE") || !strings.Contains(out, "0.50") {
+		t.Errorf("expected usage and ratio with metrics, got: %s", out)
+	}
+}
+
+func TestRenderFit(t *testing.T) {
+	fit := &capacity.FitResult{
+		CPURequest:       resource.MustParse("2"),
+		MemRequest:       resource.MustParse("4Gi"),
+		Cluster:          capacity.NodeHeadroom{CPU: resource.MustParse("4500m"), Memory: resource.MustParse("20Gi")},
+		Largest:          capacity.NodeHeadroom{Name: "n1", CPU: resource.MustParse("1500m"), Memory: resource.MustParse("7Gi")},
+		SchedulableNodes: 3,
+	}
+
+	out := RenderFit(fit)
+	for _, want := range []string{"4500m", "n1", "0 of 3", "no single node"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+
+	fit.FittingNodes = []string{"n2"}
+	if out := RenderFit(fit); !strings.Contains(out, "fits on n2") {
+		t.Errorf("expected fitting node in output, got: %s", out)
+	}
+}
+
+func TestRenderLintTable(t *testing.T) {
+	out := RenderLintTable(nil, 10)
+	if !strings.Contains(out, "No lint findings") {
+		t.Errorf("expected empty message, got: %s", out)
+	}
+
+	findings := []resources.LintFinding{
+		{Namespace: "default", PodName: "web", ContainerName: "app", Rule: "mem-limit-without-request", Message: "risky"},
+	}
+	out = RenderLintTable(findings, 10)
+	if !strings.Contains(out, "web") || !strings.Contains(out, "mem-limit-without-request") {
+		t.Errorf("expected finding row in output, got: %s", out)
+	}
+}
+
+func TestRenderQuotaDriftTable(t *testing.T) {
+	out := RenderQuotaDriftTable(nil)
+	if !strings.Contains(out, "No ResourceQuota drift") {
+		t.Errorf("expected empty message, got: %s", out)
+	}
+
+	drifts := []resources.QuotaDrift{
+		{
+			Namespace: "team-a",
+			Quota:     "compute",
+			Resource:  "requests.cpu",
+			QuotaUsed: resource.MustParse("500m"),
+			Summed:    resource.MustParse("1"),
+			Ratio:     0.5,
+		},
+	}
+	out = RenderQuotaDriftTable(drifts)
+	for _, want := range []string{"team-a", "requests.cpu", "500m", "50%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderNodePoolTable(t *testing.T) {
+	pools := []capacity.NodePool{
+		{
+			Label:          capacity.DefaultPoolLabel,
+			Value:          "m5.large",
+			Nodes:          []string{"a", "b"},
+			CPUAllocatable: resource.MustParse("4"),
+			CPURequests:    resource.MustParse("3"),
+			CPUUtilization: 75,
+			Pressure:       capacity.PressureMedium,
+		},
+	}
+	out := RenderNodePoolTable(pools)
+	for _, want := range []string{capacity.DefaultPoolLabel, "m5.large", "3/4", "75.0%", "MEDIUM"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderPoolImbalance(t *testing.T) {
+	if out := RenderPoolImbalance(nil); out != "" {
+		t.Errorf("expected no output for balanced pools, got: %s", out)
+	}
+
+	out := RenderPoolImbalance([]capacity.PoolShare{{Value: "eu-west-1a", CPUShare: 0.9, MemShare: 0.8}})
+	for _, want := range []string{"eu-west-1a", "90%", "80%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderGroupedSummary(t *testing.T) {
+	groups := []resources.ResourceGroupSummary{
+		{Key: "team", Value: "payments", Pods: 2, CPURequest: resource.MustParse("750m")},
+		{Key: "team", Value: resources.NoGroupValue, Pods: 1, CPURequest: resource.MustParse("100m")},
+	}
+	out := RenderGroupedSummary(groups)
+	for _, want := range []string{"TEAM", "payments", "750m", "<none>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderMissingResourcesTable_ShowsImage(t *testing.T) {
+	containers := []resources.ContainerResources{
+		{
+			Namespace:     "default",
+			PodName:       "web",
+			ContainerName: "nginx",
+			Image:         "nginx:1.27-alpine",
+			HasCPURequest: true,
+		},
+	}
+	out := RenderMissingResourcesTable(containers, 10)
+	if !strings.Contains(out, "IMAGE") {
+		t.Errorf("expected IMAGE column header, got: %s", out)
+	}
+	if !strings.Contains(out, "nginx:1.27-alpine") {
+		t.Errorf("expected container image in output, got: %s", out)
+	}
+}
+
+func TestRenderLimitRangeViolationTable(t *testing.T) {
+	if out := RenderLimitRangeViolationTable(nil); !strings.Contains(out, "No LimitRange violations") {
+		t.Errorf("expected empty message, got: %s", out)
+	}
+
+	violations := []resources.LimitRangeViolation{{
+		Namespace: "team-a", Pod: "app", Container: "app", LimitRange: "limits",
+		Resource: v1.ResourceCPU, Field: "limit", Bound: "max",
+		Value: resource.MustParse("4"), Constraint: resource.MustParse("2"),
+	}}
+	out := RenderLimitRangeViolationTable(violations)
+	for _, want := range []string{"LIMITRANGE", "team-a", "cpu limit", "max 2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderEffectiveResourcesTable_MarksDefaults(t *testing.T) {
+	containers := []resources.EffectiveContainerResources{{
+		ContainerResources: resources.ContainerResources{
+			Namespace: "team-a", PodName: "app", ContainerName: "app",
+			CPURequest: resource.MustParse("100m"), HasCPURequest: true,
+			MemRequest: resource.MustParse("256Mi"), HasMemRequest: true,
+		},
+		CPURequestDefaulted: true,
+	}}
+	out := RenderEffectiveResourcesTable(containers, 0)
+	for _, want := range []string{"CPU REQ", "100m*", "256Mi", "* = LimitRange default"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "256Mi*") {
+		t.Errorf("did not expect the container's own request to be marked, got: %s", out)
+	}
+}
+
+func TestRenderMissingResourcesTable_InitKindOnly(t *testing.T) {
+	containers := []resources.ContainerResources{
+		{Namespace: "default", PodName: "web", ContainerName: "migrate", IsInit: true},
+		{Namespace: "default", PodName: "web", ContainerName: "app"},
+	}
+	out := RenderMissingResourcesTable(resources.FilterContainersByKind(containers, resources.KindInit), 10)
+	if !strings.Contains(out, "migrate") {
+		t.Errorf("expected init container in output, got: %s", out)
+	}
+	if strings.Contains(out, "app") {
+		t.Errorf("did not expect regular container in output, got: %s", out)
+	}
+}
+
+func TestRenderNamespaceInventoryTable_WithLabels(t *testing.T) {
+	inv := []resources.NamespaceInventory{
+		{Namespace: "team-a", Labels: map[string]string{"owner": "teamA"}},
+		{Namespace: "scratch", Labels: map[string]string{}},
+	}
+	out := RenderNamespaceInventoryTable(inv)
+	if !strings.Contains(out, "LABELS") {
+		t.Errorf("expected LABELS column, got: %s", out)
+	}
+	if !strings.Contains(out, "owner=teamA") {
+		t.Errorf("expected owner=teamA in output, got: %s", out)
+	}
+}
+
+func TestRenderContainerSummary(t *testing.T) {
+	containers := []resources.ContainerResources{
+		{Namespace: "default", PodName: "web", ContainerName: "app", CPURequest: resource.MustParse("200m"), HasCPURequest: true},
+		{Namespace: "default", PodName: "web", ContainerName: "envoy"},
+	}
+	out := RenderContainerSummary(containers, 0)
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d lines:\n%s", len(lines), out)
+	}
+	if !strings.Contains(lines[1], "app") || !strings.Contains(lines[1], "200m") {
+		t.Errorf("expected app row with 200m, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "envoy") {
+		t.Errorf("expected envoy row, got %q", lines[2])
+	}
+}
+
+func TestRenderHugePagesTable(t *testing.T) {
+	summary := &capacity.ClusterCapacitySummary{}
+	if out := RenderHugePagesTable(summary); out != "" {
+		t.Errorf("expected no table without hugepages, got: %s", out)
+	}
+
+	summary.HugePagesAllocatable = map[v1.ResourceName]resource.Quantity{"hugepages-2Mi": resource.MustParse("1Gi")}
+	out := RenderHugePagesTable(summary)
+	for _, want := range []string{"hugepages-2Mi", "1Gi"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderNodeImbalance(t *testing.T) {
+	balanced := capacity.NodeImbalance{
+		Nodes:  2,
+		CPU:    capacity.UtilizationSpread{Mean: 50, Min: 50, Max: 50, HottestNode: "node-a"},
+		Memory: capacity.UtilizationSpread{Mean: 40, Min: 40, Max: 40, HottestNode: "node-a"},
+	}
+	if out := RenderNodeImbalance(balanced, capacity.DefaultImbalanceDeviation); !strings.Contains(out, "Balanced") {
+		t.Errorf("expected balanced note, got: %s", out)
+	}
+
+	imbalanced := balanced
+	imbalanced.CPU = capacity.UtilizationSpread{Mean: 33.3, StdDev: 40.3, Min: 0, Max: 90, HottestNode: "node-b"}
+	out := RenderNodeImbalance(imbalanced, capacity.DefaultImbalanceDeviation)
+	for _, want := range []string{"STDDEV", "40.3", "node-b cpu utilization is 90.0%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "memory utilization is") {
+		t.Errorf("did not expect a memory warning, got: %s", out)
+	}
+}
+
+func TestRenderNamespacePlacement(t *testing.T) {
+	placements := []resources.NodePlacement{
+		{NodeName: "node-a", Pods: 3, CPURequest: resource.MustParse("1500m"), MemRequest: resource.MustParse("768Mi")},
+		{NodeName: "node-b", Pods: 1, CPURequest: resource.MustParse("500m"), MemRequest: resource.MustParse("256Mi")},
+	}
+	out := RenderNamespacePlacement(placements)
+	for _, want := range []string{"NODE", "SHARE", "node-a", "75%", "1500m", "node-b", "25%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderHPATable_ShowsTargetPercentage(t *testing.T) {
+	SetGlobalColorEnabled(false)
+	current := int32(40)
+	targets := []resources.HPATarget{{
+		Namespace: "shop", Name: "web", TargetKind: "Deployment", TargetName: "web",
+		Resource: "cpu", MinReplicas: 2, MaxReplicas: 6, CurrentReplicas: 3,
+		TargetUtilization: 70, CurrentUtilization: &current,
+		PodRequest: resource.MustParse("250m"), HasRequest: true,
+	}}
+	out := RenderHPATable(targets)
+	for _, want := range []string{"TARGET %", "Deployment/web", "250m", "70%", "40%", "3 (2-6)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "⚠") {
+		t.Errorf("expected no warnings, got: %s", out)
+	}
+}
+
+func TestRenderMiniBar_SixtyPercent(t *testing.T) {
+	SetGlobalColorEnabled(false)
+	out := renderMiniBar(60, 5)
+	if got := strings.Count(out, "■"); got != 3 {
+		t.Errorf("expected 3 filled cells, got %d in %q", got, out)
+	}
+	if got := strings.Count(out, "□"); got != 2 {
+		t.Errorf("expected 2 empty cells, got %d in %q", got, out)
+	}
+	if !strings.HasSuffix(out, "60%") {
+		t.Errorf("expected percentage suffix, got %q", out)
+	}
+}
+
+func TestRenderNamespaceDiffTable_HighlightsMostWasteful(t *testing.T) {
+	diffs := []resources.NamespaceDiff{
+		{Namespace: "idle", Containers: 2, CPUUsage: resource.MustParse("400m"), CPURequest: resource.MustParse("2"), HasCPURequest: true, CPUUsageToRequest: 0.2},
+		{Namespace: "busy", Containers: 2, CPUUsage: resource.MustParse("900m"), CPURequest: resource.MustParse("1"), HasCPURequest: true, CPUUsageToRequest: 0.9},
+	}
+	out := RenderNamespaceDiffTable(diffs, 0)
+	for _, want := range []string{"CPU EFFICIENCY", "20%", "90%", "Most wasteful: idle"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
+func TestRenderRatioHistograms(t *testing.T) {
+	cpu := resources.RatioHistogram{Resource: "CPU", Counts: []int{4, 2, 0, 1}, Unset: 3}
+	got := RenderRatioHistograms(cpu)
+
+	for _, want := range []string{"CPU limit/request ratio:", "RATIO", ">4x", "Without request or limit: 3"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	// The largest bucket gets the full-width bar
+	if !strings.Contains(got, strings.Repeat("■", ratioBarWidth)) {
+		t.Errorf("expected a full-width bar for the largest bucket, got:\n%s", got)
+	}
+}
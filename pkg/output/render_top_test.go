@@ -1,6 +1,7 @@
 package output
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/marcgeld/cobrak/pkg/resources"
@@ -179,3 +180,69 @@ func containsString(haystack, needle string) bool {
 	}
 	return false
 }
+
+func TestRenderTables_NonPositiveTopShowsAll(t *testing.T) {
+	names := []string{"alpha", "bravo", "charlie"}
+	var usages []resources.ContainerUsage
+	var diffs []resources.ContainerDiff
+	var pods []resources.PodResourceSummary
+	for _, n := range names {
+		usages = append(usages, resources.ContainerUsage{Namespace: "default", PodName: n, ContainerName: "app"})
+		diffs = append(diffs, resources.ContainerDiff{Namespace: "default", PodName: n, ContainerName: "app"})
+		pods = append(pods, resources.PodResourceSummary{Namespace: "default", PodName: n})
+	}
+
+	for _, top := range []int{0, -1} {
+		rendered := map[string]string{
+			"RenderUsageTable":         RenderUsageTable(usages, top),
+			"RenderDiffTable":          RenderDiffTable(diffs, top),
+			"RenderPodResourceSummary": RenderPodResourceSummary(pods, top),
+		}
+		for renderer, out := range rendered {
+			for _, n := range names {
+				if !strings.Contains(out, n) {
+					t.Errorf("%s(top=%d): expected %s in output, got:\n%s", renderer, top, n, out)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderPodResourceSummaryWithUsage_HighlightsOOMRisk(t *testing.T) {
+	t.Setenv("CLICOLOR_FORCE", "1")
+	t.Setenv("TERM", "xterm")
+	SetGlobalColorEnabled(true)
+	defer SetGlobalColorEnabled(false)
+
+	pods := []resources.PodResourceSummary{
+		{
+			Namespace: "default",
+			PodName:   "near-limit",
+			MemUsage:  *resource.NewQuantity(95*1024*1024, resource.BinarySI),
+			MemLimit:  *resource.NewQuantity(100*1024*1024, resource.BinarySI),
+		},
+		{
+			Namespace: "default",
+			PodName:   "comfortable",
+			MemUsage:  *resource.NewQuantity(50*1024*1024, resource.BinarySI),
+			MemLimit:  *resource.NewQuantity(100*1024*1024, resource.BinarySI),
+		},
+	}
+
+	out := RenderPodResourceSummaryWithUsage(pods, 0)
+	red := StatusCritical(pods[0].MemUsage.String())
+	if !strings.Contains(red, "\x1b[31m") {
+		t.Fatalf("expected red escape sequence with colors forced, got %q", red)
+	}
+	if !strings.Contains(out, red) {
+		t.Errorf("expected red MEM USAGE for pod at 95%% of its limit, got:\n%s", out)
+	}
+	if strings.Contains(out, StatusCritical(pods[1].MemUsage.String())) {
+		t.Errorf("did not expect red MEM USAGE for pod at 50%% of its limit, got:\n%s", out)
+	}
+
+	SetGlobalColorEnabled(false)
+	if out := RenderPodResourceSummaryWithUsage(pods, 0); strings.Contains(out, "\x1b[") {
+		t.Errorf("expected no escape sequences with colors disabled, got:\n%s", out)
+	}
+}
@@ -0,0 +1,200 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PodRef identifies a pod in a report diff
+type PodRef struct {
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Pod       string `json:"pod" yaml:"pod"`
+}
+
+// NamespaceRequestDelta is the change in a namespace's total requests between
+// two reports. A namespace missing from one side counts as zero there.
+type NamespaceRequestDelta struct {
+	Namespace       string `json:"namespace" yaml:"namespace"`
+	CPURequestsOld  string `json:"cpu_requests_old" yaml:"cpuRequestsOld"`
+	CPURequestsNew  string `json:"cpu_requests_new" yaml:"cpuRequestsNew"`
+	CPURequestDelta string `json:"cpu_request_delta" yaml:"cpuRequestDelta"`
+	MemRequestsOld  string `json:"mem_requests_old" yaml:"memRequestsOld"`
+	MemRequestsNew  string `json:"mem_requests_new" yaml:"memRequestsNew"`
+	MemRequestDelta string `json:"mem_request_delta" yaml:"memRequestDelta"`
+}
+
+// ReportDiff describes what changed between a baseline export and the current one
+type ReportDiff struct {
+	PodsAdded   []PodRef                `json:"pods_added" yaml:"podsAdded"`
+	PodsRemoved []PodRef                `json:"pods_removed" yaml:"podsRemoved"`
+	Namespaces  []NamespaceRequestDelta `json:"namespaces" yaml:"namespaces"`
+}
+
+// DiffReports compares a baseline export with a newer one, listing pods that
+// appeared or disappeared and namespaces whose total requests changed.
+func DiffReports(old, new FullReport) ReportDiff {
+	diff := ReportDiff{
+		PodsAdded:   []PodRef{},
+		PodsRemoved: []PodRef{},
+		Namespaces:  []NamespaceRequestDelta{},
+	}
+
+	oldPods := podRefSet(old.PodDetails)
+	newPods := podRefSet(new.PodDetails)
+	for ref := range newPods {
+		if !oldPods[ref] {
+			diff.PodsAdded = append(diff.PodsAdded, ref)
+		}
+	}
+	for ref := range oldPods {
+		if !newPods[ref] {
+			diff.PodsRemoved = append(diff.PodsRemoved, ref)
+		}
+	}
+	sortPodRefs(diff.PodsAdded)
+	sortPodRefs(diff.PodsRemoved)
+
+	oldNS := namespaceSummaryMap(old.NamespaceInventory)
+	newNS := namespaceSummaryMap(new.NamespaceInventory)
+	names := make(map[string]bool)
+	for name := range oldNS {
+		names[name] = true
+	}
+	for name := range newNS {
+		names[name] = true
+	}
+
+	for name := range names {
+		before, after := oldNS[name], newNS[name]
+		cpuOld, cpuNew := parseQuantityOrZero(before.CPURequests), parseQuantityOrZero(after.CPURequests)
+		memOld, memNew := parseQuantityOrZero(before.MemRequests), parseQuantityOrZero(after.MemRequests)
+		if cpuOld.Cmp(cpuNew) == 0 && memOld.Cmp(memNew) == 0 {
+			continue
+		}
+		diff.Namespaces = append(diff.Namespaces, NamespaceRequestDelta{
+			Namespace:       name,
+			CPURequestsOld:  cpuOld.String(),
+			CPURequestsNew:  cpuNew.String(),
+			CPURequestDelta: signedQuantityDelta(cpuOld, cpuNew),
+			MemRequestsOld:  memOld.String(),
+			MemRequestsNew:  memNew.String(),
+			MemRequestDelta: signedQuantityDelta(memOld, memNew),
+		})
+	}
+	sort.Slice(diff.Namespaces, func(i, j int) bool {
+		return diff.Namespaces[i].Namespace < diff.Namespaces[j].Namespace
+	})
+
+	return diff
+}
+
+// LoadFullReport reads a report written by `resources export`. Files ending in
+// .yaml or .yml are parsed as YAML, anything else as JSON.
+func LoadFullReport(path string) (*FullReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading report %s: %w", path, err)
+	}
+
+	var report FullReport
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &report)
+	default:
+		err = json.Unmarshal(data, &report)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// RenderReportDiff formats added/removed pods and per-namespace request deltas
+func RenderReportDiff(d ReportDiff) string {
+	if len(d.PodsAdded) == 0 && len(d.PodsRemoved) == 0 && len(d.Namespaces) == 0 {
+		return "No changes since baseline."
+	}
+
+	var buf bytes.Buffer
+	w := newTableWriter(&buf)
+	if len(d.PodsAdded) > 0 || len(d.PodsRemoved) > 0 {
+		fmt.Fprintln(w, "CHANGE\tNAMESPACE\tPOD")
+		for _, p := range d.PodsAdded {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", "+ added", p.Namespace, truncateName(p.Pod))
+		}
+		for _, p := range d.PodsRemoved {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", "- removed", p.Namespace, truncateName(p.Pod))
+		}
+		w.Flush()
+	}
+
+	if len(d.Namespaces) > 0 {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		w = newTableWriter(&buf)
+		fmt.Fprintln(w, "NAMESPACE\tCPU REQ (OLD → NEW)\tCPU DELTA\tMEM REQ (OLD → NEW)\tMEM DELTA")
+		for _, ns := range d.Namespaces {
+			fmt.Fprintf(w, "%s\t%s → %s\t%s\t%s → %s\t%s\n",
+				ns.Namespace,
+				ns.CPURequestsOld, ns.CPURequestsNew, ns.CPURequestDelta,
+				ns.MemRequestsOld, ns.MemRequestsNew, ns.MemRequestDelta)
+		}
+		w.Flush()
+	}
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+func podRefSet(pods []PodDetail) map[PodRef]bool {
+	set := make(map[PodRef]bool, len(pods))
+	for _, p := range pods {
+		set[PodRef{Namespace: p.Namespace, Pod: p.Pod}] = true
+	}
+	return set
+}
+
+func sortPodRefs(refs []PodRef) {
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Namespace != refs[j].Namespace {
+			return refs[i].Namespace < refs[j].Namespace
+		}
+		return refs[i].Pod < refs[j].Pod
+	})
+}
+
+func namespaceSummaryMap(summaries []NamespaceSummary) map[string]NamespaceSummary {
+	m := make(map[string]NamespaceSummary, len(summaries))
+	for _, ns := range summaries {
+		m[ns.Namespace] = ns
+	}
+	return m
+}
+
+// parseQuantityOrZero parses a quantity written by an export; empty or
+// unparsable values count as zero
+func parseQuantityOrZero(s string) resource.Quantity {
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return resource.Quantity{}
+	}
+	return q
+}
+
+// signedQuantityDelta returns new-old with an explicit sign, e.g. "+500m"
+func signedQuantityDelta(old, new resource.Quantity) string {
+	delta := new.DeepCopy()
+	delta.Sub(old)
+	if delta.Sign() > 0 {
+		return "+" + delta.String()
+	}
+	return delta.String()
+}
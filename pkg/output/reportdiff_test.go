@@ -0,0 +1,45 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffReports_PodAdded(t *testing.T) {
+	baseline := FullReport{
+		PodDetails: []PodDetail{
+			{Namespace: "shop", Pod: "web-1", CPURequest: "500m", MemRequest: "256Mi"},
+		},
+		NamespaceInventory: []NamespaceSummary{
+			{Namespace: "shop", CPURequests: "500m", MemRequests: "256Mi"},
+		},
+	}
+	current := FullReport{
+		PodDetails: []PodDetail{
+			{Namespace: "shop", Pod: "web-1", CPURequest: "500m", MemRequest: "256Mi"},
+			{Namespace: "shop", Pod: "web-2", CPURequest: "500m", MemRequest: "256Mi"},
+		},
+		NamespaceInventory: []NamespaceSummary{
+			{Namespace: "shop", CPURequests: "1", MemRequests: "512Mi"},
+		},
+	}
+
+	diff := DiffReports(baseline, current)
+	if len(diff.PodsAdded) != 1 || diff.PodsAdded[0] != (PodRef{Namespace: "shop", Pod: "web-2"}) {
+		t.Errorf("expected shop/web-2 added, got %+v", diff.PodsAdded)
+	}
+	if len(diff.PodsRemoved) != 0 {
+		t.Errorf("expected no removed pods, got %+v", diff.PodsRemoved)
+	}
+	if len(diff.Namespaces) != 1 {
+		t.Fatalf("expected 1 namespace delta, got %+v", diff.Namespaces)
+	}
+	if got := diff.Namespaces[0]; got.CPURequestDelta != "+500m" || got.MemRequestDelta != "+256Mi" {
+		t.Errorf("unexpected namespace delta: %+v", got)
+	}
+
+	out := RenderReportDiff(diff)
+	if !strings.Contains(out, "+ added") || !strings.Contains(out, "web-2") {
+		t.Errorf("expected added pod in text output, got: %s", out)
+	}
+}
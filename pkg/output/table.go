@@ -0,0 +1,56 @@
+package output
+
+import (
+	"io"
+	"text/tabwriter"
+)
+
+// TableOptions controls the layout of text tables
+type TableOptions struct {
+	// MaxWidth truncates pod and container names longer than this many
+	// characters with a middle ellipsis; 0 disables truncation
+	MaxWidth int
+	// MinWidth and Padding are passed to tabwriter
+	MinWidth int
+	Padding  int
+}
+
+// DefaultTableOptions returns the table layout used when nothing is configured
+func DefaultTableOptions() TableOptions {
+	return TableOptions{Padding: 2}
+}
+
+// Global table layout, set once by the root command
+var globalTableOptions = DefaultTableOptions()
+
+// SetGlobalTableOptions sets the table layout used by all text renderers
+func SetGlobalTableOptions(opts TableOptions) {
+	globalTableOptions = opts
+}
+
+// newTableWriter returns a tabwriter using the global table layout
+func newTableWriter(out io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(out, globalTableOptions.MinWidth, 0, globalTableOptions.Padding, ' ', 0)
+}
+
+// truncateName shortens a pod or container name to the global MaxWidth
+func truncateName(s string) string {
+	return TruncateMiddle(s, globalTableOptions.MaxWidth)
+}
+
+// TruncateMiddle shortens s to at most max characters by replacing its middle
+// with an ellipsis, keeping both the prefix and the (often unique) suffix of
+// generated names. A max of 0 or less leaves s unchanged.
+func TruncateMiddle(s string, max int) string {
+	runes := []rune(s)
+	if max <= 0 || len(runes) <= max {
+		return s
+	}
+	if max == 1 {
+		return "…"
+	}
+
+	tail := (max - 1) / 2
+	head := max - 1 - tail
+	return string(runes[:head]) + "…" + string(runes[len(runes)-tail:])
+}
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/marcgeld/cobrak/pkg/resources"
+)
+
+func TestTruncateMiddle(t *testing.T) {
+	long := "payments-api-deployment-7c9f8d6b5-x2k4p-canary-rollout-abcde" // 60 chars
+	if len(long) != 60 {
+		t.Fatalf("test setup: expected 60 chars, got %d", len(long))
+	}
+
+	got := TruncateMiddle(long, 20)
+	if utf8.RuneCountInString(got) != 20 {
+		t.Errorf("expected 20 characters, got %d: %q", utf8.RuneCountInString(got), got)
+	}
+	if !strings.HasPrefix(got, "payments-a") || !strings.HasSuffix(got, "ut-abcde") || !strings.Contains(got, "…") {
+		t.Errorf("expected prefix, ellipsis and suffix to be kept, got %q", got)
+	}
+
+	tests := []struct {
+		s    string
+		max  int
+		want string
+	}{
+		{"short", 20, "short"},
+		{"exactly-ten", 11, "exactly-ten"},
+		{"unlimited", 0, "unlimited"},
+		{"abcdef", 1, "…"},
+		{"abcdef", 4, "ab…f"},
+	}
+	for _, tt := range tests {
+		if got := TruncateMiddle(tt.s, tt.max); got != tt.want {
+			t.Errorf("TruncateMiddle(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestGlobalTableOptions_TruncatesNames(t *testing.T) {
+	defer SetGlobalTableOptions(DefaultTableOptions())
+
+	diffs := []resources.ContainerDiff{
+		{Namespace: "default", PodName: "payments-api-deployment-7c9f8d6b5-x2k4p", ContainerName: "app"},
+	}
+
+	SetGlobalTableOptions(TableOptions{MaxWidth: 20, Padding: 2})
+	out := RenderGhostTable(diffs, 10)
+	if strings.Contains(out, "payments-api-deployment-7c9f8d6b5-x2k4p") {
+		t.Errorf("expected pod name to be truncated, got: %s", out)
+	}
+	if !strings.Contains(out, TruncateMiddle("payments-api-deployment-7c9f8d6b5-x2k4p", 20)) {
+		t.Errorf("expected truncated pod name, got: %s", out)
+	}
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"github.com/marcgeld/cobrak/pkg/resources"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// BuildUsageDetails converts measured container usages into structured rows
+func BuildUsageDetails(usages []resources.ContainerUsage) []ContainerUsageDetail {
+	details := make([]ContainerUsageDetail, len(usages))
+	for i, u := range usages {
+		details[i] = ContainerUsageDetail{
+			Namespace: u.Namespace,
+			Pod:       u.PodName,
+			Container: u.ContainerName,
+			CPUUsage:  u.CPUUsage.String(),
+			MemUsage:  u.MemUsage.String(),
+			Node:      u.NodeName,
+		}
+		if u.Window > 0 {
+			details[i].Window = u.Window.String()
+		}
+	}
+	return details
+}
+
+// BuildUsageReport builds the structured usage report for the given rows; the
+// totals cover exactly these rows, so apply --top before calling it
+func BuildUsageReport(usages []resources.ContainerUsage) *UsageReport {
+	cpu := resource.NewMilliQuantity(0, resource.DecimalSI)
+	mem := resource.NewQuantity(0, resource.BinarySI)
+	for _, u := range usages {
+		cpu.Add(u.CPUUsage)
+		mem.Add(u.MemUsage)
+	}
+
+	return &UsageReport{
+		Containers: BuildUsageDetails(usages),
+		Totals: UsageTotals{
+			Containers: len(usages),
+			CPUUsage:   cpu.String(),
+			MemUsage:   mem.String(),
+		},
+	}
+}
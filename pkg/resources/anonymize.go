@@ -0,0 +1,94 @@
+package resources
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Anonymizer replaces namespace and pod names with hashes so reports can be
+// shared without leaking identifiers. Each Anonymizer hashes with its own
+// random key: a name maps to the same hash everywhere in one run, but hashes
+// differ between runs and cannot be looked up from a list of common names.
+type Anonymizer struct {
+	key    []byte
+	hashes map[string]string
+}
+
+// NewAnonymizer returns an Anonymizer with a fresh random key
+func NewAnonymizer() (*Anonymizer, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generating anonymization key: %w", err)
+	}
+	return &Anonymizer{key: key, hashes: make(map[string]string)}, nil
+}
+
+// Namespace returns the anonymized form of a namespace name, e.g. "ns-1a2b3c4d"
+func (a *Anonymizer) Namespace(name string) string {
+	return a.hash("ns", name)
+}
+
+// Pod returns the anonymized form of a pod name, e.g. "pod-1a2b3c4d"
+func (a *Anonymizer) Pod(name string) string {
+	return a.hash("pod", name)
+}
+
+// hash returns prefix and a short keyed hash of name, remembering the result
+// so repeated names map identically. An empty name stays empty.
+func (a *Anonymizer) hash(prefix, name string) string {
+	if name == "" {
+		return ""
+	}
+	id := prefix + "/" + name
+	if h, ok := a.hashes[id]; ok {
+		return h
+	}
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(id))
+	h := prefix + "-" + hex.EncodeToString(mac.Sum(nil)[:4])
+	a.hashes[id] = h
+	return h
+}
+
+// PodSummaries anonymizes the namespace and pod names of pods in place
+func (a *Anonymizer) PodSummaries(pods []PodResourceSummary) {
+	for i := range pods {
+		pods[i].Namespace = a.Namespace(pods[i].Namespace)
+		pods[i].PodName = a.Pod(pods[i].PodName)
+	}
+}
+
+// Containers anonymizes the namespace and pod names of containers in place
+func (a *Anonymizer) Containers(containers []ContainerResources) {
+	for i := range containers {
+		containers[i].Namespace = a.Namespace(containers[i].Namespace)
+		containers[i].PodName = a.Pod(containers[i].PodName)
+	}
+}
+
+// NamespaceInventories anonymizes namespace names in place. Namespace labels
+// are dropped, as they often repeat the name.
+func (a *Anonymizer) NamespaceInventories(inventories []NamespaceInventory) {
+	for i := range inventories {
+		inventories[i].Namespace = a.Namespace(inventories[i].Namespace)
+		inventories[i].Labels = nil
+	}
+}
+
+// Usages anonymizes the namespace and pod names of container usages in place
+func (a *Anonymizer) Usages(usages []ContainerUsage) {
+	for i := range usages {
+		usages[i].Namespace = a.Namespace(usages[i].Namespace)
+		usages[i].PodName = a.Pod(usages[i].PodName)
+	}
+}
+
+// Policies anonymizes the namespace names of policy summaries in place
+func (a *Anonymizer) Policies(policies []PolicySummary) {
+	for i := range policies {
+		policies[i].Namespace = a.Namespace(policies[i].Namespace)
+	}
+}
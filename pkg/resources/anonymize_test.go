@@ -0,0 +1,37 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnonymizer_StableWithinRun(t *testing.T) {
+	a, err := NewAnonymizer()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pods := []PodResourceSummary{{Namespace: "payments", PodName: "api-0"}}
+	containers := []ContainerResources{{Namespace: "payments", PodName: "api-0", ContainerName: "app"}}
+	a.PodSummaries(pods)
+	a.Containers(containers)
+
+	if pods[0].PodName == "api-0" || !strings.HasPrefix(pods[0].PodName, "pod-") {
+		t.Errorf("expected pod name to be hashed, got %q", pods[0].PodName)
+	}
+	if containers[0].PodName != pods[0].PodName || containers[0].Namespace != pods[0].Namespace {
+		t.Errorf("expected the same names to hash identically, got %s/%s and %s/%s",
+			pods[0].Namespace, pods[0].PodName, containers[0].Namespace, containers[0].PodName)
+	}
+	if containers[0].ContainerName != "app" {
+		t.Errorf("expected container name to be kept, got %q", containers[0].ContainerName)
+	}
+
+	b, err := NewAnonymizer()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Pod("api-0") == a.Pod("api-0") {
+		t.Errorf("expected hashes to differ between runs")
+	}
+}
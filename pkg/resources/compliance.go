@@ -0,0 +1,69 @@
+package resources
+
+import "fmt"
+
+// CompliancePolicy selects which missing requests/limits count as violations.
+type CompliancePolicy string
+
+const (
+	// PolicyRequestsRequired only requires CPU and memory requests.
+	PolicyRequestsRequired CompliancePolicy = "requests-required"
+	// PolicyLimitsRequired only requires CPU and memory limits. Containers with
+	// limits but no requests are compliant, since Kubernetes defaults the
+	// request to the limit.
+	PolicyLimitsRequired CompliancePolicy = "limits-required"
+	// PolicyBoth requires requests and limits (default).
+	PolicyBoth CompliancePolicy = "both"
+)
+
+// ParseCompliancePolicy parses a --policy value.
+func ParseCompliancePolicy(s string) (CompliancePolicy, error) {
+	switch p := CompliancePolicy(s); p {
+	case PolicyRequestsRequired, PolicyLimitsRequired, PolicyBoth:
+		return p, nil
+	default:
+		return PolicyBoth, fmt.Errorf("unsupported policy: %s (supported: requests-required, limits-required, both)", s)
+	}
+}
+
+// RequiresRequests reports whether missing requests are violations under p.
+func (p CompliancePolicy) RequiresRequests() bool {
+	return p != PolicyLimitsRequired
+}
+
+// RequiresLimits reports whether missing limits are violations under p.
+func (p CompliancePolicy) RequiresLimits() bool {
+	return p != PolicyRequestsRequired
+}
+
+// Violates reports whether cr fails the strict check under p: a missing CPU
+// or memory request, a missing limit, or either under PolicyBoth.
+func (p CompliancePolicy) Violates(cr ContainerResources) bool {
+	missingRequests := !cr.HasCPURequest || !cr.HasMemRequest
+	missingLimits := !cr.HasCPULimit || !cr.HasMemLimit
+	switch p {
+	case PolicyLimitsRequired:
+		return missingLimits
+	case PolicyBoth:
+		return missingRequests || missingLimits
+	default:
+		return missingRequests
+	}
+}
+
+// ApplyCompliancePolicy clears the missing-requests/limits tallies that the
+// policy does not treat as violations.
+func ApplyCompliancePolicy(inventories []NamespaceInventory, p CompliancePolicy) {
+	for i := range inventories {
+		if !p.RequiresRequests() {
+			inventories[i].ContainersMissingAnyRequests = 0
+			inventories[i].ContainersMissingCPURequest = 0
+			inventories[i].ContainersMissingMemRequest = 0
+		}
+		if !p.RequiresLimits() {
+			inventories[i].ContainersMissingAnyLimits = 0
+			inventories[i].ContainersMissingCPULimit = 0
+			inventories[i].ContainersMissingMemLimit = 0
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package resources
+
+import "testing"
+
+func complianceContainers() []ContainerResources {
+	return []ContainerResources{
+		{Namespace: "default", PodName: "full", HasCPURequest: true, HasMemRequest: true, HasCPULimit: true, HasMemLimit: true},
+		{Namespace: "default", PodName: "requests-only", HasCPURequest: true, HasMemRequest: true},
+		{Namespace: "default", PodName: "limits-only", HasCPULimit: true, HasMemLimit: true},
+		{Namespace: "default", PodName: "bare"},
+	}
+}
+
+func TestCompliancePolicies(t *testing.T) {
+	tests := []struct {
+		policy          CompliancePolicy
+		missingRequests int
+		missingLimits   int
+		violators       []string
+		lintFindings    int
+	}{
+		{
+			policy:          PolicyBoth,
+			missingRequests: 2,
+			missingLimits:   2,
+			violators:       []string{"requests-only", "limits-only", "bare"},
+			lintFindings:    2,
+		},
+		{
+			policy:          PolicyRequestsRequired,
+			missingRequests: 2,
+			missingLimits:   0,
+			violators:       []string{"limits-only", "bare"},
+			lintFindings:    2,
+		},
+		{
+			policy:          PolicyLimitsRequired,
+			missingRequests: 0,
+			missingLimits:   2,
+			violators:       []string{"requests-only", "bare"},
+			lintFindings:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			containers := complianceContainers()
+
+			inv := NamespaceInventory{Namespace: "default"}
+			for _, cr := range containers {
+				addToNamespaceInventory(&inv, cr)
+			}
+			inventories := []NamespaceInventory{inv}
+			ApplyCompliancePolicy(inventories, tt.policy)
+
+			if got := inventories[0].ContainersMissingAnyRequests; got != tt.missingRequests {
+				t.Errorf("missing requests: got %d, want %d", got, tt.missingRequests)
+			}
+			if got := inventories[0].ContainersMissingAnyLimits; got != tt.missingLimits {
+				t.Errorf("missing limits: got %d, want %d", got, tt.missingLimits)
+			}
+
+			var violators []string
+			for _, cr := range containers {
+				if tt.policy.Violates(cr) {
+					violators = append(violators, cr.PodName)
+				}
+			}
+			if len(violators) != len(tt.violators) {
+				t.Fatalf("violators: got %v, want %v", violators, tt.violators)
+			}
+			for i := range violators {
+				if violators[i] != tt.violators[i] {
+					t.Errorf("violators: got %v, want %v", violators, tt.violators)
+				}
+			}
+
+			// limits-only trips both limit-without-request rules unless the policy excuses missing requests
+			findings := Lint(containers, LintRulesForPolicy(DefaultLintRules, tt.policy))
+			if len(findings) != tt.lintFindings {
+				t.Errorf("lint findings: got %d, want %d: %+v", len(findings), tt.lintFindings, findings)
+			}
+		})
+	}
+}
+
+func TestCompliancePolicy_RequestsWithoutLimits(t *testing.T) {
+	cr := ContainerResources{Namespace: "default", PodName: "requests-only", HasCPURequest: true, HasMemRequest: true}
+
+	if PolicyRequestsRequired.Violates(cr) {
+		t.Errorf("expected %s to accept a container with requests but no limits", PolicyRequestsRequired)
+	}
+	if !PolicyBoth.Violates(cr) {
+		t.Errorf("expected %s to reject a container with requests but no limits", PolicyBoth)
+	}
+}
+
+func TestParseCompliancePolicy(t *testing.T) {
+	for _, s := range []string{"requests-required", "limits-required", "both"} {
+		if _, err := ParseCompliancePolicy(s); err != nil {
+			t.Errorf("expected %q to parse, got %v", s, err)
+		}
+	}
+	if _, err := ParseCompliancePolicy("none"); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
@@ -4,18 +4,37 @@ import (
 	"sort"
 )
 
+// DiffOptions controls how BuildDiffWithOptions joins inventory and usage
+type DiffOptions struct {
+	// IncludeOrphans adds a row for usage of a container that is missing from
+	// its pod's current spec. Usage for pods absent from the inventory (deleted,
+	// or rejected by a pod filter) is still dropped.
+	IncludeOrphans bool
+}
+
 // BuildDiff joins inventory and usage data to compute per-container diffs.
 func BuildDiff(inventory []ContainerResources, usage []ContainerUsage) []ContainerDiff {
+	return BuildDiffWithOptions(inventory, usage, DiffOptions{})
+}
+
+// BuildDiffWithOptions is BuildDiff with control over rows for orphan usage.
+func BuildDiffWithOptions(inventory []ContainerResources, usage []ContainerUsage, opts DiffOptions) []ContainerDiff {
 	type key struct{ ns, pod, container string }
 	usageMap := make(map[key]ContainerUsage, len(usage))
 	for _, u := range usage {
 		usageMap[key{u.Namespace, u.PodName, u.ContainerName}] = u
 	}
 
+	type podKey struct{ ns, pod string }
+	specContainers := make(map[key]bool, len(inventory))
+	specPods := make(map[podKey]bool)
+
 	diffs := make([]ContainerDiff, 0, len(inventory))
 	for _, cr := range inventory {
 		k := key{cr.Namespace, cr.PodName, cr.ContainerName}
-		u := usageMap[k]
+		u, hasUsage := usageMap[k]
+		specContainers[k] = true
+		specPods[podKey{cr.Namespace, cr.PodName}] = true
 
 		diff := ContainerDiff{
 			Namespace:     cr.Namespace,
@@ -33,6 +52,8 @@ func BuildDiff(inventory []ContainerResources, usage []ContainerUsage) []Contain
 			MemLimit:      cr.MemLimit.DeepCopy(),
 			HasMemRequest: cr.HasMemRequest,
 			HasMemLimit:   cr.HasMemLimit,
+
+			HasUsage: hasUsage,
 		}
 
 		if cr.HasCPURequest && !cr.CPURequest.IsZero() {
@@ -50,6 +71,24 @@ func BuildDiff(inventory []ContainerResources, usage []ContainerUsage) []Contain
 		diffs = append(diffs, diff)
 	}
 
+	if opts.IncludeOrphans {
+		for _, u := range usage {
+			k := key{u.Namespace, u.PodName, u.ContainerName}
+			if specContainers[k] || !specPods[podKey{u.Namespace, u.PodName}] {
+				continue
+			}
+			diffs = append(diffs, ContainerDiff{
+				Namespace:     u.Namespace,
+				PodName:       u.PodName,
+				ContainerName: u.ContainerName,
+				CPUUsage:      u.CPUUsage.DeepCopy(),
+				MemUsage:      u.MemUsage.DeepCopy(),
+				Orphan:        true,
+				HasUsage:      true,
+			})
+		}
+	}
+
 	sort.Slice(diffs, func(i, j int) bool {
 		a, b := diffs[i], diffs[j]
 		if a.Namespace != b.Namespace {
@@ -63,3 +102,55 @@ func BuildDiff(inventory []ContainerResources, usage []ContainerUsage) []Contain
 
 	return diffs
 }
+
+// AggregateDiffByNamespace sums container diffs per namespace and computes
+// namespace-wide usage/request ratios. Results are sorted from the lowest CPU
+// ratio (most wasteful) up; namespaces without CPU requests come last.
+func AggregateDiffByNamespace(diffs []ContainerDiff) []NamespaceDiff {
+	byNamespace := make(map[string]*NamespaceDiff)
+	var order []string
+	for _, d := range diffs {
+		nd, ok := byNamespace[d.Namespace]
+		if !ok {
+			nd = &NamespaceDiff{Namespace: d.Namespace}
+			byNamespace[d.Namespace] = nd
+			order = append(order, d.Namespace)
+		}
+		nd.Containers++
+		if d.HasCPURequest {
+			nd.CPUUsage.Add(d.CPUUsage)
+			nd.CPURequest.Add(d.CPURequest)
+			nd.HasCPURequest = true
+		}
+		if d.HasMemRequest {
+			nd.MemUsage.Add(d.MemUsage)
+			nd.MemRequest.Add(d.MemRequest)
+			nd.HasMemRequest = true
+		}
+	}
+
+	result := make([]NamespaceDiff, 0, len(order))
+	for _, ns := range order {
+		nd := byNamespace[ns]
+		if !nd.CPURequest.IsZero() {
+			nd.CPUUsageToRequest = float64(nd.CPUUsage.MilliValue()) / float64(nd.CPURequest.MilliValue())
+		}
+		if !nd.MemRequest.IsZero() {
+			nd.MemUsageToRequest = float64(nd.MemUsage.Value()) / float64(nd.MemRequest.Value())
+		}
+		result = append(result, *nd)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.HasCPURequest != b.HasCPURequest {
+			return a.HasCPURequest
+		}
+		if a.CPUUsageToRequest != b.CPUUsageToRequest {
+			return a.CPUUsageToRequest < b.CPUUsageToRequest
+		}
+		return a.Namespace < b.Namespace
+	})
+
+	return result
+}
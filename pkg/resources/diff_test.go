@@ -49,3 +49,61 @@ func TestBuildDiff_WithData(t *testing.T) {
 		t.Errorf("expected MemUsageToRequest ~0.5, got %f", d.MemUsageToRequest)
 	}
 }
+
+func TestBuildDiffWithOptions_IncludeOrphans(t *testing.T) {
+	inventory := []ContainerResources{
+		{Namespace: "default", PodName: "web", ContainerName: "app", CPURequest: resource.MustParse("100m"), HasCPURequest: true},
+	}
+	usage := []ContainerUsage{
+		{Namespace: "default", PodName: "web", ContainerName: "app", CPUUsage: resource.MustParse("50m")},
+		// sidecar removed from the spec, still reported by metrics-server
+		{Namespace: "default", PodName: "web", ContainerName: "old-sidecar", CPUUsage: resource.MustParse("20m"), MemUsage: resource.MustParse("32Mi")},
+		// pod not in the inventory at all
+		{Namespace: "default", PodName: "gone", ContainerName: "app", CPUUsage: resource.MustParse("10m")},
+	}
+
+	if diffs := BuildDiff(inventory, usage); len(diffs) != 1 {
+		t.Fatalf("expected orphan usage dropped by default, got %d rows", len(diffs))
+	}
+
+	diffs := BuildDiffWithOptions(inventory, usage, DiffOptions{IncludeOrphans: true})
+	if len(diffs) != 2 {
+		t.Fatalf("expected 2 rows with orphans, got %d", len(diffs))
+	}
+	orphan := diffs[1]
+	if orphan.ContainerName != "old-sidecar" || !orphan.Orphan {
+		t.Fatalf("expected orphan row for old-sidecar, got %+v", orphan)
+	}
+	if orphan.CPUUsage.String() != "20m" || orphan.HasCPURequest || orphan.HasMemRequest {
+		t.Errorf("expected usage only on the orphan row, got cpu %s, requests %v/%v", orphan.CPUUsage.String(), orphan.HasCPURequest, orphan.HasMemRequest)
+	}
+	if diffs[0].Orphan {
+		t.Error("expected the spec container not to be marked orphan")
+	}
+}
+
+func TestAggregateDiffByNamespace(t *testing.T) {
+	diffs := []ContainerDiff{
+		{Namespace: "busy", CPUUsage: resource.MustParse("450m"), CPURequest: resource.MustParse("500m"), HasCPURequest: true},
+		{Namespace: "busy", CPUUsage: resource.MustParse("450m"), CPURequest: resource.MustParse("500m"), HasCPURequest: true},
+		{Namespace: "idle", CPUUsage: resource.MustParse("100m"), CPURequest: resource.MustParse("500m"), HasCPURequest: true},
+		{Namespace: "idle", CPUUsage: resource.MustParse("300m"), CPURequest: resource.MustParse("1500m"), HasCPURequest: true},
+	}
+
+	got := AggregateDiffByNamespace(diffs)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 namespaces, got %d", len(got))
+	}
+	if got[0].Namespace != "idle" {
+		t.Errorf("expected most wasteful namespace first, got %s", got[0].Namespace)
+	}
+	if r := got[0].CPUUsageToRequest; r < 0.19 || r > 0.21 {
+		t.Errorf("expected idle CPU ratio ~0.2, got %f", r)
+	}
+	if r := got[1].CPUUsageToRequest; r < 0.89 || r > 0.91 {
+		t.Errorf("expected busy CPU ratio ~0.9, got %f", r)
+	}
+	if got[1].Containers != 2 || got[1].CPURequest.String() != "1" {
+		t.Errorf("unexpected busy totals: %+v", got[1])
+	}
+}
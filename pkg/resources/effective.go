@@ -0,0 +1,120 @@
+package resources
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// EffectiveContainerResources is a container's requests/limits after LimitRange
+// defaulting. The *Defaulted flags mark values the container spec does not set:
+// a LimitRange default, or a request copied from the container's own limit.
+type EffectiveContainerResources struct {
+	ContainerResources
+
+	CPURequestDefaulted bool
+	CPULimitDefaulted   bool
+	MemRequestDefaulted bool
+	MemLimitDefaulted   bool
+}
+
+// ApplyLimitRangeDefaults returns the requests/limits each container would
+// receive at admission given its namespace's Container-type LimitRanges, as
+// returned by BuildInventory. A missing request on a container with its own
+// limit takes that limit, as the API server defaults it before admission.
+// Otherwise a missing limit takes the LimitRange default; a missing request
+// takes defaultRequest, falling back to default as the LimitRanger admission
+// plugin does. Values the container sets are kept.
+func ApplyLimitRangeDefaults(containers []ContainerResources, policies []PolicySummary) []EffectiveContainerResources {
+	rangesByNS := make(map[string][]LimitRangeSummary, len(policies))
+	for _, ps := range policies {
+		rangesByNS[ps.Namespace] = ps.LimitRanges
+	}
+
+	effective := make([]EffectiveContainerResources, 0, len(containers))
+	for _, cr := range containers {
+		ec := EffectiveContainerResources{ContainerResources: cr}
+		if !ec.HasCPURequest && ec.HasCPULimit {
+			ec.CPURequest, ec.HasCPURequest, ec.CPURequestDefaulted = ec.CPULimit.DeepCopy(), true, true
+		}
+		if !ec.HasMemRequest && ec.HasMemLimit {
+			ec.MemRequest, ec.HasMemRequest, ec.MemRequestDefaulted = ec.MemLimit.DeepCopy(), true, true
+		}
+		for _, lr := range rangesByNS[cr.Namespace] {
+			for _, item := range lr.Items {
+				if item.Type != string(v1.LimitTypeContainer) {
+					continue
+				}
+				ec.CPURequestDefaulted = applyDefault(&ec.CPURequest, &ec.HasCPURequest, item.DefaultRequestCPU, item.DefaultCPU) || ec.CPURequestDefaulted
+				ec.CPULimitDefaulted = applyDefault(&ec.CPULimit, &ec.HasCPULimit, item.DefaultCPU) || ec.CPULimitDefaulted
+				ec.MemRequestDefaulted = applyDefault(&ec.MemRequest, &ec.HasMemRequest, item.DefaultRequestMemory, item.DefaultMemory) || ec.MemRequestDefaulted
+				ec.MemLimitDefaulted = applyDefault(&ec.MemLimit, &ec.HasMemLimit, item.DefaultMemory) || ec.MemLimitDefaulted
+			}
+		}
+		effective = append(effective, ec)
+	}
+	return effective
+}
+
+// applyDefault sets value from the first parsable default when it is not set yet
+// and reports whether it did
+func applyDefault(value *resource.Quantity, set *bool, defaults ...string) bool {
+	if *set {
+		return false
+	}
+	for _, d := range defaults {
+		if q, ok := parseConstraint(d); ok {
+			*value, *set = q, true
+			return true
+		}
+	}
+	return false
+}
+
+// podKey identifies a pod by namespace and name
+type podKey struct{ namespace, name string }
+
+// podTotals holds a pod's effective requests and limits, CPU in millicores
+// and memory in bytes
+type podTotals struct{ cpuReq, memReq, cpuLim, memLim int64 }
+
+// effectivePodTotals groups containers by pod and returns what the scheduler
+// and quota accounting charge each pod: the sum of its regular containers, or
+// its largest init container when that is bigger, per resource. Pods are
+// returned in the order they first appear in containers.
+func effectivePodTotals(containers []ContainerResources) ([]podKey, map[podKey]podTotals) {
+	var order []podKey
+	sums := make(map[podKey]*podTotals)
+	inits := make(map[podKey]*podTotals)
+	for _, cr := range containers {
+		key := podKey{cr.Namespace, cr.PodName}
+		if _, ok := sums[key]; !ok {
+			sums[key], inits[key] = &podTotals{}, &podTotals{}
+			order = append(order, key)
+		}
+		if cr.IsInit {
+			ic := inits[key]
+			ic.cpuReq = max(ic.cpuReq, cr.CPURequest.MilliValue())
+			ic.memReq = max(ic.memReq, cr.MemRequest.Value())
+			ic.cpuLim = max(ic.cpuLim, cr.CPULimit.MilliValue())
+			ic.memLim = max(ic.memLim, cr.MemLimit.Value())
+			continue
+		}
+		sum := sums[key]
+		sum.cpuReq += cr.CPURequest.MilliValue()
+		sum.memReq += cr.MemRequest.Value()
+		sum.cpuLim += cr.CPULimit.MilliValue()
+		sum.memLim += cr.MemLimit.Value()
+	}
+
+	totals := make(map[podKey]podTotals, len(order))
+	for _, key := range order {
+		sum, ic := sums[key], inits[key]
+		totals[key] = podTotals{
+			cpuReq: max(sum.cpuReq, ic.cpuReq),
+			memReq: max(sum.memReq, ic.memReq),
+			cpuLim: max(sum.cpuLim, ic.cpuLim),
+			memLim: max(sum.memLim, ic.memLim),
+		}
+	}
+	return order, totals
+}
@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApplyLimitRangeDefaults_InheritsCPUDefault(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("256Mi")},
+				},
+			}},
+		},
+	}
+	limitRange := &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+		Spec: v1.LimitRangeSpec{
+			Limits: []v1.LimitRangeItem{{
+				Type:    v1.LimitTypeContainer,
+				Default: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+				DefaultRequest: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("100m"),
+					v1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+			}},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod, limitRange)
+	_, containers, policies, err := BuildInventory(context.Background(), client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	effective := ApplyLimitRangeDefaults(containers, policies)
+	if len(effective) != 1 {
+		t.Fatalf("expected 1 container, got %d", len(effective))
+	}
+	ec := effective[0]
+
+	if !ec.HasCPURequest || !ec.CPURequestDefaulted || ec.CPURequest.Cmp(resource.MustParse("100m")) != 0 {
+		t.Errorf("expected defaulted CPU request 100m, got %s (defaulted %v)", ec.CPURequest.String(), ec.CPURequestDefaulted)
+	}
+	if !ec.CPULimitDefaulted || ec.CPULimit.Cmp(resource.MustParse("500m")) != 0 {
+		t.Errorf("expected defaulted CPU limit 500m, got %s", ec.CPULimit.String())
+	}
+	if ec.MemRequestDefaulted || ec.MemRequest.Cmp(resource.MustParse("256Mi")) != 0 {
+		t.Errorf("expected the container's own memory request 256Mi to be kept, got %s", ec.MemRequest.String())
+	}
+	if ec.HasMemLimit {
+		t.Errorf("expected no memory limit without a default, got %s", ec.MemLimit.String())
+	}
+}
+
+func TestApplyLimitRangeDefaults_RequestFallsBackToDefaultLimit(t *testing.T) {
+	containers := []ContainerResources{{Namespace: "team-a", PodName: "app", ContainerName: "app"}}
+	policies := []PolicySummary{{
+		Namespace:   "team-a",
+		LimitRanges: []LimitRangeSummary{{Name: "defaults", Items: []LimitRangeItemSummary{{Type: "Container", DefaultCPU: "200m"}}}},
+	}}
+
+	ec := ApplyLimitRangeDefaults(containers, policies)[0]
+	if !ec.CPURequestDefaulted || ec.CPURequest.Cmp(resource.MustParse("200m")) != 0 {
+		t.Errorf("expected CPU request to fall back to the default limit 200m, got %s", ec.CPURequest.String())
+	}
+}
+
+func TestApplyLimitRangeDefaults_RequestDefaultsToOwnLimit(t *testing.T) {
+	containers := []ContainerResources{{
+		Namespace: "team-a", PodName: "app", ContainerName: "app",
+		CPULimit: resource.MustParse("1"), HasCPULimit: true,
+	}}
+	policies := []PolicySummary{{
+		Namespace: "team-a",
+		LimitRanges: []LimitRangeSummary{{Name: "defaults", Items: []LimitRangeItemSummary{{
+			Type: "Container", DefaultCPU: "200m", DefaultRequestCPU: "100m",
+		}}}},
+	}}
+
+	ec := ApplyLimitRangeDefaults(containers, policies)[0]
+	if !ec.CPURequestDefaulted || ec.CPURequest.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("expected CPU request to default to the container's own limit 1, got %s", ec.CPURequest.String())
+	}
+	if ec.CPULimitDefaulted || ec.CPULimit.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("expected the container's CPU limit to be kept, got %s", ec.CPULimit.String())
+	}
+}
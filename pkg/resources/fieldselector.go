@@ -0,0 +1,53 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// SupportedPodFieldSelectors are the pod fields the API server accepts in a field selector.
+var SupportedPodFieldSelectors = []string{
+	"metadata.name",
+	"metadata.namespace",
+	"spec.nodeName",
+	"spec.restartPolicy",
+	"spec.schedulerName",
+	"spec.serviceAccountName",
+	"spec.hostNetwork",
+	"status.phase",
+	"status.podIP",
+	"status.nominatedNodeName",
+}
+
+// ParsePodFieldSelector validates a pod field selector such as "status.phase=Running"
+// and returns it in canonical form. Fields the API server cannot select pods by are
+// rejected here instead of failing the list call.
+func ParsePodFieldSelector(s string) (string, error) {
+	selector, err := fields.ParseSelector(s)
+	if err != nil {
+		return "", fmt.Errorf("parsing field selector %q: %w", s, err)
+	}
+
+	for _, req := range selector.Requirements() {
+		if !isSupportedPodField(req.Field) {
+			supported := append([]string(nil), SupportedPodFieldSelectors...)
+			sort.Strings(supported)
+			return "", fmt.Errorf("field %q is not supported for pods (supported: %s)", req.Field, strings.Join(supported, ", "))
+		}
+	}
+
+	return selector.String(), nil
+}
+
+// isSupportedPodField reports whether field is in SupportedPodFieldSelectors
+func isSupportedPodField(field string) bool {
+	for _, f := range SupportedPodFieldSelectors {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// honorPhaseSelector makes the fake clientset filter pod lists by status.phase,
+// which the object tracker otherwise ignores
+func honorPhaseSelector(client *fake.Clientset, pods []corev1.Pod) {
+	client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		selector := action.(k8stesting.ListAction).GetListRestrictions().Fields
+		if selector == nil || selector.Empty() {
+			return false, nil, nil
+		}
+		list := &corev1.PodList{}
+		for _, pod := range pods {
+			if selector.Matches(fields.Set{"status.phase": string(pod.Status.Phase)}) {
+				list.Items = append(list.Items, pod)
+			}
+		}
+		return true, list, nil
+	})
+}
+
+func TestFieldSelector_ListsOnlyRunningPods(t *testing.T) {
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default"}, Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"}, Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}, Status: corev1.PodStatus{Phase: corev1.PodPending}},
+	}
+	client := fake.NewSimpleClientset(&pods[0], &pods[1])
+	honorPhaseSelector(client, pods)
+
+	selector, err := ParsePodFieldSelector("status.phase=Running")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	summaries, err := BuildPodSummaries(ctx, client, "", selector)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].PodName != "running" {
+		t.Errorf("expected only the running pod, got %+v", summaries)
+	}
+
+	_, containers, _, err := BuildInventory(ctx, client, "", selector)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(containers) != 1 || containers[0].PodName != "running" {
+		t.Errorf("expected only the running pod's container in the inventory, got %+v", containers)
+	}
+
+	// Without a selector every pod is listed
+	all, err := BuildPodSummaries(ctx, client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected 2 pods without a selector, got %d", len(all))
+	}
+}
+
+func TestParsePodFieldSelector(t *testing.T) {
+	if got, err := ParsePodFieldSelector("spec.nodeName=worker-1,status.phase!=Failed"); err != nil || got == "" {
+		t.Errorf("expected valid selector, got %q (err %v)", got, err)
+	}
+	if _, err := ParsePodFieldSelector("spec.containers=app"); err == nil {
+		t.Error("expected error for a field the API server cannot select pods by")
+	}
+	if _, err := ParsePodFieldSelector("status.phase"); err == nil {
+		t.Error("expected error for a malformed selector")
+	}
+}
@@ -0,0 +1,94 @@
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodFilter decides whether a listed pod is included in an analysis.
+type PodFilter func(pod *corev1.Pod) bool
+
+// CreatedWithin returns a PodFilter keeping pods whose creation timestamp lies
+// within window before now(). The clock is injectable so tests can pin time.
+func CreatedWithin(window time.Duration, now func() time.Time) PodFilter {
+	return func(pod *corev1.Pod) bool {
+		cutoff := now().Add(-window)
+		return !pod.CreationTimestamp.Time.Before(cutoff)
+	}
+}
+
+// IsTerminated reports whether the pod is in a terminal phase (Succeeded or Failed).
+// Such pods keep their spec but no longer hold node resources.
+func IsTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// ExcludeTerminated is a PodFilter dropping pods in a terminal phase, such as
+// the pods of completed Jobs.
+func ExcludeTerminated(pod *corev1.Pod) bool {
+	return !IsTerminated(pod)
+}
+
+// filterPods returns the pods accepted by every filter.
+func filterPods(pods []corev1.Pod, filters []PodFilter) []corev1.Pod {
+	if len(filters) == 0 {
+		return pods
+	}
+
+	kept := make([]corev1.Pod, 0, len(pods))
+	for i := range pods {
+		if acceptPod(&pods[i], filters) {
+			kept = append(kept, pods[i])
+		}
+	}
+	return kept
+}
+
+// acceptPod reports whether the pod passes all filters.
+func acceptPod(pod *corev1.Pod, filters []PodFilter) bool {
+	for _, f := range filters {
+		if !f(pod) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainerKind selects init containers, regular containers, or both.
+type ContainerKind string
+
+const (
+	// KindInit keeps only init containers.
+	KindInit ContainerKind = "init"
+	// KindRegular keeps only regular (non-init) containers.
+	KindRegular ContainerKind = "regular"
+	// KindAll keeps every container (default).
+	KindAll ContainerKind = "all"
+)
+
+// ParseContainerKind parses a --kind value.
+func ParseContainerKind(s string) (ContainerKind, error) {
+	switch k := ContainerKind(s); k {
+	case KindInit, KindRegular, KindAll:
+		return k, nil
+	default:
+		return KindAll, fmt.Errorf("unsupported kind: %s (supported: init, regular, all)", s)
+	}
+}
+
+// FilterContainersByKind returns the containers matching kind, preserving order.
+func FilterContainersByKind(containers []ContainerResources, kind ContainerKind) []ContainerResources {
+	if kind == KindAll || kind == "" {
+		return containers
+	}
+
+	kept := make([]ContainerResources, 0, len(containers))
+	for _, cr := range containers {
+		if cr.IsInit == (kind == KindInit) {
+			kept = append(kept, cr)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,106 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newPodCreatedAt(name string, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+	}
+}
+
+func TestCreatedWithin_FiltersOldPods(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	client := fake.NewSimpleClientset(
+		newPodCreatedAt("fresh", now.Add(-10*time.Minute)),
+		newPodCreatedAt("stale", now.Add(-2*time.Hour)),
+	)
+	ctx := context.Background()
+	filter := CreatedWithin(time.Hour, clock)
+
+	summaries, err := BuildPodSummaries(ctx, client, "", "", filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].PodName != "fresh" {
+		t.Errorf("expected only pod 'fresh', got %+v", summaries)
+	}
+
+	_, containers, _, err := BuildInventory(ctx, client, "", "", filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(containers) != 1 || containers[0].PodName != "fresh" {
+		t.Errorf("expected only containers of pod 'fresh', got %+v", containers)
+	}
+}
+
+func TestExcludeTerminated_SkipsSucceededPods(t *testing.T) {
+	now := time.Now()
+	running := newPodCreatedAt("running", now)
+	running.Status.Phase = corev1.PodRunning
+	done := newPodCreatedAt("job-done", now)
+	done.Status.Phase = corev1.PodSucceeded
+
+	client := fake.NewSimpleClientset(running, done)
+	ctx := context.Background()
+
+	summaries, err := BuildPodSummaries(ctx, client, "", "", ExcludeTerminated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].PodName != "running" {
+		t.Errorf("expected only pod 'running', got %+v", summaries)
+	}
+
+	all, err := BuildPodSummaries(ctx, client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected 2 pods without filters, got %d", len(all))
+	}
+}
+
+func TestFilterContainersByKind(t *testing.T) {
+	containers := []ContainerResources{
+		{PodName: "web", ContainerName: "migrate", IsInit: true},
+		{PodName: "web", ContainerName: "app"},
+		{PodName: "web", ContainerName: "envoy"},
+	}
+
+	kind, err := ParseContainerKind("init")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	initOnly := FilterContainersByKind(containers, kind)
+	if len(initOnly) != 1 || initOnly[0].ContainerName != "migrate" {
+		t.Errorf("expected only the init container, got %+v", initOnly)
+	}
+
+	if regular := FilterContainersByKind(containers, KindRegular); len(regular) != 2 {
+		t.Errorf("expected 2 regular containers, got %d", len(regular))
+	}
+	if all := FilterContainersByKind(containers, KindAll); len(all) != 3 {
+		t.Errorf("expected all 3 containers, got %d", len(all))
+	}
+	if _, err := ParseContainerKind("sidecar"); err == nil {
+		t.Error("expected error for unsupported kind")
+	}
+}
@@ -0,0 +1,38 @@
+package resources
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DefaultGhostMinCPU is the CPU usage above which a requestless container is reported.
+var DefaultGhostMinCPU = resource.MustParse("100m")
+
+// DefaultGhostMinMemory is the memory usage above which a requestless container is reported.
+var DefaultGhostMinMemory = resource.MustParse("128Mi")
+
+// FindGhostContainers returns containers that have no request set for a resource
+// but whose measured usage of that resource is at or above the given minimum.
+// These workloads are invisible to the scheduler yet consume real capacity.
+// The result is sorted by CPU usage, then memory usage, highest first.
+func FindGhostContainers(diffs []ContainerDiff, minCPU, minMem resource.Quantity) []ContainerDiff {
+	var ghosts []ContainerDiff
+	for _, d := range diffs {
+		cpuGhost := !d.HasCPURequest && d.CPUUsage.Cmp(minCPU) >= 0
+		memGhost := !d.HasMemRequest && d.MemUsage.Cmp(minMem) >= 0
+		if cpuGhost || memGhost {
+			ghosts = append(ghosts, d)
+		}
+	}
+
+	sort.SliceStable(ghosts, func(i, j int) bool {
+		a, b := ghosts[i], ghosts[j]
+		if c := a.CPUUsage.Cmp(b.CPUUsage); c != 0 {
+			return c > 0
+		}
+		return a.MemUsage.Cmp(b.MemUsage) > 0
+	})
+
+	return ghosts
+}
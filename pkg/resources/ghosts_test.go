@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestFindGhostContainers_FlagsRequestlessHighCPU(t *testing.T) {
+	inventory := []ContainerResources{
+		{Namespace: "default", PodName: "hungry", ContainerName: "app"},
+		{
+			Namespace:     "default",
+			PodName:       "polite",
+			ContainerName: "app",
+			CPURequest:    resource.MustParse("1"),
+			HasCPURequest: true,
+			MemRequest:    resource.MustParse("1Gi"),
+			HasMemRequest: true,
+		},
+		{Namespace: "default", PodName: "idle", ContainerName: "app"},
+	}
+	usage := []ContainerUsage{
+		{Namespace: "default", PodName: "hungry", ContainerName: "app", CPUUsage: resource.MustParse("800m"), MemUsage: resource.MustParse("64Mi")},
+		{Namespace: "default", PodName: "polite", ContainerName: "app", CPUUsage: resource.MustParse("900m"), MemUsage: resource.MustParse("512Mi")},
+		{Namespace: "default", PodName: "idle", ContainerName: "app", CPUUsage: resource.MustParse("5m"), MemUsage: resource.MustParse("10Mi")},
+	}
+
+	ghosts := FindGhostContainers(BuildDiff(inventory, usage), DefaultGhostMinCPU, DefaultGhostMinMemory)
+	if len(ghosts) != 1 {
+		t.Fatalf("expected 1 ghost container, got %d", len(ghosts))
+	}
+	if ghosts[0].PodName != "hungry" {
+		t.Errorf("expected pod 'hungry' to be flagged, got %s", ghosts[0].PodName)
+	}
+}
+
+func TestFindGhostContainers_SortedByUsage(t *testing.T) {
+	diffs := []ContainerDiff{
+		{PodName: "small", CPUUsage: resource.MustParse("200m")},
+		{PodName: "large", CPUUsage: resource.MustParse("2")},
+		{PodName: "medium", CPUUsage: resource.MustParse("500m")},
+	}
+
+	ghosts := FindGhostContainers(diffs, DefaultGhostMinCPU, DefaultGhostMinMemory)
+	want := []string{"large", "medium", "small"}
+	if len(ghosts) != len(want) {
+		t.Fatalf("expected %d ghosts, got %d", len(want), len(ghosts))
+	}
+	for i, name := range want {
+		if ghosts[i].PodName != name {
+			t.Errorf("position %d: expected %s, got %s", i, name, ghosts[i].PodName)
+		}
+	}
+}
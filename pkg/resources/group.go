@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NoGroupValue is the group name used for pods that lack the grouping annotation.
+const NoGroupValue = "<none>"
+
+// GroupByAnnotation aggregates pod request/limit totals by the value of the given
+// pod annotation. Pods without the annotation are grouped under NoGroupValue.
+// Groups are sorted by value, with NoGroupValue last.
+func GroupByAnnotation(pods []PodResourceSummary, key string) []ResourceGroupSummary {
+	groupMap := make(map[string]*ResourceGroupSummary)
+
+	for _, pod := range pods {
+		value, ok := pod.Annotations[key]
+		if !ok || value == "" {
+			value = NoGroupValue
+		}
+
+		if _, exists := groupMap[value]; !exists {
+			groupMap[value] = &ResourceGroupSummary{
+				Key:        key,
+				Value:      value,
+				CPURequest: *resource.NewQuantity(0, resource.DecimalSI),
+				CPULimit:   *resource.NewQuantity(0, resource.DecimalSI),
+				MemRequest: *resource.NewQuantity(0, resource.BinarySI),
+				MemLimit:   *resource.NewQuantity(0, resource.BinarySI),
+			}
+		}
+
+		group := groupMap[value]
+		group.Pods++
+		group.CPURequest.Add(pod.CPURequest)
+		group.CPULimit.Add(pod.CPULimit)
+		group.MemRequest.Add(pod.MemRequest)
+		group.MemLimit.Add(pod.MemLimit)
+	}
+
+	groups := make([]ResourceGroupSummary, 0, len(groupMap))
+	for _, g := range groupMap {
+		groups = append(groups, *g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i].Value, groups[j].Value
+		if a == NoGroupValue || b == NoGroupValue {
+			return b == NoGroupValue && a != NoGroupValue
+		}
+		return a < b
+	})
+
+	return groups
+}
@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newAnnotatedPod(name string, annotations map[string]string, cpu string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse(cpu),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGroupByAnnotation(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newAnnotatedPod("checkout", map[string]string{"team": "payments"}, "500m"),
+		newAnnotatedPod("billing", map[string]string{"team": "payments"}, "250m"),
+		newAnnotatedPod("indexer", map[string]string{"team": "search"}, "1"),
+		newAnnotatedPod("orphan", nil, "100m"),
+	)
+
+	summaries, err := BuildPodSummaries(context.Background(), client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	groups := GroupByAnnotation(summaries, "team")
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(groups))
+	}
+
+	expected := []struct {
+		value string
+		pods  int
+		cpu   string
+	}{
+		{"payments", 2, "750m"},
+		{"search", 1, "1"},
+		{NoGroupValue, 1, "100m"},
+	}
+
+	for i, want := range expected {
+		g := groups[i]
+		if g.Value != want.value {
+			t.Errorf("group %d: expected value %s, got %s", i, want.value, g.Value)
+		}
+		if g.Pods != want.pods {
+			t.Errorf("group %s: expected %d pods, got %d", want.value, want.pods, g.Pods)
+		}
+		if g.CPURequest.Cmp(resource.MustParse(want.cpu)) != 0 {
+			t.Errorf("group %s: expected CPU request %s, got %s", want.value, want.cpu, g.CPURequest.String())
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// HPATarget is one resource utilization target of a HorizontalPodAutoscaler,
+// together with the per-pod request the utilization is measured against.
+type HPATarget struct {
+	Namespace  string
+	Name       string
+	TargetKind string
+	TargetName string
+	Resource   corev1.ResourceName
+
+	MinReplicas     int32
+	MaxReplicas     int32
+	CurrentReplicas int32
+
+	// TargetUtilization is the desired usage as a percentage of requests
+	TargetUtilization int32
+	// CurrentUtilization is nil until the HPA has reported a value
+	CurrentUtilization *int32
+
+	// PodRequest is the summed container request of the scaled Deployment's
+	// pod template; HasRequest is false when no container sets one, or when
+	// the target is not a Deployment that could be read
+	PodRequest resource.Quantity
+	HasRequest bool
+}
+
+// AtMaxReplicas reports whether the HPA cannot scale out any further.
+func (t HPATarget) AtMaxReplicas() bool {
+	return t.MaxReplicas > 0 && t.CurrentReplicas >= t.MaxReplicas
+}
+
+// AnalyzeHPAs lists autoscaling/v2 HorizontalPodAutoscalers and returns one entry
+// per CPU or memory utilization target. For Deployment targets the pod template's
+// requests are looked up, since the HPA measures utilization against them.
+// The result is sorted by namespace, HPA name and resource.
+func AnalyzeHPAs(ctx context.Context, client kubernetes.Interface, namespace string) ([]HPATarget, error) {
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing horizontal pod autoscalers: %w", err)
+	}
+
+	var targets []HPATarget
+	for i := range hpas.Items {
+		hpa := &hpas.Items[i]
+		var template *corev1.PodTemplateSpec
+		if hpa.Spec.ScaleTargetRef.Kind == "Deployment" {
+			template, err = deploymentTemplate(ctx, client, hpa.Namespace, hpa.Spec.ScaleTargetRef.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, metric := range hpa.Spec.Metrics {
+			if metric.Type != autoscalingv2.ResourceMetricSourceType || metric.Resource == nil {
+				continue
+			}
+			if metric.Resource.Target.Type != autoscalingv2.UtilizationMetricType || metric.Resource.Target.AverageUtilization == nil {
+				continue
+			}
+			name := metric.Resource.Name
+			if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+				continue
+			}
+
+			target := HPATarget{
+				Namespace:          hpa.Namespace,
+				Name:               hpa.Name,
+				TargetKind:         hpa.Spec.ScaleTargetRef.Kind,
+				TargetName:         hpa.Spec.ScaleTargetRef.Name,
+				Resource:           name,
+				MaxReplicas:        hpa.Spec.MaxReplicas,
+				CurrentReplicas:    hpa.Status.CurrentReplicas,
+				TargetUtilization:  *metric.Resource.Target.AverageUtilization,
+				CurrentUtilization: currentHPAUtilization(hpa.Status.CurrentMetrics, name),
+			}
+			if hpa.Spec.MinReplicas != nil {
+				target.MinReplicas = *hpa.Spec.MinReplicas
+			} else {
+				target.MinReplicas = 1
+			}
+			if template != nil {
+				target.PodRequest, target.HasRequest = templateRequest(template, name)
+			}
+			targets = append(targets, target)
+		}
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Resource < b.Resource
+	})
+
+	return targets, nil
+}
+
+// deploymentTemplate returns the pod template of a Deployment, or nil when it does not exist
+func deploymentTemplate(ctx context.Context, client kubernetes.Interface, namespace, name string) (*corev1.PodTemplateSpec, error) {
+	deploy, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting deployment %s/%s: %w", namespace, name, err)
+	}
+	return &deploy.Spec.Template, nil
+}
+
+// templateRequest sums one resource's requests over a pod template's containers
+func templateRequest(template *corev1.PodTemplateSpec, name corev1.ResourceName) (resource.Quantity, bool) {
+	total := *resource.NewQuantity(0, resource.DecimalSI)
+	if name == corev1.ResourceMemory {
+		total = *resource.NewQuantity(0, resource.BinarySI)
+	}
+	found := false
+	for _, c := range template.Spec.Containers {
+		if req, ok := c.Resources.Requests[name]; ok {
+			total.Add(req)
+			found = true
+		}
+	}
+	return total, found
+}
+
+// currentHPAUtilization finds the reported average utilization for a resource
+func currentHPAUtilization(metrics []autoscalingv2.MetricStatus, name corev1.ResourceName) *int32 {
+	for _, m := range metrics {
+		if m.Type == autoscalingv2.ResourceMetricSourceType && m.Resource != nil && m.Resource.Name == name {
+			return m.Resource.Current.AverageUtilization
+		}
+	}
+	return nil
+}
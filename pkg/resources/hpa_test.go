@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAnalyzeHPAs_TargetPercentage(t *testing.T) {
+	target := int32(70)
+	current := int32(85)
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "app",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+						},
+					}},
+				},
+			},
+		},
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web", APIVersion: "apps/v1"},
+			MaxReplicas:    5,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &target},
+				},
+			}},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 5,
+			CurrentMetrics: []autoscalingv2.MetricStatus{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name:    corev1.ResourceCPU,
+					Current: autoscalingv2.MetricValueStatus{AverageUtilization: &current},
+				},
+			}},
+		},
+	}
+
+	client := fake.NewSimpleClientset(deploy, hpa)
+	targets, err := AnalyzeHPAs(context.Background(), client, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targets) != 1 {
+		t.Fatalf("expected 1 HPA target, got %d: %+v", len(targets), targets)
+	}
+
+	got := targets[0]
+	if got.TargetUtilization != 70 {
+		t.Errorf("expected target 70%%, got %d%%", got.TargetUtilization)
+	}
+	if got.CurrentUtilization == nil || *got.CurrentUtilization != 85 {
+		t.Errorf("expected current utilization 85%%, got %v", got.CurrentUtilization)
+	}
+	if !got.HasRequest || got.PodRequest.MilliValue() != 250 {
+		t.Errorf("expected pod request 250m, got %s (has=%v)", got.PodRequest.String(), got.HasRequest)
+	}
+	if got.MinReplicas != 1 || !got.AtMaxReplicas() {
+		t.Errorf("expected min 1 and at max replicas, got %+v", got)
+	}
+}
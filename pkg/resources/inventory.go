@@ -13,13 +13,15 @@ import (
 
 // BuildInventory queries the cluster for pods, limitranges, and resourcequotas
 // and returns per-namespace inventories, per-container resources, and policy summaries.
-func BuildInventory(ctx context.Context, client kubernetes.Interface, namespace string) (
+// A non-empty fieldSelector filters the pod list server-side (see
+// ParsePodFieldSelector). Pods rejected by any of the optional filters are skipped.
+func BuildInventory(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string, filters ...PodFilter) (
 	[]NamespaceInventory,
 	[]ContainerResources,
 	[]PolicySummary,
 	error,
 ) {
-	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: fieldSelector})
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("listing pods: %w", err)
 	}
@@ -37,8 +39,9 @@ func BuildInventory(ctx context.Context, client kubernetes.Interface, namespace
 	var allContainers []ContainerResources
 	nsMap := make(map[string]*NamespaceInventory)
 
-	for i := range pods.Items {
-		pod := &pods.Items[i]
+	items := filterPods(pods.Items, filters)
+	for i := range items {
+		pod := &items[i]
 		ns := pod.Namespace
 		if _, ok := nsMap[ns]; !ok {
 			nsMap[ns] = &NamespaceInventory{Namespace: ns}
@@ -121,6 +124,7 @@ func extractContainerResources(ns, podName string, c v1.Container, isInit bool)
 		Namespace:     ns,
 		PodName:       podName,
 		ContainerName: c.Name,
+		Image:         c.Image,
 		IsInit:        isInit,
 	}
 
@@ -153,6 +157,27 @@ func addToNamespaceInventory(inv *NamespaceInventory, cr ContainerResources) {
 	if !cr.HasCPULimit || !cr.HasMemLimit {
 		inv.ContainersMissingAnyLimits++
 	}
+	if !cr.HasCPURequest {
+		inv.ContainersMissingCPURequest++
+	}
+	if !cr.HasMemRequest {
+		inv.ContainersMissingMemRequest++
+	}
+	if !cr.HasCPULimit {
+		inv.ContainersMissingCPULimit++
+	}
+	if !cr.HasMemLimit {
+		inv.ContainersMissingMemLimit++
+	}
+	if cr.HasCPURequest && !cr.HasMemRequest {
+		inv.ContainersCPUOnlyRequest++
+	}
+	if cr.HasMemRequest && !cr.HasCPURequest {
+		inv.ContainersMemOnlyRequest++
+	}
+	if isGuaranteed(cr) {
+		inv.ContainersGuaranteed++
+	}
 
 	if cr.HasCPURequest {
 		inv.CPURequestsTotal.Add(cr.CPURequest)
@@ -168,6 +193,12 @@ func addToNamespaceInventory(inv *NamespaceInventory, cr ContainerResources) {
 	}
 }
 
+// isGuaranteed reports whether cr sets equal requests and limits for both CPU and memory
+func isGuaranteed(cr ContainerResources) bool {
+	return cr.HasCPURequest && cr.HasCPULimit && cr.CPURequest.Cmp(cr.CPULimit) == 0 &&
+		cr.HasMemRequest && cr.HasMemLimit && cr.MemRequest.Cmp(cr.MemLimit) == 0
+}
+
 func summarizeLimitRange(lr *v1.LimitRange) LimitRangeSummary {
 	s := LimitRangeSummary{Name: lr.Name}
 	for _, item := range lr.Spec.Limits {
@@ -178,6 +209,12 @@ func summarizeLimitRange(lr *v1.LimitRange) LimitRangeSummary {
 		if v, ok := item.Default[v1.ResourceMemory]; ok {
 			is.DefaultMemory = v.String()
 		}
+		if v, ok := item.DefaultRequest[v1.ResourceCPU]; ok {
+			is.DefaultRequestCPU = v.String()
+		}
+		if v, ok := item.DefaultRequest[v1.ResourceMemory]; ok {
+			is.DefaultRequestMemory = v.String()
+		}
 		if v, ok := item.Max[v1.ResourceCPU]; ok {
 			is.MaxCPU = v.String()
 		}
@@ -207,5 +244,19 @@ func summarizeResourceQuota(rq *v1.ResourceQuota) ResourceQuotaSummary {
 	for k, v := range rq.Status.Used {
 		s.Used[k] = v.DeepCopy()
 	}
+	s.Unused = quotaUnused(s.Hard, s.Used)
 	return s
 }
+
+// quotaUnused reports whether a quota has hard limits but no usage at all
+func quotaUnused(hard, used map[v1.ResourceName]resource.Quantity) bool {
+	if len(hard) == 0 {
+		return false
+	}
+	for _, u := range used {
+		if !u.IsZero() {
+			return false
+		}
+	}
+	return true
+}
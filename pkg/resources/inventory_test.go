@@ -12,7 +12,7 @@ import (
 
 func TestBuildInventory_Empty(t *testing.T) {
 	client := fake.NewSimpleClientset()
-	nsInv, containers, policies, err := BuildInventory(context.Background(), client, "")
+	nsInv, containers, policies, err := BuildInventory(context.Background(), client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -49,7 +49,8 @@ func TestBuildInventory_WithPods(t *testing.T) {
 					},
 				},
 				{
-					Name: "container2",
+					Name:  "container2",
+					Image: "busybox:1.36",
 					// No requests or limits
 				},
 			},
@@ -57,7 +58,7 @@ func TestBuildInventory_WithPods(t *testing.T) {
 	}
 
 	client := fake.NewSimpleClientset(pod)
-	nsInv, containers, _, err := BuildInventory(context.Background(), client, "")
+	nsInv, containers, _, err := BuildInventory(context.Background(), client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -75,7 +76,50 @@ func TestBuildInventory_WithPods(t *testing.T) {
 		t.Errorf("expected 1 container missing limits, got %d", nsInv[0].ContainersMissingAnyLimits)
 	}
 	if len(containers) != 2 {
-		t.Errorf("expected 2 containers, got %d", len(containers))
+		t.Fatalf("expected 2 containers, got %d", len(containers))
+	}
+	if containers[1].Image != "busybox:1.36" {
+		t.Errorf("expected image 'busybox:1.36' for container2, got %q", containers[1].Image)
+	}
+}
+
+func TestBuildInventory_SingleResourceRequests(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "cpu-only",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+					},
+				},
+				{
+					Name: "both",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod)
+	nsInv, _, _, err := BuildInventory(context.Background(), client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nsInv) != 1 {
+		t.Fatalf("expected 1 namespace, got %d", len(nsInv))
+	}
+	if nsInv[0].ContainersCPUOnlyRequest != 1 {
+		t.Errorf("expected 1 CPU-only container, got %d", nsInv[0].ContainersCPUOnlyRequest)
+	}
+	if nsInv[0].ContainersMemOnlyRequest != 0 {
+		t.Errorf("expected 0 memory-only containers, got %d", nsInv[0].ContainersMemOnlyRequest)
 	}
 }
 
@@ -125,7 +169,7 @@ func TestBuildInventory_MultipleNamespaces(t *testing.T) {
 	}
 
 	client := fake.NewSimpleClientset(pod1, pod2)
-	nsInv, _, _, err := BuildInventory(context.Background(), client, "")
+	nsInv, _, _, err := BuildInventory(context.Background(), client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -175,7 +219,7 @@ func TestBuildInventory_WithNamespaceFilter(t *testing.T) {
 	}
 
 	client := fake.NewSimpleClientset(pod1, pod2)
-	nsInv, _, _, err := BuildInventory(context.Background(), client, "default")
+	nsInv, _, _, err := BuildInventory(context.Background(), client, "default", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -188,3 +232,83 @@ func TestBuildInventory_WithNamespaceFilter(t *testing.T) {
 		t.Errorf("expected 'default' namespace, got %s", nsInv[0].Namespace)
 	}
 }
+
+func TestBuildInventory_GuaranteedContainers(t *testing.T) {
+	equal := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("500m"),
+		v1.ResourceMemory: resource.MustParse("256Mi"),
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "guaranteed", Resources: v1.ResourceRequirements{Requests: equal, Limits: equal}},
+				{
+					Name: "burstable",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("256Mi"),
+						},
+						Limits: equal,
+					},
+				},
+				{Name: "best-effort"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod)
+	nsInv, _, _, err := BuildInventory(context.Background(), client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nsInv) != 1 {
+		t.Fatalf("expected 1 namespace, got %d", len(nsInv))
+	}
+	if nsInv[0].ContainersGuaranteed != 1 {
+		t.Errorf("expected 1 guaranteed container, got %d", nsInv[0].ContainersGuaranteed)
+	}
+}
+
+func TestBuildInventory_MissingOnlyMemoryLimit(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "app",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+						Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("200m")},
+					},
+				},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod)
+	nsInv, _, _, err := BuildInventory(context.Background(), client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nsInv) != 1 {
+		t.Fatalf("expected 1 namespace, got %d", len(nsInv))
+	}
+	inv := nsInv[0]
+	if inv.ContainersMissingAnyLimits != 1 {
+		t.Errorf("expected 1 container missing any limit, got %d", inv.ContainersMissingAnyLimits)
+	}
+	if inv.ContainersMissingMemLimit != 1 {
+		t.Errorf("expected 1 container missing a memory limit, got %d", inv.ContainersMissingMemLimit)
+	}
+	if inv.ContainersMissingCPULimit != 0 {
+		t.Errorf("expected 0 containers missing a CPU limit, got %d", inv.ContainersMissingCPULimit)
+	}
+	if inv.ContainersMissingCPURequest != 0 || inv.ContainersMissingMemRequest != 0 {
+		t.Errorf("expected no missing requests, got CPU %d, memory %d", inv.ContainersMissingCPURequest, inv.ContainersMissingMemRequest)
+	}
+}
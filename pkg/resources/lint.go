@@ -0,0 +1,179 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// LintRule flags containers whose resource settings match a risky pattern.
+type LintRule struct {
+	Name    string
+	Message string
+	Match   func(ContainerResources) bool
+
+	// MissingRequest marks rules that report an absent request; they are
+	// skipped when the compliance policy does not require requests
+	MissingRequest bool
+}
+
+// LintFinding is a single rule violation for a container.
+type LintFinding struct {
+	Namespace     string
+	PodName       string
+	ContainerName string
+	Rule          string
+	Message       string
+}
+
+// MemLimitWithoutRequest flags containers with a memory limit but no memory request.
+// This is a manifest hygiene check: the API server defaults the missing request
+// to the limit, so the container silently reserves its full limit.
+var MemLimitWithoutRequest = LintRule{
+	Name:    "mem-limit-without-request",
+	Message: "memory limit set without a request; the request defaults to the limit, reserving all of it",
+	Match: func(cr ContainerResources) bool {
+		return cr.HasMemLimit && !cr.HasMemRequest
+	},
+	MissingRequest: true,
+}
+
+// CPULimitWithoutRequest flags containers with a CPU limit but no CPU request.
+// This is a manifest hygiene check: the API server defaults the missing request
+// to the limit, so the container silently reserves its full limit.
+var CPULimitWithoutRequest = LintRule{
+	Name:    "cpu-limit-without-request",
+	Message: "CPU limit set without a request; the request defaults to the limit, reserving all of it",
+	Match: func(cr ContainerResources) bool {
+		return cr.HasCPULimit && !cr.HasCPURequest
+	},
+	MissingRequest: true,
+}
+
+// CPULimitBelowRequest flags containers whose CPU limit is lower than their
+// CPU request. The API server rejects such a spec, so it usually comes from a
+// manifest mixing cores and millicores (e.g. request "1", limit "500m").
+var CPULimitBelowRequest = LintRule{
+	Name:    "cpu-limit-below-request",
+	Message: "CPU limit is lower than the request; the spec is invalid and will be rejected",
+	Match: func(cr ContainerResources) bool {
+		return cr.HasCPULimit && cr.HasCPURequest && cr.CPULimit.Cmp(cr.CPURequest) < 0
+	},
+}
+
+// MemLimitBelowRequest flags containers whose memory limit is lower than their
+// memory request, typically a unit mix-up such as "1G" vs "1Gi".
+var MemLimitBelowRequest = LintRule{
+	Name:    "mem-limit-below-request",
+	Message: "memory limit is lower than the request; the spec is invalid and will be rejected",
+	Match: func(cr ContainerResources) bool {
+		return cr.HasMemLimit && cr.HasMemRequest && cr.MemLimit.Cmp(cr.MemRequest) < 0
+	},
+}
+
+// InvalidSpecRules flag resource specs the API server would reject.
+var InvalidSpecRules = []LintRule{CPULimitBelowRequest, MemLimitBelowRequest}
+
+// DefaultLintRules are the rules applied by `resources lint`.
+var DefaultLintRules = []LintRule{MemLimitWithoutRequest, CPULimitWithoutRequest, CPULimitBelowRequest, MemLimitBelowRequest}
+
+// FindInvalidResourceSpecs returns a finding for every container whose CPU or
+// memory limit is lower than its request.
+func FindInvalidResourceSpecs(containers []ContainerResources) []LintFinding {
+	return Lint(containers, InvalidSpecRules)
+}
+
+// LintRulesForPolicy returns the rules that apply under the compliance policy.
+func LintRulesForPolicy(rules []LintRule, policy CompliancePolicy) []LintRule {
+	var applicable []LintRule
+	for _, rule := range rules {
+		if rule.MissingRequest && !policy.RequiresRequests() {
+			continue
+		}
+		applicable = append(applicable, rule)
+	}
+	return applicable
+}
+
+// Lint applies rules to containers and returns findings sorted by namespace,
+// pod, container and rule name.
+func Lint(containers []ContainerResources, rules []LintRule) []LintFinding {
+	var findings []LintFinding
+	for _, cr := range containers {
+		for _, rule := range rules {
+			if !rule.Match(cr) {
+				continue
+			}
+			findings = append(findings, LintFinding{
+				Namespace:     cr.Namespace,
+				PodName:       cr.PodName,
+				ContainerName: cr.ContainerName,
+				Rule:          rule.Name,
+				Message:       rule.Message,
+			})
+		}
+	}
+
+	SortLintFindings(findings)
+	return findings
+}
+
+// SortLintFindings orders findings by namespace, pod, container and rule name.
+// Pod-level findings have no container and sort before the pod's containers.
+func SortLintFindings(findings []LintFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.PodName != b.PodName {
+			return a.PodName < b.PodName
+		}
+		if a.ContainerName != b.ContainerName {
+			return a.ContainerName < b.ContainerName
+		}
+		return a.Rule < b.Rule
+	})
+}
+
+// UnschedulableBySize is the rule name of findings from FindOversizedPods.
+const UnschedulableBySize = "unschedulable-by-size"
+
+// FindOversizedPods returns a finding for every pod whose CPU or memory
+// requests exceed the given per-node maximum, typically the largest node
+// allocatable in the cluster. Such a pod can never be scheduled. A pod's
+// request is what the scheduler reserves: the sum of its containers, or its
+// largest init container when that is bigger. Findings are pod-level, so
+// ContainerName is empty; a zero maximum disables the check for that resource.
+func FindOversizedPods(containers []ContainerResources, maxCPU, maxMem resource.Quantity) []LintFinding {
+	order, totals := effectivePodTotals(containers)
+
+	var findings []LintFinding
+	for _, key := range order {
+		req := totals[key]
+		cpu := *resource.NewMilliQuantity(req.cpuReq, resource.DecimalSI)
+		mem := *resource.NewQuantity(req.memReq, resource.BinarySI)
+
+		var reasons []string
+		if !maxCPU.IsZero() && cpu.Cmp(maxCPU) > 0 {
+			reasons = append(reasons, fmt.Sprintf("CPU request %s exceeds the largest node allocatable %s", cpu.String(), maxCPU.String()))
+		}
+		if !maxMem.IsZero() && mem.Cmp(maxMem) > 0 {
+			reasons = append(reasons, fmt.Sprintf("memory request %s exceeds the largest node allocatable %s", mem.String(), maxMem.String()))
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+		findings = append(findings, LintFinding{
+			Namespace: key.namespace,
+			PodName:   key.name,
+			Rule:      UnschedulableBySize,
+			Message:   strings.Join(reasons, "; ") + "; the pod can never be scheduled",
+		})
+	}
+
+	SortLintFindings(findings)
+	return findings
+}
@@ -0,0 +1,135 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestMemLimitWithoutRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		cr   ContainerResources
+		want bool
+	}{
+		{name: "limit only", cr: ContainerResources{HasMemLimit: true}, want: true},
+		{name: "limit and request", cr: ContainerResources{HasMemLimit: true, HasMemRequest: true}, want: false},
+		{name: "request only", cr: ContainerResources{HasMemRequest: true}, want: false},
+		{name: "neither", cr: ContainerResources{}, want: false},
+		{name: "cpu limit only", cr: ContainerResources{HasCPULimit: true}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MemLimitWithoutRequest.Match(tt.cr); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !strings.Contains(MemLimitWithoutRequest.Message, "defaults to the limit") {
+		t.Errorf("expected message to explain the request defaults to the limit, got %q", MemLimitWithoutRequest.Message)
+	}
+}
+
+func TestCPULimitWithoutRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		cr   ContainerResources
+		want bool
+	}{
+		{name: "limit only", cr: ContainerResources{HasCPULimit: true}, want: true},
+		{name: "limit and request", cr: ContainerResources{HasCPULimit: true, HasCPURequest: true}, want: false},
+		{name: "request only", cr: ContainerResources{HasCPURequest: true}, want: false},
+		{name: "neither", cr: ContainerResources{}, want: false},
+		{name: "memory limit only", cr: ContainerResources{HasMemLimit: true}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CPULimitWithoutRequest.Match(tt.cr); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !strings.Contains(CPULimitWithoutRequest.Message, "defaults to the limit") {
+		t.Errorf("expected message to explain the request defaults to the limit, got %q", CPULimitWithoutRequest.Message)
+	}
+}
+
+func TestLint_SortedFindings(t *testing.T) {
+	containers := []ContainerResources{
+		{Namespace: "prod", PodName: "api", ContainerName: "app", HasCPULimit: true, HasMemLimit: true},
+		{Namespace: "dev", PodName: "web", ContainerName: "app", HasMemLimit: true},
+		{Namespace: "dev", PodName: "ok", ContainerName: "app", HasMemLimit: true, HasMemRequest: true},
+	}
+
+	findings := Lint(containers, DefaultLintRules)
+	want := []struct{ pod, rule string }{
+		{"web", MemLimitWithoutRequest.Name},
+		{"api", CPULimitWithoutRequest.Name},
+		{"api", MemLimitWithoutRequest.Name},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("expected %d findings, got %d: %+v", len(want), len(findings), findings)
+	}
+	for i, w := range want {
+		if findings[i].PodName != w.pod || findings[i].Rule != w.rule {
+			t.Errorf("position %d: expected %s/%s, got %s/%s", i, w.pod, w.rule, findings[i].PodName, findings[i].Rule)
+		}
+	}
+}
+
+func TestFindInvalidResourceSpecs_CPULimitBelowRequest(t *testing.T) {
+	containers := []ContainerResources{
+		{
+			Namespace: "default", PodName: "web", ContainerName: "app",
+			CPURequest: resource.MustParse("1"), CPULimit: resource.MustParse("500m"),
+			HasCPURequest: true, HasCPULimit: true,
+		},
+		{
+			Namespace: "default", PodName: "web", ContainerName: "sidecar",
+			CPURequest: resource.MustParse("100m"), CPULimit: resource.MustParse("200m"),
+			MemRequest: resource.MustParse("64Mi"), MemLimit: resource.MustParse("64Mi"),
+			HasCPURequest: true, HasCPULimit: true, HasMemRequest: true, HasMemLimit: true,
+		},
+	}
+
+	findings := FindInvalidResourceSpecs(containers)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].ContainerName != "app" || findings[0].Rule != "cpu-limit-below-request" {
+		t.Errorf("unexpected finding: %+v", findings[0])
+	}
+}
+
+func TestFindOversizedPods(t *testing.T) {
+	containers := []ContainerResources{
+		// 12 + 4 CPU in one pod is more than any 8-CPU node can offer
+		{Namespace: "batch", PodName: "huge", ContainerName: "main", CPURequest: resource.MustParse("12"), MemRequest: resource.MustParse("4Gi")},
+		{Namespace: "batch", PodName: "huge", ContainerName: "sidecar", CPURequest: resource.MustParse("4"), MemRequest: resource.MustParse("1Gi")},
+		{Namespace: "batch", PodName: "fits", ContainerName: "main", CPURequest: resource.MustParse("6"), MemRequest: resource.MustParse("16Gi")},
+		// Init containers run one at a time, so only the largest counts
+		{Namespace: "batch", PodName: "init-heavy", ContainerName: "setup", IsInit: true, MemRequest: resource.MustParse("40Gi")},
+		{Namespace: "batch", PodName: "init-heavy", ContainerName: "app", MemRequest: resource.MustParse("1Gi")},
+	}
+
+	findings := FindOversizedPods(containers, resource.MustParse("8"), resource.MustParse("32Gi"))
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 oversized pods, got %d: %+v", len(findings), findings)
+	}
+
+	huge := findings[0]
+	if huge.PodName != "huge" || huge.Rule != UnschedulableBySize || huge.ContainerName != "" {
+		t.Errorf("expected a pod-level %s finding for huge, got %+v", UnschedulableBySize, huge)
+	}
+	if !strings.Contains(huge.Message, "CPU request 16") {
+		t.Errorf("expected the summed CPU request in the message, got %q", huge.Message)
+	}
+	if findings[1].PodName != "init-heavy" || !strings.Contains(findings[1].Message, "memory request 40Gi") {
+		t.Errorf("expected init-heavy flagged for memory, got %+v", findings[1])
+	}
+}
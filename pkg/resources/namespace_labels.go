@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/marcgeld/cobrak/pkg/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AttachNamespaceLabels lists the namespaces and copies their labels onto the
+// given inventories. When keys is empty all labels are copied, otherwise only
+// the listed keys are kept. Labels are optional; without permission to list
+// namespaces the inventories are left unlabeled.
+func AttachNamespaceLabels(ctx context.Context, client kubernetes.Interface, inventories []NamespaceInventory, keys []string) {
+	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logging.FromContext(ctx).Debugf("namespace labels unavailable: %v", err)
+		return
+	}
+
+	labelsByNamespace := make(map[string]map[string]string, len(namespaces.Items))
+	for i := range namespaces.Items {
+		labelsByNamespace[namespaces.Items[i].Name] = namespaces.Items[i].Labels
+	}
+
+	for i := range inventories {
+		inventories[i].Labels = selectLabels(labelsByNamespace[inventories[i].Namespace], keys)
+	}
+}
+
+// selectLabels returns the labels restricted to keys, or a copy of all labels when keys is empty.
+func selectLabels(labels map[string]string, keys []string) map[string]string {
+	selected := make(map[string]string)
+	if len(keys) == 0 {
+		for k, v := range labels {
+			selected[k] = v
+		}
+		return selected
+	}
+
+	for _, k := range keys {
+		if v, ok := labels[k]; ok {
+			selected[k] = v
+		}
+	}
+	return selected
+}
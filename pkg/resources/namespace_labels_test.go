@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/marcgeld/cobrak/pkg/logging"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAttachNamespaceLabels(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-a",
+			Labels: map[string]string{"owner": "teamA", "env": "prod"},
+		}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "scratch"}},
+	)
+	inventories := []NamespaceInventory{{Namespace: "scratch"}, {Namespace: "team-a"}}
+
+	AttachNamespaceLabels(context.Background(), client, inventories, []string{"owner"})
+
+	if got := inventories[1].Labels["owner"]; got != "teamA" {
+		t.Errorf("expected owner=teamA on team-a, got %q", got)
+	}
+	if _, ok := inventories[1].Labels["env"]; ok {
+		t.Error("expected unselected label 'env' to be omitted")
+	}
+	if inventories[0].Labels == nil || len(inventories[0].Labels) != 0 {
+		t.Errorf("expected empty label set for unlabeled namespace, got %v", inventories[0].Labels)
+	}
+}
+
+func TestAttachNamespaceLabels_Forbidden(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", errors.New("denied"))
+	})
+	inventories := []NamespaceInventory{{Namespace: "team-a"}}
+
+	var logs bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), logging.New(&logs, logging.LevelDebug))
+	AttachNamespaceLabels(ctx, client, inventories, nil)
+
+	if inventories[0].Labels != nil {
+		t.Errorf("expected no labels without list permission, got %v", inventories[0].Labels)
+	}
+	if !strings.Contains(logs.String(), "namespace labels unavailable") {
+		t.Errorf("expected the failed lookup to be logged, got %q", logs.String())
+	}
+}
@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+// UnscheduledNode groups pods that have no node assigned yet
+const UnscheduledNode = "<unscheduled>"
+
+// NodePlacement is the share of one namespace's pods and requests carried by a node
+type NodePlacement struct {
+	NodeName   string
+	Pods       int
+	CPURequest resource.Quantity
+	MemRequest resource.Quantity
+}
+
+// BuildNamespacePlacement maps a namespace's pods to the nodes running them and
+// sums their requests per node. Pods without a node fall under UnscheduledNode.
+// A non-empty fieldSelector filters the pod list server-side. The result is
+// sorted by pod count (descending), then node name.
+func BuildNamespacePlacement(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string, filters ...PodFilter) ([]NodePlacement, error) {
+	byNode := make(map[string]*NodePlacement)
+	err := forEachPod(ctx, client, namespace, fieldSelector, filters, func(pod *corev1.Pod) error {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			nodeName = UnscheduledNode
+		}
+		placement, ok := byNode[nodeName]
+		if !ok {
+			placement = &NodePlacement{
+				NodeName:   nodeName,
+				CPURequest: *resource.NewQuantity(0, resource.DecimalSI),
+				MemRequest: *resource.NewQuantity(0, resource.BinarySI),
+			}
+			byNode[nodeName] = placement
+		}
+
+		summary := summarizePod(pod)
+		placement.Pods++
+		placement.CPURequest.Add(summary.CPURequest)
+		placement.MemRequest.Add(summary.MemRequest)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	placements := make([]NodePlacement, 0, len(byNode))
+	for _, p := range byNode {
+		placements = append(placements, *p)
+	}
+	sort.Slice(placements, func(i, j int) bool {
+		if placements[i].Pods != placements[j].Pods {
+			return placements[i].Pods > placements[j].Pods
+		}
+		return placements[i].NodeName < placements[j].NodeName
+	})
+
+	return placements, nil
+}
@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func placementPod(namespace, name, node, cpu string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestBuildNamespacePlacement_TwoNodes(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		placementPod("payments", "api-0", "node-a", "500m"),
+		placementPod("payments", "api-1", "node-a", "250m"),
+		placementPod("payments", "worker-0", "node-b", "1"),
+		placementPod("other", "noise", "node-c", "2"),
+	)
+
+	placements, err := BuildNamespacePlacement(context.Background(), client, "payments", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(placements) != 2 {
+		t.Fatalf("expected 2 nodes, got %d: %+v", len(placements), placements)
+	}
+
+	a, b := placements[0], placements[1]
+	if a.NodeName != "node-a" || a.Pods != 2 {
+		t.Errorf("expected node-a with 2 pods first, got %s with %d", a.NodeName, a.Pods)
+	}
+	if a.CPURequest.Cmp(resource.MustParse("750m")) != 0 || a.MemRequest.Cmp(resource.MustParse("512Mi")) != 0 {
+		t.Errorf("expected node-a to carry 750m CPU and 512Mi memory, got %s and %s", a.CPURequest.String(), a.MemRequest.String())
+	}
+	if b.NodeName != "node-b" || b.Pods != 1 || b.CPURequest.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("expected node-b with 1 pod requesting 1 CPU, got %+v", b)
+	}
+}
@@ -11,129 +11,312 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-// BuildPodSummaries aggregates CPU/memory requests and limits per pod.
-func BuildPodSummaries(ctx context.Context, client kubernetes.Interface, namespace string) ([]PodResourceSummary, error) {
-	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("listing pods: %w", err)
-	}
+// podListPageSize bounds how many pods are held in memory per list call when streaming
+const podListPageSize = 500
 
-	var summaries []PodResourceSummary
-	podMap := make(map[string]*PodResourceSummary)
-
-	for i := range pods.Items {
-		pod := &pods.Items[i]
-		key := pod.Namespace + "/" + pod.Name
-
-		if _, exists := podMap[key]; !exists {
-			podMap[key] = &PodResourceSummary{
-				Namespace:  pod.Namespace,
-				PodName:    pod.Name,
-				CPUUsage:   *resource.NewQuantity(0, resource.DecimalSI),
-				CPURequest: *resource.NewQuantity(0, resource.DecimalSI),
-				CPULimit:   *resource.NewQuantity(0, resource.DecimalSI),
-				MemUsage:   *resource.NewQuantity(0, resource.BinarySI),
-				MemRequest: *resource.NewQuantity(0, resource.BinarySI),
-				MemLimit:   *resource.NewQuantity(0, resource.BinarySI),
-			}
+// forEachPod lists pods matching fieldSelector page by page and calls fn for each
+// pod accepted by filters. Iteration stops at the first error returned by fn.
+func forEachPod(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string, filters []PodFilter, fn func(pod *corev1.Pod) error) error {
+	opts := metav1.ListOptions{Limit: podListPageSize, FieldSelector: fieldSelector}
+	for {
+		pods, err := client.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("listing pods: %w", err)
 		}
-
-		summary := podMap[key]
-
-		// Sum all container requests/limits
-		for _, c := range pod.Spec.Containers {
-			if c.Resources.Requests != nil {
-				if cpuReq, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
-					summary.CPURequest.Add(cpuReq)
-				}
-				if memReq, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
-					summary.MemRequest.Add(memReq)
-				}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if !acceptPod(pod, filters) {
+				continue
 			}
-			if c.Resources.Limits != nil {
-				if cpuLim, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
-					summary.CPULimit.Add(cpuLim)
-				}
-				if memLim, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
-					summary.MemLimit.Add(memLim)
-				}
+			if err := fn(pod); err != nil {
+				return err
 			}
 		}
+		if pods.Continue == "" {
+			return nil
+		}
+		opts.Continue = pods.Continue
+	}
+}
+
+// ForEachPodSummary streams the requests/limits summary of every pod to fn in API
+// list order, without collecting them. Pods are listed in pages, so memory stays
+// bounded on large clusters. A non-empty fieldSelector filters the pod list
+// server-side. Iteration stops at the first error returned by fn.
+func ForEachPodSummary(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string, fn func(PodResourceSummary) error, filters ...PodFilter) error {
+	return forEachPod(ctx, client, namespace, fieldSelector, filters, func(pod *corev1.Pod) error {
+		return fn(summarizePod(pod))
+	})
+}
 
-		// Sum all init container requests/limits
+// ForEachContainer streams the requests/limits of every container to fn in API
+// list order (init containers first within a pod). A non-empty fieldSelector
+// filters the pod list server-side. Iteration stops at the first error returned by fn.
+func ForEachContainer(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string, fn func(ContainerResources) error, filters ...PodFilter) error {
+	return forEachPod(ctx, client, namespace, fieldSelector, filters, func(pod *corev1.Pod) error {
 		for _, c := range pod.Spec.InitContainers {
-			if c.Resources.Requests != nil {
-				if cpuReq, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
-					summary.CPURequest.Add(cpuReq)
-				}
-				if memReq, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
-					summary.MemRequest.Add(memReq)
-				}
+			if err := fn(extractContainerResources(pod.Namespace, pod.Name, c, true)); err != nil {
+				return err
 			}
-			if c.Resources.Limits != nil {
-				if cpuLim, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
-					summary.CPULimit.Add(cpuLim)
-				}
-				if memLim, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
-					summary.MemLimit.Add(memLim)
-				}
+		}
+		for _, c := range pod.Spec.Containers {
+			if err := fn(extractContainerResources(pod.Namespace, pod.Name, c, false)); err != nil {
+				return err
 			}
 		}
+		return nil
+	})
+}
+
+// BuildPodSummaries aggregates CPU/memory requests and limits per pod.
+// A non-empty fieldSelector filters the pod list server-side. Pods rejected
+// by any of the optional filters are skipped.
+func BuildPodSummaries(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string, filters ...PodFilter) ([]PodResourceSummary, error) {
+	var summaries []PodResourceSummary
+	err := ForEachPodSummary(ctx, client, namespace, fieldSelector, func(summary PodResourceSummary) error {
+		summaries = append(summaries, summary)
+		return nil
+	}, filters...)
+	if err != nil {
+		return nil, err
 	}
 
-	// Convert map to slice and sort by namespace/pod name
-	for _, summary := range podMap {
-		summaries = append(summaries, *summary)
+	sortPodSummaries(summaries)
+
+	return summaries, nil
+}
+
+// NonZeroRequestPods returns the summaries that request some CPU or memory
+func NonZeroRequestPods(pods []PodResourceSummary) []PodResourceSummary {
+	kept := make([]PodResourceSummary, 0, len(pods))
+	for _, p := range pods {
+		if !p.CPURequest.IsZero() || !p.MemRequest.IsZero() {
+			kept = append(kept, p)
+		}
 	}
+	return kept
+}
 
+// CountPods tallies pods, scheduled vs pending pods, and containers in summaries
+func CountPods(pods []PodResourceSummary) PodCounts {
+	counts := PodCounts{Pods: len(pods)}
+	for _, p := range pods {
+		if p.NodeName != "" {
+			counts.Scheduled++
+		} else {
+			counts.Pending++
+		}
+		counts.Containers += p.ContainerCount + p.InitContainerCount
+		counts.InitContainers += p.InitContainerCount
+	}
+	return counts
+}
+
+// NonZeroRequestContainers returns the containers that request some CPU or memory
+func NonZeroRequestContainers(containers []ContainerResources) []ContainerResources {
+	kept := make([]ContainerResources, 0, len(containers))
+	for _, c := range containers {
+		if (c.HasCPURequest && !c.CPURequest.IsZero()) || (c.HasMemRequest && !c.MemRequest.IsZero()) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// sortPodSummaries orders summaries by namespace, then pod name
+func sortPodSummaries(summaries []PodResourceSummary) {
 	sort.Slice(summaries, func(i, j int) bool {
 		if summaries[i].Namespace == summaries[j].Namespace {
 			return summaries[i].PodName < summaries[j].PodName
 		}
 		return summaries[i].Namespace < summaries[j].Namespace
 	})
+}
 
-	return summaries, nil
+// summarizePod sums the requests/limits of a pod's containers and init containers
+func summarizePod(pod *corev1.Pod) PodResourceSummary {
+	summary := PodResourceSummary{
+		Namespace:   pod.Namespace,
+		PodName:     pod.Name,
+		NodeName:    pod.Spec.NodeName,
+		Annotations: pod.Annotations,
+		CPUUsage:    *resource.NewQuantity(0, resource.DecimalSI),
+		CPURequest:  *resource.NewQuantity(0, resource.DecimalSI),
+		CPULimit:    *resource.NewQuantity(0, resource.DecimalSI),
+		MemUsage:    *resource.NewQuantity(0, resource.BinarySI),
+		MemRequest:  *resource.NewQuantity(0, resource.BinarySI),
+		MemLimit:    *resource.NewQuantity(0, resource.BinarySI),
+
+		ContainerCount:     len(pod.Spec.Containers),
+		InitContainerCount: len(pod.Spec.InitContainers),
+	}
+
+	containers := make([]corev1.Container, 0, len(pod.Spec.Containers)+len(pod.Spec.InitContainers))
+	containers = append(containers, pod.Spec.Containers...)
+	containers = append(containers, pod.Spec.InitContainers...)
+	for _, c := range containers {
+		if cpuReq, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
+			summary.CPURequest.Add(cpuReq)
+		}
+		if memReq, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+			summary.MemRequest.Add(memReq)
+		}
+		if cpuLim, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
+			summary.CPULimit.Add(cpuLim)
+		}
+		if memLim, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+			summary.MemLimit.Add(memLim)
+		}
+	}
+
+	return summary
 }
 
-// BuildPodSummariesWithUsage aggregates CPU/memory including actual usage from metrics.
-func BuildPodSummariesWithUsage(ctx context.Context, client kubernetes.Interface, metricsReader MetricsReader, namespace string) ([]PodResourceSummary, error) {
-	// Get base summaries (requests/limits)
-	summaries, err := BuildPodSummaries(ctx, client, namespace)
+// BuildPodSummariesWithAllocatable is BuildPodSummaries with each pod's requests
+// also expressed as a percentage of the cluster's total node allocatable.
+// Percentages stay nil for a resource with zero allocatable.
+func BuildPodSummariesWithAllocatable(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string, filters ...PodFilter) ([]PodResourceSummary, error) {
+	summaries, err := BuildPodSummaries(ctx, client, namespace, fieldSelector, filters...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Try to get usage metrics
-	usages, err := metricsReader.PodMetrics(ctx, namespace)
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
-		// Metrics not available, just return request/limit summaries
-		return summaries, nil
+		return nil, fmt.Errorf("listing nodes: %w", err)
 	}
 
-	// Create usage map for quick lookup
-	usageMap := make(map[string]*ContainerUsage)
-	for _, u := range usages {
-		key := u.Namespace + "/" + u.PodName + "/" + u.ContainerName
-		usageMap[key] = &u
+	var cpuAllocatable, memAllocatable int64
+	for _, node := range nodes.Items {
+		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+			cpuAllocatable += cpu.MilliValue()
+		}
+		if mem, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+			memAllocatable += mem.Value()
+		}
 	}
 
-	// Update summaries with usage data
 	for i := range summaries {
-		pods, err := client.CoreV1().Pods(summaries[i].Namespace).List(ctx, metav1.ListOptions{
-			FieldSelector: "metadata.name=" + summaries[i].PodName,
-		})
-		if err == nil && len(pods.Items) > 0 {
-			pod := &pods.Items[0]
-			for _, c := range pod.Spec.Containers {
-				key := summaries[i].Namespace + "/" + summaries[i].PodName + "/" + c.Name
-				if usage, ok := usageMap[key]; ok {
-					summaries[i].CPUUsage.Add(usage.CPUUsage)
-					summaries[i].MemUsage.Add(usage.MemUsage)
-				}
+		if cpuAllocatable > 0 {
+			pct := float64(summaries[i].CPURequest.MilliValue()) / float64(cpuAllocatable) * 100
+			summaries[i].CPUPercentOfAllocatable = &pct
+		}
+		if memAllocatable > 0 {
+			pct := float64(summaries[i].MemRequest.Value()) / float64(memAllocatable) * 100
+			summaries[i].MemPercentOfAllocatable = &pct
+		}
+	}
+
+	return summaries, nil
+}
+
+// BuildContainerSummaries returns one row per container with its requests/limits,
+// sorted by namespace and pod name (init containers first within a pod).
+// A non-empty fieldSelector filters the pod list server-side. Pods rejected
+// by any of the optional filters are skipped.
+func BuildContainerSummaries(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string, filters ...PodFilter) ([]ContainerResources, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: fieldSelector})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+	items := filterPods(pods.Items, filters)
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace == items[j].Namespace {
+			return items[i].Name < items[j].Name
+		}
+		return items[i].Namespace < items[j].Namespace
+	})
+
+	var containers []ContainerResources
+	for _, pod := range items {
+		for _, c := range pod.Spec.InitContainers {
+			containers = append(containers, extractContainerResources(pod.Namespace, pod.Name, c, true))
+		}
+		for _, c := range pod.Spec.Containers {
+			containers = append(containers, extractContainerResources(pod.Namespace, pod.Name, c, false))
+		}
+	}
+
+	return containers, nil
+}
+
+// BuildSinglePodSummary fetches one pod by name and returns its aggregated
+// requests/limits together with its per-container resources (init containers first).
+func BuildSinglePodSummary(ctx context.Context, client kubernetes.Interface, namespace, name string) (*PodResourceSummary, []ContainerResources, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting pod %s/%s: %w", namespace, name, err)
+	}
+
+	summary := &PodResourceSummary{
+		Namespace:   pod.Namespace,
+		PodName:     pod.Name,
+		NodeName:    pod.Spec.NodeName,
+		Annotations: pod.Annotations,
+		CPUUsage:    *resource.NewQuantity(0, resource.DecimalSI),
+		CPURequest:  *resource.NewQuantity(0, resource.DecimalSI),
+		CPULimit:    *resource.NewQuantity(0, resource.DecimalSI),
+		MemUsage:    *resource.NewQuantity(0, resource.BinarySI),
+		MemRequest:  *resource.NewQuantity(0, resource.BinarySI),
+		MemLimit:    *resource.NewQuantity(0, resource.BinarySI),
+	}
+
+	containers := make([]ContainerResources, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	for _, c := range pod.Spec.InitContainers {
+		containers = append(containers, extractContainerResources(pod.Namespace, pod.Name, c, true))
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, extractContainerResources(pod.Namespace, pod.Name, c, false))
+	}
+
+	for _, cr := range containers {
+		if cr.HasCPURequest {
+			summary.CPURequest.Add(cr.CPURequest)
+		}
+		if cr.HasCPULimit {
+			summary.CPULimit.Add(cr.CPULimit)
+		}
+		if cr.HasMemRequest {
+			summary.MemRequest.Add(cr.MemRequest)
+		}
+		if cr.HasMemLimit {
+			summary.MemLimit.Add(cr.MemLimit)
+		}
+	}
+
+	return summary, containers, nil
+}
+
+// BuildPodSummariesWithUsage aggregates CPU/memory including actual usage from metrics.
+// Pods are listed once and joined with the metrics by namespace/pod/container.
+// When metrics cannot be read, the request/limit summaries are returned without usage.
+func BuildPodSummariesWithUsage(ctx context.Context, client kubernetes.Interface, metricsReader MetricsReader, namespace string) ([]PodResourceSummary, error) {
+	// Create usage map for quick lookup; it stays empty when metrics are not available
+	usageMap := make(map[string]ContainerUsage)
+	if usages, err := metricsReader.PodMetrics(ctx, namespace); err == nil {
+		for _, u := range usages {
+			usageMap[u.Namespace+"/"+u.PodName+"/"+u.ContainerName] = u
+		}
+	}
+
+	var summaries []PodResourceSummary
+	err := forEachPod(ctx, client, namespace, "", nil, func(pod *corev1.Pod) error {
+		summary := summarizePod(pod)
+		for _, c := range pod.Spec.Containers {
+			if usage, ok := usageMap[pod.Namespace+"/"+pod.Name+"/"+c.Name]; ok {
+				summary.CPUUsage.Add(usage.CPUUsage)
+				summary.MemUsage.Add(usage.MemUsage)
 			}
 		}
+		summaries = append(summaries, summary)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	sortPodSummaries(summaries)
+
 	return summaries, nil
 }
@@ -39,7 +39,7 @@ func TestBuildPodSummaries_Integration(t *testing.T) {
 	client := fake.NewSimpleClientset(pod)
 	ctx := context.Background()
 
-	summaries, err := BuildPodSummaries(ctx, client, "")
+	summaries, err := BuildPodSummaries(ctx, client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -111,7 +111,7 @@ func TestBuildPodSummaries_MultipleNamespaces(t *testing.T) {
 	client := fake.NewSimpleClientset(pods[0], pods[1])
 	ctx := context.Background()
 
-	summaries, err := BuildPodSummaries(ctx, client, "")
+	summaries, err := BuildPodSummaries(ctx, client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -176,7 +176,7 @@ func TestBuildPodSummaries_WithNamespaceFilter(t *testing.T) {
 	client := fake.NewSimpleClientset(pods[0], pods[1])
 	ctx := context.Background()
 
-	summaries, err := BuildPodSummaries(ctx, client, "default")
+	summaries, err := BuildPodSummaries(ctx, client, "default", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -194,7 +194,7 @@ func TestBuildPodSummaries_EmptyCluster(t *testing.T) {
 	client := fake.NewSimpleClientset()
 	ctx := context.Background()
 
-	summaries, err := BuildPodSummaries(ctx, client, "")
+	summaries, err := BuildPodSummaries(ctx, client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -223,7 +223,7 @@ func TestBuildPodSummaries_MissingResources(t *testing.T) {
 	client := fake.NewSimpleClientset(pod)
 	ctx := context.Background()
 
-	summaries, err := BuildPodSummaries(ctx, client, "")
+	summaries, err := BuildPodSummaries(ctx, client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -275,7 +275,7 @@ func TestBuildPodSummaries_MultipleContainers(t *testing.T) {
 	client := fake.NewSimpleClientset(pod)
 	ctx := context.Background()
 
-	summaries, err := BuildPodSummaries(ctx, client, "")
+	summaries, err := BuildPodSummaries(ctx, client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -296,3 +296,150 @@ func TestBuildPodSummaries_MultipleContainers(t *testing.T) {
 		t.Errorf("expected aggregated memory %d, got %d", expectedMem, summaries[0].MemRequest.Value())
 	}
 }
+
+// TestBuildPodSummaries_ContainerCounts tests that main and init containers are counted separately
+func TestBuildPodSummaries_ContainerCounts(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "sidecars", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate"}},
+			Containers:     []corev1.Container{{Name: "app"}, {Name: "proxy"}},
+		},
+	}
+
+	summaries, err := BuildPodSummaries(context.Background(), fake.NewSimpleClientset(pod), "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("expected 1 summary, got %d", len(summaries))
+	}
+	if summaries[0].ContainerCount != 2 || summaries[0].InitContainerCount != 1 {
+		t.Errorf("expected 2 containers and 1 init container, got %d and %d",
+			summaries[0].ContainerCount, summaries[0].InitContainerCount)
+	}
+}
+
+// TestBuildSinglePodSummary tests fetching and summarizing one pod by name
+func TestBuildSinglePodSummary(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name: "migrate",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name: "nginx",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("200m"),
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+			}},
+		},
+	}
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "shop"}}
+
+	client := fake.NewSimpleClientset(pod, other)
+	summary, containers, err := BuildSinglePodSummary(context.Background(), client, "shop", "web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.PodName != "web" || summary.CPURequest.String() != "250m" || summary.MemLimit.String() != "512Mi" {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if len(containers) != 2 || !containers[0].IsInit || containers[1].ContainerName != "nginx" {
+		t.Fatalf("expected init container then nginx, got %+v", containers)
+	}
+	if containers[1].HasCPULimit || !containers[1].HasMemLimit {
+		t.Errorf("expected only a memory limit on nginx, got %+v", containers[1])
+	}
+
+	if _, _, err := BuildSinglePodSummary(context.Background(), client, "shop", "missing"); err == nil {
+		t.Error("expected error for a pod that does not exist")
+	}
+}
+
+func TestBuildContainerSummaries_OneRowPerContainer(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+					},
+				},
+				{
+					Name: "envoy",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+					},
+				},
+			},
+		},
+	}
+
+	containers, err := BuildContainerSummaries(context.Background(), fake.NewSimpleClientset(pod), "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(containers) != 2 {
+		t.Fatalf("expected 2 container rows, got %d", len(containers))
+	}
+	if containers[0].ContainerName != "app" || containers[1].ContainerName != "envoy" {
+		t.Errorf("expected rows for app and envoy, got %s and %s", containers[0].ContainerName, containers[1].ContainerName)
+	}
+	if containers[1].PodName != "web" || containers[1].CPURequest.String() != "50m" {
+		t.Errorf("expected envoy row of pod web with 50m CPU request, got %+v", containers[1])
+	}
+}
+
+func TestBuildPodSummariesWithAllocatable_PercentOfCluster(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	summaries, err := BuildPodSummariesWithAllocatable(context.Background(), fake.NewSimpleClientset(node, pod), "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("expected 1 pod summary, got %d", len(summaries))
+	}
+	if got := summaries[0].CPUPercentOfAllocatable; got == nil || *got != 25 {
+		t.Errorf("expected CPU at 25%% of allocatable, got %v", got)
+	}
+	if got := summaries[0].MemPercentOfAllocatable; got == nil || *got != 12.5 {
+		t.Errorf("expected memory at 12.5%% of allocatable, got %v", got)
+	}
+}
@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// LimitRangeViolation describes a container request or limit outside the
+// min/max bounds of a Container-type LimitRange item in its namespace.
+type LimitRangeViolation struct {
+	Namespace  string
+	Pod        string
+	Container  string
+	LimitRange string
+	Resource   v1.ResourceName
+	Field      string // "request" or "limit"
+	Bound      string // "max" or "min"
+	Value      resource.Quantity
+	Constraint resource.Quantity
+}
+
+// FindLimitRangeViolations compares each container's requests and limits with the
+// Container-type LimitRange constraints from BuildInventory. A value above max or
+// below min is a violation; values that are not set are not checked.
+// The result is sorted by namespace, pod, container, resource and field.
+func FindLimitRangeViolations(containers []ContainerResources, policies []PolicySummary) []LimitRangeViolation {
+	rangesByNS := make(map[string][]LimitRangeSummary, len(policies))
+	for _, ps := range policies {
+		rangesByNS[ps.Namespace] = ps.LimitRanges
+	}
+
+	var violations []LimitRangeViolation
+	for _, cr := range containers {
+		for _, lr := range rangesByNS[cr.Namespace] {
+			for _, item := range lr.Items {
+				if item.Type != string(v1.LimitTypeContainer) {
+					continue
+				}
+				checks := []struct {
+					resource v1.ResourceName
+					field    string
+					value    resource.Quantity
+					set      bool
+				}{
+					{v1.ResourceCPU, "request", cr.CPURequest, cr.HasCPURequest},
+					{v1.ResourceCPU, "limit", cr.CPULimit, cr.HasCPULimit},
+					{v1.ResourceMemory, "request", cr.MemRequest, cr.HasMemRequest},
+					{v1.ResourceMemory, "limit", cr.MemLimit, cr.HasMemLimit},
+				}
+				for _, c := range checks {
+					if !c.set {
+						continue
+					}
+					maxStr, minStr := item.MaxCPU, item.MinCPU
+					if c.resource == v1.ResourceMemory {
+						maxStr, minStr = item.MaxMemory, item.MinMemory
+					}
+					violation := LimitRangeViolation{
+						Namespace:  cr.Namespace,
+						Pod:        cr.PodName,
+						Container:  cr.ContainerName,
+						LimitRange: lr.Name,
+						Resource:   c.resource,
+						Field:      c.field,
+						Value:      c.value,
+					}
+					if upper, ok := parseConstraint(maxStr); ok && c.value.Cmp(upper) > 0 {
+						violation.Bound, violation.Constraint = "max", upper
+						violations = append(violations, violation)
+					}
+					if lower, ok := parseConstraint(minStr); ok && c.value.Cmp(lower) < 0 {
+						violation.Bound, violation.Constraint = "min", lower
+						violations = append(violations, violation)
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Pod != b.Pod {
+			return a.Pod < b.Pod
+		}
+		if a.Container != b.Container {
+			return a.Container < b.Container
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return a.Field > b.Field // request before limit
+	})
+
+	return violations
+}
+
+// parseConstraint parses a LimitRange bound captured by summarizeLimitRange.
+// Empty or malformed strings mean no constraint.
+func parseConstraint(s string) (resource.Quantity, bool) {
+	if s == "" {
+		return resource.Quantity{}, false
+	}
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return resource.Quantity{}, false
+	}
+	return q, true
+}
@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindLimitRangeViolations_ExceedsMaxCPU(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("500m"),
+						v1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+					Limits: v1.ResourceList{
+						v1.ResourceCPU: resource.MustParse("4"),
+					},
+				},
+			}},
+		},
+	}
+	limitRange := &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "team-a"},
+		Spec: v1.LimitRangeSpec{
+			Limits: []v1.LimitRangeItem{{
+				Type: v1.LimitTypeContainer,
+				Max: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("2"),
+					v1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+				Min: v1.ResourceList{
+					v1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+			}},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod, limitRange)
+	_, containers, policies, err := BuildInventory(context.Background(), client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	violations := FindLimitRangeViolations(containers, policies)
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %+v", len(violations), violations)
+	}
+
+	v := violations[0]
+	if v.Namespace != "team-a" || v.Pod != "app" || v.LimitRange != "limits" {
+		t.Errorf("unexpected violation: %+v", v)
+	}
+	if v.Resource != v1.ResourceCPU || v.Field != "limit" || v.Bound != "max" {
+		t.Errorf("expected CPU limit above max, got %s %s %s", v.Resource, v.Field, v.Bound)
+	}
+	if v.Constraint.Cmp(resource.MustParse("2")) != 0 {
+		t.Errorf("expected max constraint 2, got %s", v.Constraint.String())
+	}
+}
+
+func TestFindLimitRangeViolations_BelowMin(t *testing.T) {
+	containers := []ContainerResources{
+		{Namespace: "team-a", PodName: "tiny", ContainerName: "app", MemRequest: resource.MustParse("16Mi"), HasMemRequest: true},
+		{Namespace: "team-b", PodName: "other", ContainerName: "app", MemRequest: resource.MustParse("16Mi"), HasMemRequest: true},
+	}
+	policies := []PolicySummary{{
+		Namespace:   "team-a",
+		LimitRanges: []LimitRangeSummary{{Name: "limits", Items: []LimitRangeItemSummary{{Type: "Container", MinMemory: "64Mi"}}}},
+	}}
+
+	violations := FindLimitRangeViolations(containers, policies)
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %+v", len(violations), violations)
+	}
+	if v := violations[0]; v.Pod != "tiny" || v.Bound != "min" || v.Resource != v1.ResourceMemory {
+		t.Errorf("unexpected violation: %+v", v)
+	}
+}
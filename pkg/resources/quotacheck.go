@@ -0,0 +1,136 @@
+package resources
+
+import (
+	"math"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DefaultQuotaDriftTolerance is the relative difference between a quota's Used
+// amount and the summed pod requests/limits that is still considered in sync.
+const DefaultQuotaDriftTolerance = 0.05
+
+// QuotaDrift describes a ResourceQuota whose reported usage disagrees with the
+// requests/limits summed from the namespace's pods.
+type QuotaDrift struct {
+	Namespace string
+	Quota     string
+	Resource  v1.ResourceName
+	QuotaUsed resource.Quantity
+	Summed    resource.Quantity
+	// Ratio is |used - summed| / max(used, summed)
+	Ratio float64
+}
+
+// FindQuotaDrift compares each ResourceQuota's Used values against the
+// requests/limits summed from containers, as returned by BuildInventory, and
+// returns entries differing by more than tolerance. Each pod counts the way
+// quota accounting charges it: the sum of its regular containers, or its
+// largest init container when that is bigger. Only compute resources
+// (cpu/memory requests and limits) are compared. The result is sorted by
+// namespace, quota and resource name.
+func FindQuotaDrift(containers []ContainerResources, policies []PolicySummary, tolerance float64) []QuotaDrift {
+	order, totals := effectivePodTotals(containers)
+	byNS := make(map[string]podTotals)
+	for _, key := range order {
+		pod, ns := totals[key], byNS[key.namespace]
+		ns.cpuReq += pod.cpuReq
+		ns.memReq += pod.memReq
+		ns.cpuLim += pod.cpuLim
+		ns.memLim += pod.memLim
+		byNS[key.namespace] = ns
+	}
+
+	var drifts []QuotaDrift
+	for _, ps := range policies {
+		ns := byNS[ps.Namespace]
+		for _, rq := range ps.ResourceQuotas {
+			for name, used := range rq.Used {
+				summed, ok := summedForQuotaResource(ns, name)
+				if !ok {
+					continue
+				}
+				ratio := driftRatio(used, summed)
+				if ratio <= tolerance {
+					continue
+				}
+				drifts = append(drifts, QuotaDrift{
+					Namespace: ps.Namespace,
+					Quota:     rq.Name,
+					Resource:  name,
+					QuotaUsed: used,
+					Summed:    summed,
+					Ratio:     ratio,
+				})
+			}
+		}
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		a, b := drifts[i], drifts[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Quota != b.Quota {
+			return a.Quota < b.Quota
+		}
+		return a.Resource < b.Resource
+	})
+
+	return drifts
+}
+
+// UnusedQuota identifies a ResourceQuota with hard limits but nothing used.
+type UnusedQuota struct {
+	Namespace string
+	Quota     string
+}
+
+// FindUnusedQuotas returns the quotas flagged as unused, sorted by namespace and name.
+func FindUnusedQuotas(policies []PolicySummary) []UnusedQuota {
+	var unused []UnusedQuota
+	for _, ps := range policies {
+		for _, rq := range ps.ResourceQuotas {
+			if rq.Unused {
+				unused = append(unused, UnusedQuota{Namespace: ps.Namespace, Quota: rq.Name})
+			}
+		}
+	}
+
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].Namespace != unused[j].Namespace {
+			return unused[i].Namespace < unused[j].Namespace
+		}
+		return unused[i].Quota < unused[j].Quota
+	})
+
+	return unused
+}
+
+// summedForQuotaResource maps a quota resource name to the matching namespace total
+func summedForQuotaResource(ns podTotals, name v1.ResourceName) (resource.Quantity, bool) {
+	switch name {
+	case v1.ResourceRequestsCPU, v1.ResourceCPU:
+		return *resource.NewMilliQuantity(ns.cpuReq, resource.DecimalSI), true
+	case v1.ResourceRequestsMemory, v1.ResourceMemory:
+		return *resource.NewQuantity(ns.memReq, resource.BinarySI), true
+	case v1.ResourceLimitsCPU:
+		return *resource.NewMilliQuantity(ns.cpuLim, resource.DecimalSI), true
+	case v1.ResourceLimitsMemory:
+		return *resource.NewQuantity(ns.memLim, resource.BinarySI), true
+	default:
+		return resource.Quantity{}, false
+	}
+}
+
+// driftRatio returns the relative difference between two quantities (0 when both are zero)
+func driftRatio(a, b resource.Quantity) float64 {
+	x, y := float64(a.MilliValue()), float64(b.MilliValue())
+	largest := math.Max(x, y)
+	if largest == 0 {
+		return 0
+	}
+	return math.Abs(x-y) / largest
+}
@@ -0,0 +1,166 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindQuotaDrift_FlagsMismatch(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("1"),
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			}},
+		},
+	}
+	quota := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+		Status: v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{
+				v1.ResourceRequestsCPU:    resource.MustParse("4"),
+				v1.ResourceRequestsMemory: resource.MustParse("8Gi"),
+			},
+			Used: v1.ResourceList{
+				v1.ResourceRequestsCPU:    resource.MustParse("500m"),
+				v1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod, quota)
+	_, containers, policies, err := BuildInventory(context.Background(), client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	drifts := FindQuotaDrift(containers, policies, DefaultQuotaDriftTolerance)
+	if len(drifts) != 1 {
+		t.Fatalf("expected 1 drift entry, got %d: %+v", len(drifts), drifts)
+	}
+
+	d := drifts[0]
+	if d.Namespace != "team-a" || d.Quota != "compute" || d.Resource != v1.ResourceRequestsCPU {
+		t.Errorf("unexpected drift entry: %+v", d)
+	}
+	if d.Summed.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("expected summed requests 1, got %s", d.Summed.String())
+	}
+	if d.Ratio < 0.49 || d.Ratio > 0.51 {
+		t.Errorf("expected ratio ~0.5, got %f", d.Ratio)
+	}
+}
+
+func TestFindQuotaDrift_WithinTolerance(t *testing.T) {
+	containers := []ContainerResources{
+		{Namespace: "team-a", PodName: "app", ContainerName: "app", CPURequest: resource.MustParse("1"), HasCPURequest: true},
+	}
+	policies := []PolicySummary{
+		{
+			Namespace: "team-a",
+			ResourceQuotas: []ResourceQuotaSummary{{
+				Name: "compute",
+				Used: map[v1.ResourceName]resource.Quantity{
+					v1.ResourceRequestsCPU: resource.MustParse("980m"),
+					v1.ResourcePods:        resource.MustParse("3"),
+				},
+			}},
+		},
+	}
+
+	if drifts := FindQuotaDrift(containers, policies, DefaultQuotaDriftTolerance); len(drifts) != 0 {
+		t.Errorf("expected no drift, got %+v", drifts)
+	}
+}
+
+func TestFindQuotaDrift_InitContainerNotSummed(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{
+				Name: "migrate",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("500m"),
+						v1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			}},
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("1"),
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			}},
+		},
+	}
+	// Quota charges max(sum of containers, largest init container) per resource
+	quota := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+		Status: v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{
+				v1.ResourceRequestsCPU:    resource.MustParse("4"),
+				v1.ResourceRequestsMemory: resource.MustParse("8Gi"),
+			},
+			Used: v1.ResourceList{
+				v1.ResourceRequestsCPU:    resource.MustParse("1"),
+				v1.ResourceRequestsMemory: resource.MustParse("2Gi"),
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod, quota)
+	_, containers, policies, err := BuildInventory(context.Background(), client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if drifts := FindQuotaDrift(containers, policies, DefaultQuotaDriftTolerance); len(drifts) != 0 {
+		t.Errorf("expected no drift for a pod with an init container, got %+v", drifts)
+	}
+}
+
+func TestFindUnusedQuotas_HardSetNothingUsed(t *testing.T) {
+	idle := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "leftover", Namespace: "team-b"},
+		Status: v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("2")},
+			Used: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("0")},
+		},
+	}
+	busy := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+		Status: v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("2")},
+			Used: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("500m")},
+		},
+	}
+
+	client := fake.NewSimpleClientset(idle, busy)
+	_, _, policies, err := BuildInventory(context.Background(), client, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unused := FindUnusedQuotas(policies)
+	if len(unused) != 1 {
+		t.Fatalf("expected 1 unused quota, got %d: %+v", len(unused), unused)
+	}
+	if unused[0].Namespace != "team-b" || unused[0].Quota != "leftover" {
+		t.Errorf("expected team-b/leftover, got %+v", unused[0])
+	}
+}
@@ -0,0 +1,56 @@
+package resources
+
+// RatioBuckets are the limit/request ratio ranges of a RatioHistogram, in order.
+// A ratio falls in the first bucket whose upper bound it does not exceed.
+var RatioBuckets = []struct {
+	Label string
+	Max   float64 // upper bound, inclusive; 0 means unbounded
+}{
+	{Label: "1x", Max: 1},
+	{Label: "1-2x", Max: 2},
+	{Label: "2-4x", Max: 4},
+	{Label: ">4x", Max: 0},
+}
+
+// RatioHistogram counts containers per limit/request ratio bucket for one resource.
+type RatioHistogram struct {
+	Resource string
+	Counts   []int // indexed like RatioBuckets
+
+	// Containers without both a request and a limit; they have no ratio
+	Unset int
+}
+
+// BuildRatioHistograms buckets the CPU and memory limit/request ratio of every
+// container. The ratio is the burst headroom above the scheduled amount: 1x
+// means limit equals request, >4x means the container may use more than four
+// times what the scheduler reserved for it.
+func BuildRatioHistograms(containers []ContainerResources) (cpu, mem RatioHistogram) {
+	cpu = RatioHistogram{Resource: "CPU", Counts: make([]int, len(RatioBuckets))}
+	mem = RatioHistogram{Resource: "Memory", Counts: make([]int, len(RatioBuckets))}
+
+	for _, c := range containers {
+		if c.HasCPURequest && c.HasCPULimit && !c.CPURequest.IsZero() {
+			cpu.Counts[ratioBucket(float64(c.CPULimit.MilliValue())/float64(c.CPURequest.MilliValue()))]++
+		} else {
+			cpu.Unset++
+		}
+		if c.HasMemRequest && c.HasMemLimit && !c.MemRequest.IsZero() {
+			mem.Counts[ratioBucket(float64(c.MemLimit.Value())/float64(c.MemRequest.Value()))]++
+		} else {
+			mem.Unset++
+		}
+	}
+
+	return cpu, mem
+}
+
+// ratioBucket returns the index in RatioBuckets for the given ratio
+func ratioBucket(ratio float64) int {
+	for i, b := range RatioBuckets {
+		if b.Max > 0 && ratio <= b.Max {
+			return i
+		}
+	}
+	return len(RatioBuckets) - 1
+}
@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func ratioContainer(name, cpuReq, cpuLim, memReq, memLim string) ContainerResources {
+	c := ContainerResources{Namespace: "default", PodName: name, ContainerName: "app"}
+	if cpuReq != "" {
+		c.CPURequest, c.HasCPURequest = resource.MustParse(cpuReq), true
+	}
+	if cpuLim != "" {
+		c.CPULimit, c.HasCPULimit = resource.MustParse(cpuLim), true
+	}
+	if memReq != "" {
+		c.MemRequest, c.HasMemRequest = resource.MustParse(memReq), true
+	}
+	if memLim != "" {
+		c.MemLimit, c.HasMemLimit = resource.MustParse(memLim), true
+	}
+	return c
+}
+
+func TestBuildRatioHistograms_Buckets(t *testing.T) {
+	containers := []ContainerResources{
+		ratioContainer("guaranteed", "500m", "500m", "1Gi", "1Gi"),
+		ratioContainer("double", "500m", "1", "1Gi", "2Gi"),
+		ratioContainer("triple", "1", "3", "1Gi", "1536Mi"),
+		ratioContainer("bursty", "100m", "2", "128Mi", "1Gi"),
+		ratioContainer("no-limit", "100m", "", "128Mi", ""),
+	}
+
+	cpu, mem := BuildRatioHistograms(containers)
+
+	// Buckets: 1x, 1-2x, 2-4x, >4x
+	wantCPU := []int{1, 1, 1, 1}
+	wantMem := []int{1, 2, 0, 1}
+	for i, b := range RatioBuckets {
+		if cpu.Counts[i] != wantCPU[i] {
+			t.Errorf("cpu bucket %s: expected %d, got %d", b.Label, wantCPU[i], cpu.Counts[i])
+		}
+		if mem.Counts[i] != wantMem[i] {
+			t.Errorf("memory bucket %s: expected %d, got %d", b.Label, wantMem[i], mem.Counts[i])
+		}
+	}
+	if cpu.Unset != 1 || mem.Unset != 1 {
+		t.Errorf("expected 1 container without a ratio per resource, got cpu=%d memory=%d", cpu.Unset, mem.Unset)
+	}
+}
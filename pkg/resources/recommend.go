@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"math"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DefaultSuggestHeadroom is the fraction added on top of usage when suggesting a request.
+const DefaultSuggestHeadroom = 0.2
+
+// Recommendation pairs a container diff with a suggested request derived from usage.
+type Recommendation struct {
+	ContainerDiff
+
+	SuggestedCPURequest resource.Quantity
+	SuggestedMemRequest resource.Quantity
+
+	// Percentage change from the current request to the suggestion.
+	// Only meaningful when the corresponding Has*Request flag is set.
+	CPURequestDelta float64
+	MemRequestDelta float64
+}
+
+// Recommend suggests a request of usage*(1+headroom) for every container in diffs.
+// CPU suggestions are rounded up to whole millicores and memory to whole MiB.
+// Containers without a metrics sample, such as init containers, are skipped:
+// their zero usage would suggest cutting the request to nothing.
+func Recommend(diffs []ContainerDiff, headroom float64) []Recommendation {
+	factor := 1 + headroom
+	recs := make([]Recommendation, 0, len(diffs))
+	for _, d := range diffs {
+		if !d.HasUsage {
+			continue
+		}
+		rec := Recommendation{ContainerDiff: d}
+
+		cpuMilli := int64(math.Ceil(float64(d.CPUUsage.MilliValue()) * factor))
+		rec.SuggestedCPURequest = *resource.NewMilliQuantity(cpuMilli, resource.DecimalSI)
+
+		const mi = 1024 * 1024
+		memMi := int64(math.Ceil(float64(d.MemUsage.Value()) * factor / mi))
+		rec.SuggestedMemRequest = *resource.NewQuantity(memMi*mi, resource.BinarySI)
+
+		if d.HasCPURequest && !d.CPURequest.IsZero() {
+			rec.CPURequestDelta = (d.CPUUsageToRequest*factor - 1) * 100
+		}
+		if d.HasMemRequest && !d.MemRequest.IsZero() {
+			rec.MemRequestDelta = (d.MemUsageToRequest*factor - 1) * 100
+		}
+
+		recs = append(recs, rec)
+	}
+	return recs
+}
@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"math"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestRecommend_SuggestsUsagePlusHeadroom(t *testing.T) {
+	inventory := []ContainerResources{
+		{
+			Namespace:     "default",
+			PodName:       "web",
+			ContainerName: "app",
+			CPURequest:    resource.MustParse("500m"),
+			HasCPURequest: true,
+		},
+	}
+	usage := []ContainerUsage{
+		{Namespace: "default", PodName: "web", ContainerName: "app", CPUUsage: resource.MustParse("250m"), MemUsage: resource.MustParse("100Mi")},
+	}
+
+	recs := Recommend(BuildDiff(inventory, usage), DefaultSuggestHeadroom)
+	if len(recs) != 1 {
+		t.Fatalf("expected 1 recommendation, got %d", len(recs))
+	}
+	rec := recs[0]
+
+	if got := rec.SuggestedCPURequest.MilliValue(); got != 300 {
+		t.Errorf("expected suggested CPU 300m, got %dm", got)
+	}
+	if math.Abs(rec.CPURequestDelta-(-40)) > 0.01 {
+		t.Errorf("expected CPU delta -40%%, got %.2f%%", rec.CPURequestDelta)
+	}
+	if want := resource.MustParse("120Mi"); rec.SuggestedMemRequest.Cmp(want) != 0 {
+		t.Errorf("expected suggested memory 120Mi, got %s", rec.SuggestedMemRequest.String())
+	}
+	if rec.MemRequestDelta != 0 {
+		t.Errorf("expected no memory delta without a request, got %.2f", rec.MemRequestDelta)
+	}
+}
+
+func TestRecommend_SkipsContainersWithoutUsage(t *testing.T) {
+	inventory := []ContainerResources{
+		{
+			Namespace:     "default",
+			PodName:       "web",
+			ContainerName: "migrate",
+			IsInit:        true,
+			CPURequest:    resource.MustParse("200m"),
+			HasCPURequest: true,
+		},
+		{
+			Namespace:     "default",
+			PodName:       "web",
+			ContainerName: "app",
+			CPURequest:    resource.MustParse("500m"),
+			HasCPURequest: true,
+		},
+	}
+	usage := []ContainerUsage{
+		{Namespace: "default", PodName: "web", ContainerName: "app", CPUUsage: resource.MustParse("250m"), MemUsage: resource.MustParse("100Mi")},
+	}
+
+	recs := Recommend(BuildDiff(inventory, usage), DefaultSuggestHeadroom)
+	if len(recs) != 1 {
+		t.Fatalf("expected 1 recommendation, got %d: %+v", len(recs), recs)
+	}
+	if recs[0].ContainerName != "app" {
+		t.Errorf("expected only the sampled container, got %s", recs[0].ContainerName)
+	}
+}
@@ -112,7 +112,7 @@ func TestBuildInventory_ResourceCoverage(t *testing.T) {
 	client := fake.NewSimpleClientset(completePod, incompletePod)
 	ctx := context.Background()
 
-	nsInv, containers, _, err := BuildInventory(ctx, client, "")
+	nsInv, containers, _, err := BuildInventory(ctx, client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -275,7 +275,7 @@ func TestNamespaceResourceAggregation(t *testing.T) {
 	client := fake.NewSimpleClientset(pod1, pod2)
 	ctx := context.Background()
 
-	nsInv, _, _, err := BuildInventory(ctx, client, "")
+	nsInv, _, _, err := BuildInventory(ctx, client, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
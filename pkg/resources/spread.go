@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// WorkloadSpread is how the pods of one workload are distributed across nodes and zones
+type WorkloadSpread struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Pods      int
+
+	// Scheduled pods per node, and per topology.kubernetes.io/zone for nodes
+	// carrying that label
+	Nodes map[string]int
+	Zones map[string]int
+
+	// Unzoned counts scheduled pods on nodes without a zone label
+	Unzoned int
+}
+
+// Scheduled returns the number of the workload's pods assigned to a node
+func (w WorkloadSpread) Scheduled() int {
+	n := 0
+	for _, pods := range w.Nodes {
+		n += pods
+	}
+	return n
+}
+
+// SingleNode reports whether a workload with several scheduled pods has all of
+// them on one node, so losing that node takes the whole workload down
+func (w WorkloadSpread) SingleNode() bool {
+	return w.Scheduled() > 1 && len(w.Nodes) == 1
+}
+
+// SingleZone reports whether a workload with several scheduled pods has all of
+// them in one zone. Workloads with pods on unzoned nodes are never flagged.
+func (w WorkloadSpread) SingleZone() bool {
+	return w.Scheduled() > 1 && w.Unzoned == 0 && len(w.Zones) == 1
+}
+
+// Concentrated reports whether the workload sits on a single node or zone
+func (w WorkloadSpread) Concentrated() bool {
+	return w.SingleNode() || w.SingleZone()
+}
+
+// BuildWorkloadSpread groups a namespace's pods by their controlling workload and
+// counts them per node and zone. ReplicaSets created by a Deployment are reported
+// as that Deployment; pods without a controller are skipped. Concentrated
+// workloads come first, then workloads are ordered by kind and name. A
+// non-empty fieldSelector filters the pod list server-side.
+func BuildWorkloadSpread(ctx context.Context, client kubernetes.Interface, namespace, fieldSelector string, filters ...PodFilter) ([]WorkloadSpread, error) {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+	zoneOf := make(map[string]string, len(nodes.Items))
+	for _, node := range nodes.Items {
+		zoneOf[node.Name] = node.Labels[corev1.LabelTopologyZone]
+	}
+
+	type key struct{ ns, kind, name string }
+	byWorkload := make(map[key]*WorkloadSpread)
+	err = forEachPod(ctx, client, namespace, fieldSelector, filters, func(pod *corev1.Pod) error {
+		kind, name, ok := podWorkload(pod)
+		if !ok {
+			return nil
+		}
+		k := key{pod.Namespace, kind, name}
+		spread, ok := byWorkload[k]
+		if !ok {
+			spread = &WorkloadSpread{
+				Namespace: pod.Namespace,
+				Kind:      kind,
+				Name:      name,
+				Nodes:     make(map[string]int),
+				Zones:     make(map[string]int),
+			}
+			byWorkload[k] = spread
+		}
+
+		spread.Pods++
+		if pod.Spec.NodeName == "" {
+			return nil
+		}
+		spread.Nodes[pod.Spec.NodeName]++
+		if zone := zoneOf[pod.Spec.NodeName]; zone != "" {
+			spread.Zones[zone]++
+		} else {
+			spread.Unzoned++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spreads := make([]WorkloadSpread, 0, len(byWorkload))
+	for _, s := range byWorkload {
+		spreads = append(spreads, *s)
+	}
+	sort.Slice(spreads, func(i, j int) bool {
+		a, b := spreads[i], spreads[j]
+		if a.Concentrated() != b.Concentrated() {
+			return a.Concentrated()
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	return spreads, nil
+}
+
+// podWorkload returns the kind and name of the workload controlling pod. A
+// ReplicaSet named after the pod-template-hash label belongs to a Deployment.
+func podWorkload(pod *corev1.Pod) (kind, name string, ok bool) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return "", "", false
+	}
+	if ref.Kind == "ReplicaSet" {
+		if hash := pod.Labels["pod-template-hash"]; hash != "" {
+			if deployment, found := strings.CutSuffix(ref.Name, "-"+hash); found {
+				return "Deployment", deployment, true
+			}
+		}
+	}
+	return ref.Kind, ref.Name, true
+}
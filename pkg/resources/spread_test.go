@@ -0,0 +1,78 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func spreadNode(name, zone string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   name,
+		Labels: map[string]string{corev1.LabelTopologyZone: zone},
+	}}
+}
+
+func spreadPod(name, node, ownerKind, ownerName, hash string) *corev1.Pod {
+	controller := true
+	pod := placementPod("shop", name, node, "100m")
+	pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: ownerName, Controller: &controller}}
+	if hash != "" {
+		pod.Labels = map[string]string{"pod-template-hash": hash}
+	}
+	return pod
+}
+
+func TestBuildWorkloadSpread_FlagsSingleNodeWorkload(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		spreadNode("node-a", "zone-1"), spreadNode("node-b", "zone-2"),
+		// 3-replica Deployment, every pod on node-a
+		spreadPod("api-7d9f-1", "node-a", "ReplicaSet", "api-7d9f", "7d9f"),
+		spreadPod("api-7d9f-2", "node-a", "ReplicaSet", "api-7d9f", "7d9f"),
+		spreadPod("api-7d9f-3", "node-a", "ReplicaSet", "api-7d9f", "7d9f"),
+		// StatefulSet spread over both zones
+		spreadPod("db-0", "node-a", "StatefulSet", "db", ""),
+		spreadPod("db-1", "node-b", "StatefulSet", "db", ""),
+		// standalone pod, not a workload
+		placementPod("shop", "debug", "node-b", "100m"),
+	)
+
+	spreads, err := BuildWorkloadSpread(context.Background(), client, "shop", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spreads) != 2 {
+		t.Fatalf("expected 2 workloads, got %d: %+v", len(spreads), spreads)
+	}
+
+	api := spreads[0]
+	if api.Kind != "Deployment" || api.Name != "api" || api.Pods != 3 {
+		t.Fatalf("expected Deployment api with 3 pods first, got %s %s with %d", api.Kind, api.Name, api.Pods)
+	}
+	if !api.SingleNode() || !api.SingleZone() || !api.Concentrated() {
+		t.Errorf("expected api flagged as concentrated on one node and zone, got %+v", api)
+	}
+
+	db := spreads[1]
+	if db.Kind != "StatefulSet" || db.Concentrated() {
+		t.Errorf("expected StatefulSet db spread across nodes, got %+v", db)
+	}
+	if len(db.Nodes) != 2 || len(db.Zones) != 2 {
+		t.Errorf("expected db on 2 nodes in 2 zones, got nodes %v zones %v", db.Nodes, db.Zones)
+	}
+}
+
+func TestWorkloadSpread_SingleZoneNeedsZoneLabels(t *testing.T) {
+	spread := WorkloadSpread{Pods: 2, Nodes: map[string]int{"a": 1, "b": 1}, Zones: map[string]int{"zone-1": 1}, Unzoned: 1}
+	if spread.SingleZone() {
+		t.Error("expected no single-zone flag when some pods run on unzoned nodes")
+	}
+
+	single := WorkloadSpread{Pods: 1, Nodes: map[string]int{"a": 1}, Zones: map[string]int{"zone-1": 1}}
+	if single.Concentrated() {
+		t.Error("expected a single-replica workload not to be flagged")
+	}
+}
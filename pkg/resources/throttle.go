@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"sort"
+)
+
+// DefaultThrottleMargin is how close CPU usage may come to the CPU limit, as a
+// fraction of the limit, before a container is at risk of throttling.
+const DefaultThrottleMargin = 0.1
+
+// ThrottleRisk is a container whose CPU usage is within the margin of its CPU limit.
+type ThrottleRisk struct {
+	ContainerDiff
+
+	// CPUUsageToLimit is usage / limit; 1 or more means usage has reached the limit
+	CPUUsageToLimit float64
+}
+
+// FindThrottleRisks returns containers with a CPU limit whose usage is at or above
+// (1-margin) of that limit. The CFS quota throttles such containers as soon as a
+// burst pushes them over, even when the node has idle CPU.
+// The result is sorted by closeness to the limit, closest first.
+func FindThrottleRisks(diffs []ContainerDiff, margin float64) []ThrottleRisk {
+	var risks []ThrottleRisk
+	for _, d := range diffs {
+		if !d.HasCPULimit || d.CPULimit.IsZero() {
+			continue
+		}
+		ratio := float64(d.CPUUsage.MilliValue()) / float64(d.CPULimit.MilliValue())
+		if ratio >= 1-margin {
+			risks = append(risks, ThrottleRisk{ContainerDiff: d, CPUUsageToLimit: ratio})
+		}
+	}
+
+	sort.SliceStable(risks, func(i, j int) bool {
+		return risks[i].CPUUsageToLimit > risks[j].CPUUsageToLimit
+	})
+
+	return risks
+}
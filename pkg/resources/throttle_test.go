@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestFindThrottleRisks_FlagsUsageNearLimit(t *testing.T) {
+	inventory := []ContainerResources{
+		{Namespace: "default", PodName: "busy", ContainerName: "app", CPULimit: resource.MustParse("1"), HasCPULimit: true},
+		{Namespace: "default", PodName: "calm", ContainerName: "app", CPULimit: resource.MustParse("1"), HasCPULimit: true},
+		{Namespace: "default", PodName: "unbounded", ContainerName: "app"},
+	}
+	usage := []ContainerUsage{
+		{Namespace: "default", PodName: "busy", ContainerName: "app", CPUUsage: resource.MustParse("950m")},
+		{Namespace: "default", PodName: "calm", ContainerName: "app", CPUUsage: resource.MustParse("500m")},
+		{Namespace: "default", PodName: "unbounded", ContainerName: "app", CPUUsage: resource.MustParse("4")},
+	}
+
+	risks := FindThrottleRisks(BuildDiff(inventory, usage), DefaultThrottleMargin)
+	if len(risks) != 1 {
+		t.Fatalf("expected 1 throttle risk, got %d", len(risks))
+	}
+	if risks[0].PodName != "busy" || risks[0].CPUUsageToLimit != 0.95 {
+		t.Errorf("expected pod 'busy' at 0.95 of its limit, got %s at %.2f", risks[0].PodName, risks[0].CPUUsageToLimit)
+	}
+}
+
+func TestFindThrottleRisks_SortedByClosenessToLimit(t *testing.T) {
+	limit := resource.MustParse("1")
+	diffs := []ContainerDiff{
+		{PodName: "near", CPUUsage: resource.MustParse("920m"), CPULimit: limit, HasCPULimit: true},
+		{PodName: "over", CPUUsage: resource.MustParse("1100m"), CPULimit: limit, HasCPULimit: true},
+		{PodName: "at", CPUUsage: resource.MustParse("1"), CPULimit: limit, HasCPULimit: true},
+	}
+
+	risks := FindThrottleRisks(diffs, DefaultThrottleMargin)
+	want := []string{"over", "at", "near"}
+	if len(risks) != len(want) {
+		t.Fatalf("expected %d risks, got %d", len(want), len(risks))
+	}
+	for i, name := range want {
+		if risks[i].PodName != name {
+			t.Errorf("position %d: expected %s, got %s", i, name, risks[i].PodName)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package resources
 
 import (
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -10,6 +12,7 @@ type ContainerResources struct {
 	Namespace     string
 	PodName       string
 	ContainerName string
+	Image         string
 	IsInit        bool
 
 	CPURequest resource.Quantity
@@ -26,11 +29,26 @@ type ContainerResources struct {
 // NamespaceInventory aggregates resource coverage for a namespace.
 type NamespaceInventory struct {
 	Namespace string
+	Labels    map[string]string
 
 	ContainersTotal              int
 	ContainersMissingAnyRequests int
 	ContainersMissingAnyLimits   int
 
+	// Per-resource gaps; a container missing only its memory limit counts
+	// towards ContainersMissingMemLimit but not ContainersMissingCPULimit
+	ContainersMissingCPURequest int
+	ContainersMissingMemRequest int
+	ContainersMissingCPULimit   int
+	ContainersMissingMemLimit   int
+
+	// Containers requesting only one of CPU or memory; these bin-pack poorly
+	ContainersCPUOnlyRequest int
+	ContainersMemOnlyRequest int
+
+	// Containers whose requests equal their limits for both CPU and memory
+	ContainersGuaranteed int
+
 	CPURequestsTotal resource.Quantity
 	CPULimitsTotal   resource.Quantity
 	MemRequestsTotal resource.Quantity
@@ -52,13 +70,15 @@ type LimitRangeSummary struct {
 
 // LimitRangeItemSummary is one item within a LimitRange.
 type LimitRangeItemSummary struct {
-	Type          string
-	DefaultCPU    string
-	DefaultMemory string
-	MaxCPU        string
-	MaxMemory     string
-	MinCPU        string
-	MinMemory     string
+	Type                 string
+	DefaultCPU           string
+	DefaultMemory        string
+	DefaultRequestCPU    string
+	DefaultRequestMemory string
+	MaxCPU               string
+	MaxMemory            string
+	MinCPU               string
+	MinMemory            string
 }
 
 // ResourceQuotaSummary is a compact summary of a ResourceQuota.
@@ -66,15 +86,27 @@ type ResourceQuotaSummary struct {
 	Name string
 	Hard map[v1.ResourceName]resource.Quantity
 	Used map[v1.ResourceName]resource.Quantity
+
+	// Unused is true when Hard is set but every Used entry is zero,
+	// which usually means the quota is a leftover
+	Unused bool
 }
 
 // ContainerUsage holds actual observed CPU/memory usage for a container.
+// MemUsage is the working set reported by metrics-server, not RSS.
 type ContainerUsage struct {
 	Namespace     string
 	PodName       string
 	ContainerName string
 	CPUUsage      resource.Quantity
 	MemUsage      resource.Quantity
+
+	// Timestamp is when the sample was taken; Window is the interval it covers
+	Timestamp time.Time
+	Window    time.Duration
+
+	// NodeName is only set by AttachUsageNodes; metrics do not carry placement
+	NodeName string
 }
 
 // ContainerDiff compares usage with requests/limits for a container.
@@ -98,12 +130,48 @@ type ContainerDiff struct {
 	// Derived signals (ratios: usage / request)
 	CPUUsageToRequest float64
 	MemUsageToRequest float64
+
+	// Orphan marks usage reported for a container that is no longer in its
+	// pod's spec (e.g. during a rollout); requests and limits are unset
+	Orphan bool
+
+	// HasUsage is set when a metrics sample matched the container. Without
+	// one (init containers, pending or not yet scraped pods) usage reads zero.
+	HasUsage bool
+}
+
+// NamespaceDiff sums container usage and requests for a namespace. Usage is
+// only counted for containers that set the matching request, so the ratios
+// measure how much of what was requested is actually used.
+type NamespaceDiff struct {
+	Namespace  string
+	Containers int
+
+	CPUUsage      resource.Quantity
+	CPURequest    resource.Quantity
+	HasCPURequest bool
+
+	MemUsage      resource.Quantity
+	MemRequest    resource.Quantity
+	HasMemRequest bool
+
+	// Derived signals (ratios: usage / request)
+	CPUUsageToRequest float64
+	MemUsageToRequest float64
 }
 
 // PodResourceSummary aggregates CPU/memory usage, requests, and limits for a pod.
 type PodResourceSummary struct {
-	Namespace string
-	PodName   string
+	Namespace   string
+	PodName     string
+	Annotations map[string]string
+
+	// NodeName is empty while the pod is not scheduled
+	NodeName string
+
+	// Number of regular and init containers in the pod spec
+	ContainerCount     int
+	InitContainerCount int
 
 	// CPU values
 	CPUUsage   resource.Quantity
@@ -114,4 +182,32 @@ type PodResourceSummary struct {
 	MemUsage   resource.Quantity
 	MemRequest resource.Quantity
 	MemLimit   resource.Quantity
+
+	// Requests as a percentage of cluster allocatable (0-100).
+	// Nil unless built by BuildPodSummariesWithAllocatable.
+	CPUPercentOfAllocatable *float64
+	MemPercentOfAllocatable *float64
+}
+
+// PodCounts tallies pods and their containers; a pod without a node is pending.
+// Containers includes init containers, which are also counted in InitContainers.
+type PodCounts struct {
+	Pods      int
+	Scheduled int
+	Pending   int
+
+	Containers     int
+	InitContainers int
+}
+
+// ResourceGroupSummary aggregates requests and limits for pods sharing an annotation value.
+type ResourceGroupSummary struct {
+	Key   string
+	Value string
+	Pods  int
+
+	CPURequest resource.Quantity
+	CPULimit   resource.Quantity
+	MemRequest resource.Quantity
+	MemLimit   resource.Quantity
 }
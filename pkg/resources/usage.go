@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
+	"github.com/marcgeld/cobrak/pkg/logging"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
@@ -36,6 +39,8 @@ func NewMetricsReaderFromConfig(cfg *rest.Config) (MetricsReader, error) {
 func (m *metricsReaderImpl) IsAvailable(ctx context.Context) (bool, error) {
 	_, err := m.client.MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{Limit: 1})
 	if err != nil {
+		// Not installed and not permitted look the same to callers; keep the cause for -vv
+		logging.FromContext(ctx).Debugf("metrics API probe failed: %v", err)
 		return false, nil
 	}
 	return true, nil
@@ -51,6 +56,97 @@ func (m *metricsReaderImpl) PodMetrics(ctx context.Context, namespace string) ([
 	return extractContainerUsages(podMetrics.Items), nil
 }
 
+// AttachUsageNodes sets NodeName on each usage from the Spec.NodeName of its
+// pod, listing the pods in namespace once. Usages whose pod is gone keep an
+// empty node.
+func AttachUsageNodes(ctx context.Context, client kubernetes.Interface, namespace string, usages []ContainerUsage) error {
+	type podKey struct{ namespace, name string }
+	nodes := make(map[podKey]string)
+	err := forEachPod(ctx, client, namespace, "", nil, func(pod *v1.Pod) error {
+		nodes[podKey{pod.Namespace, pod.Name}] = pod.Spec.NodeName
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range usages {
+		usages[i].NodeName = nodes[podKey{usages[i].Namespace, usages[i].PodName}]
+	}
+	return nil
+}
+
+// Orders accepted by SortContainerUsages
+const (
+	UsageSortName = "name"
+	UsageSortCPU  = "cpu"
+	UsageSortMem  = "mem"
+)
+
+// ValidateUsageSort checks an order for SortContainerUsages
+func ValidateUsageSort(by string) error {
+	switch by {
+	case UsageSortName, UsageSortCPU, UsageSortMem:
+		return nil
+	default:
+		return fmt.Errorf("invalid sort order %q: must be %s, %s or %s", by, UsageSortName, UsageSortCPU, UsageSortMem)
+	}
+}
+
+// SortContainerUsages orders usages by namespace, pod and container name, or by
+// CPU or memory usage (highest first, ties by name).
+func SortContainerUsages(usages []ContainerUsage, by string) error {
+	if err := ValidateUsageSort(by); err != nil {
+		return err
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		switch by {
+		case UsageSortCPU:
+			if c := a.CPUUsage.Cmp(b.CPUUsage); c != 0 {
+				return c > 0
+			}
+		case UsageSortMem:
+			if c := a.MemUsage.Cmp(b.MemUsage); c != 0 {
+				return c > 0
+			}
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.PodName != b.PodName {
+			return a.PodName < b.PodName
+		}
+		return a.ContainerName < b.ContainerName
+	})
+	return nil
+}
+
+// CheckMetricsAge returns an error when the newest sample in usages is older than
+// maxAge at now. Samples without a timestamp are ignored; a maxAge of zero or less
+// disables the check.
+func CheckMetricsAge(usages []ContainerUsage, maxAge time.Duration, now time.Time) error {
+	if maxAge <= 0 {
+		return nil
+	}
+
+	var newest time.Time
+	for _, u := range usages {
+		if u.Timestamp.After(newest) {
+			newest = u.Timestamp
+		}
+	}
+	if newest.IsZero() {
+		return nil
+	}
+
+	if age := now.Sub(newest); age > maxAge {
+		return fmt.Errorf("newest metrics sample is %s old, exceeding max age %s", age.Round(time.Second), maxAge)
+	}
+	return nil
+}
+
 func extractContainerUsages(items []metricsv1beta1.PodMetrics) []ContainerUsage {
 	var usages []ContainerUsage
 	for i := range items {
@@ -60,10 +156,13 @@ func extractContainerUsages(items []metricsv1beta1.PodMetrics) []ContainerUsage
 				Namespace:     pm.Namespace,
 				PodName:       pm.Name,
 				ContainerName: c.Name,
+				Timestamp:     pm.Timestamp.Time,
+				Window:        pm.Window.Duration,
 			}
 			if cpuQ, ok := c.Usage[v1.ResourceCPU]; ok {
 				cu.CPUUsage = cpuQ.DeepCopy()
 			}
+			// metrics-server reports the memory working set here
 			if memQ, ok := c.Usage[v1.ResourceMemory]; ok {
 				cu.MemUsage = memQ.DeepCopy()
 			}
@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
@@ -296,6 +297,48 @@ func TestBuildPodSummariesWithUsage_NamespaceFilter(t *testing.T) {
 	}
 }
 
+// TestBuildPodSummariesWithUsage_SingleListSameNames tests that pods are listed once and
+// that pods sharing a name in different namespaces each get their own usage
+func TestBuildPodSummariesWithUsage_SingleListSameNames(t *testing.T) {
+	var objects []runtime.Object
+	var usages []ContainerUsage
+	for i, ns := range []string{"team-a", "team-b", "team-c"} {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: ns},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		})
+		usages = append(usages, ContainerUsage{
+			Namespace: ns, PodName: "web", ContainerName: "app",
+			CPUUsage: *resource.NewMilliQuantity(int64(100*(i+1)), resource.DecimalSI),
+		})
+	}
+
+	client := fake.NewSimpleClientset(objects...)
+	summaries, err := BuildPodSummariesWithUsage(context.Background(), client, &MockMetricsReader{available: true, usages: usages}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lists := 0
+	for _, action := range client.Actions() {
+		if action.Matches("list", "pods") {
+			lists++
+		}
+	}
+	if lists != 1 {
+		t.Errorf("expected 1 pod List call, got %d", lists)
+	}
+
+	if len(summaries) != 3 {
+		t.Fatalf("expected 3 summaries, got %d", len(summaries))
+	}
+	for i, s := range summaries {
+		if want := int64(100 * (i + 1)); s.CPUUsage.MilliValue() != want {
+			t.Errorf("%s/%s: expected %dm CPU usage, got %dm", s.Namespace, s.PodName, want, s.CPUUsage.MilliValue())
+		}
+	}
+}
+
 // TestBuildPodSummariesWithUsage_UsageVsRequest tests usage comparison with requests
 func TestBuildPodSummariesWithUsage_UsageVsRequest(t *testing.T) {
 	pod := &corev1.Pod{
@@ -1,10 +1,24 @@
 package resources
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/marcgeld/cobrak/pkg/logging"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
 )
 
 type fakeMetricsReader struct {
@@ -32,6 +46,24 @@ func TestFakeMetricsReader_NotAvailable(t *testing.T) {
 	}
 }
 
+func TestMetricsReader_IsAvailableLogsProbeError(t *testing.T) {
+	client := metricsfake.NewSimpleClientset()
+	client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}, "", errors.New("denied"))
+	})
+	reader := &metricsReaderImpl{client: client}
+
+	var logs bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), logging.New(&logs, logging.LevelDebug))
+	available, err := reader.IsAvailable(ctx)
+	if err != nil || available {
+		t.Fatalf("expected unavailable without error, got %v, %v", available, err)
+	}
+	if !strings.Contains(logs.String(), "forbidden") {
+		t.Errorf("expected the probe error to be logged, got %q", logs.String())
+	}
+}
+
 func TestFakeMetricsReader_PodMetrics(t *testing.T) {
 	reader := &fakeMetricsReader{
 		available: true,
@@ -48,3 +80,77 @@ func TestFakeMetricsReader_PodMetrics(t *testing.T) {
 		t.Errorf("expected 2 usages, got %d", len(usages))
 	}
 }
+
+func TestExtractContainerUsages_CarriesWindow(t *testing.T) {
+	sampled := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	items := []metricsv1beta1.PodMetrics{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1"},
+			Timestamp:  metav1.NewTime(sampled),
+			Window:     metav1.Duration{Duration: 30 * time.Second},
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{
+					Name: "c1",
+					Usage: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("100m"),
+						v1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	usages := extractContainerUsages(items)
+	if len(usages) != 1 {
+		t.Fatalf("expected 1 usage, got %d", len(usages))
+	}
+	if usages[0].Window != 30*time.Second {
+		t.Errorf("expected window 30s, got %s", usages[0].Window)
+	}
+	if !usages[0].Timestamp.Equal(sampled) {
+		t.Errorf("expected timestamp %s, got %s", sampled, usages[0].Timestamp)
+	}
+}
+
+func TestCheckMetricsAge_StaleSampleFails(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	usages := []ContainerUsage{
+		{Namespace: "default", PodName: "web", ContainerName: "app", Timestamp: now.Add(-5 * time.Minute)},
+	}
+
+	if err := CheckMetricsAge(usages, time.Minute, now); err == nil {
+		t.Error("expected a 5 minute old sample to fail a 1m max age")
+	}
+	if err := CheckMetricsAge(usages, 10*time.Minute, now); err != nil {
+		t.Errorf("expected sample within 10m max age to pass, got %v", err)
+	}
+	if err := CheckMetricsAge(usages, 0, now); err != nil {
+		t.Errorf("expected zero max age to disable the check, got %v", err)
+	}
+}
+
+func TestAttachUsageNodes(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-a"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-b"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "other"}, Spec: v1.PodSpec{NodeName: "node-c"}},
+	)
+	usages := []ContainerUsage{
+		{Namespace: "default", PodName: "web", ContainerName: "app"},
+		{Namespace: "default", PodName: "web", ContainerName: "sidecar"},
+		{Namespace: "default", PodName: "db", ContainerName: "postgres"},
+		{Namespace: "other", PodName: "web", ContainerName: "app"},
+		{Namespace: "default", PodName: "deleted", ContainerName: "app"},
+	}
+
+	if err := AttachUsageNodes(context.Background(), client, "", usages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"node-a", "node-a", "node-b", "node-c", ""}
+	for i, u := range usages {
+		if u.NodeName != want[i] {
+			t.Errorf("%s/%s/%s: expected node %q, got %q", u.Namespace, u.PodName, u.ContainerName, want[i], u.NodeName)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ContainerWaste is a container whose requests exceed what right-sizing to
+// usage*(1+DefaultSuggestHeadroom) would keep.
+type ContainerWaste struct {
+	ContainerDiff
+
+	// Request minus the right-sized request; zero when the request is not above it
+	ReclaimableCPU resource.Quantity
+	ReclaimableMem resource.Quantity
+}
+
+// WasteReport sums the resources reclaimable by right-sizing over-provisioned containers.
+type WasteReport struct {
+	ReclaimableCPU resource.Quantity
+	ReclaimableMem resource.Quantity
+
+	// Offenders are the containers with anything to reclaim, largest CPU
+	// savings first, then largest memory savings
+	Offenders []ContainerWaste
+}
+
+// ComputeWaste right-sizes every container in diffs to its usage plus
+// DefaultSuggestHeadroom (see Recommend) and sums how much of its current
+// CPU and memory requests that would free. Containers without a request,
+// or requesting no more than the right-sized value, reclaim nothing.
+// Containers without a metrics sample are skipped, as Recommend skips them:
+// their zero usage would count the whole request as reclaimable.
+func ComputeWaste(diffs []ContainerDiff) WasteReport {
+	report := WasteReport{
+		ReclaimableCPU: *resource.NewMilliQuantity(0, resource.DecimalSI),
+		ReclaimableMem: *resource.NewQuantity(0, resource.BinarySI),
+	}
+
+	for _, rec := range Recommend(diffs, DefaultSuggestHeadroom) {
+		waste := ContainerWaste{
+			ContainerDiff:  rec.ContainerDiff,
+			ReclaimableCPU: *resource.NewMilliQuantity(0, resource.DecimalSI),
+			ReclaimableMem: *resource.NewQuantity(0, resource.BinarySI),
+		}
+		if rec.HasCPURequest && rec.CPURequest.Cmp(rec.SuggestedCPURequest) > 0 {
+			waste.ReclaimableCPU = rec.CPURequest.DeepCopy()
+			waste.ReclaimableCPU.Sub(rec.SuggestedCPURequest)
+		}
+		if rec.HasMemRequest && rec.MemRequest.Cmp(rec.SuggestedMemRequest) > 0 {
+			waste.ReclaimableMem = rec.MemRequest.DeepCopy()
+			waste.ReclaimableMem.Sub(rec.SuggestedMemRequest)
+		}
+		if waste.ReclaimableCPU.IsZero() && waste.ReclaimableMem.IsZero() {
+			continue
+		}
+
+		report.ReclaimableCPU.Add(waste.ReclaimableCPU)
+		report.ReclaimableMem.Add(waste.ReclaimableMem)
+		report.Offenders = append(report.Offenders, waste)
+	}
+
+	sort.SliceStable(report.Offenders, func(i, j int) bool {
+		a, b := report.Offenders[i], report.Offenders[j]
+		if c := a.ReclaimableCPU.Cmp(b.ReclaimableCPU); c != 0 {
+			return c > 0
+		}
+		return a.ReclaimableMem.Cmp(b.ReclaimableMem) > 0
+	})
+
+	return report
+}
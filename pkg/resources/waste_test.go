@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestComputeWaste_SumsReclaimableCPU(t *testing.T) {
+	diffs := []ContainerDiff{
+		// 1 CPU requested, 100m used: right-sized to 120m, 880m reclaimable
+		{PodName: "idle", HasUsage: true, CPURequest: resource.MustParse("1"), HasCPURequest: true, CPUUsage: resource.MustParse("100m")},
+		// 500m requested, 200m used: right-sized to 240m, 260m reclaimable
+		{PodName: "quiet", HasUsage: true, CPURequest: resource.MustParse("500m"), HasCPURequest: true, CPUUsage: resource.MustParse("200m")},
+		// Busier than its request: nothing to reclaim
+		{PodName: "busy", HasUsage: true, CPURequest: resource.MustParse("100m"), HasCPURequest: true, CPUUsage: resource.MustParse("300m")},
+		// No request: nothing to reclaim
+		{PodName: "unset", HasUsage: true, CPUUsage: resource.MustParse("50m")},
+	}
+
+	report := ComputeWaste(diffs)
+	if got := report.ReclaimableCPU.MilliValue(); got != 1140 {
+		t.Errorf("expected 1140m reclaimable CPU, got %dm", got)
+	}
+	if len(report.Offenders) != 2 {
+		t.Fatalf("expected 2 offenders, got %d", len(report.Offenders))
+	}
+	if report.Offenders[0].PodName != "idle" || report.Offenders[1].PodName != "quiet" {
+		t.Errorf("expected offenders sorted by reclaimable CPU (idle, quiet), got %s, %s",
+			report.Offenders[0].PodName, report.Offenders[1].PodName)
+	}
+}
+
+func TestComputeWaste_SkipsContainersWithoutMetrics(t *testing.T) {
+	request := resource.MustParse("500m")
+	inventory := []ContainerResources{
+		{Namespace: "default", PodName: "api", ContainerName: "migrate", IsInit: true, CPURequest: request, HasCPURequest: true},
+		{Namespace: "default", PodName: "api", ContainerName: "app", CPURequest: request, HasCPURequest: true},
+		{Namespace: "default", PodName: "pending", ContainerName: "app", CPURequest: request, HasCPURequest: true},
+	}
+	// Only the running app container has a metrics sample
+	usage := []ContainerUsage{
+		{Namespace: "default", PodName: "api", ContainerName: "app", CPUUsage: resource.MustParse("100m")},
+	}
+
+	report := ComputeWaste(BuildDiff(inventory, usage))
+	// 500m requested, right-sized to 120m
+	if got := report.ReclaimableCPU.MilliValue(); got != 380 {
+		t.Errorf("expected 380m reclaimable CPU from the measured container only, got %dm", got)
+	}
+	if len(report.Offenders) != 1 || report.Offenders[0].ContainerName != "app" || report.Offenders[0].PodName != "api" {
+		t.Errorf("expected only api/app as an offender, got %+v", report.Offenders)
+	}
+}